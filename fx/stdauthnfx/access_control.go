@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"buf.build/go/protovalidate"
+	"github.com/advdv/stdgo/stdenvcfg"
 	"github.com/advdv/stdgo/stdfx"
 	"github.com/cockroachdb/errors"
 	"github.com/lestrrat-go/httprc/v3"
@@ -37,9 +38,10 @@ type Config struct {
 
 // AccessControl manages API key signing/validation and access token verification.
 type AccessControl struct {
-	config    Config
-	validator protovalidate.Validator
-	hasher    func() hash.Hash
+	config     Config
+	reloadable *stdenvcfg.Reloadable[Config]
+	validator  protovalidate.Validator
+	hasher     func() hash.Hash
 
 	// api key signing and validation
 	apiKeys struct {
@@ -59,9 +61,10 @@ type AccessControl struct {
 func New(deps struct {
 	fx.In
 
-	Config    Config
-	Validator protovalidate.Validator
-	Hasher    func() hash.Hash `name:"api_key"`
+	Config     Config
+	Reloadable *stdenvcfg.Reloadable[Config] `optional:"true"`
+	Validator  protovalidate.Validator
+	Hasher     func() hash.Hash `name:"api_key"`
 },
 ) (res struct {
 	fx.Out
@@ -70,7 +73,9 @@ func New(deps struct {
 }, err error,
 ) {
 	cfg := deps.Config
-	res.AccessControl = &AccessControl{config: cfg, validator: deps.Validator, hasher: deps.Hasher}
+	res.AccessControl = &AccessControl{
+		config: cfg, reloadable: deps.Reloadable, validator: deps.Validator, hasher: deps.Hasher,
+	}
 
 	// setup keys for api key signing and validation.
 	{
@@ -120,6 +125,22 @@ func New(deps struct {
 	return res, nil
 }
 
+// anonymousAccessWhitelist returns the current whitelist for anonymous access, which may be reloaded while the
+// process is running.
+func (ac *AccessControl) anonymousAccessWhitelist() []string {
+	if ac.reloadable != nil {
+		return ac.reloadable.Get().AnonymousAccessWhitelist
+	}
+
+	return ac.config.AnonymousAccessWhitelist
+}
+
+// ProvideReloadable makes the anonymous access whitelist reloadable while the process is running. It requires the
+// reloader provided by [stdenvcfg.ProvideReloader].
+func ProvideReloadable() fx.Option {
+	return stdenvcfg.ProvideReloadable[Config]("STDAUTHN_")
+}
+
 // Provide returns an fx.Option that supplies AccessControl and its dependencies.
 func Provide() fx.Option {
 	return stdfx.ZapEnvCfgModule[Config]("stdauthn",
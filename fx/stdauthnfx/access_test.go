@@ -39,23 +39,7 @@ func setup(tb testing.TB, anonWhitelist []string, more ...any) (
 		AccessControl *stdauthnfx.AccessControl
 	}
 
-	jwksURL, accessToken := insecureaccesstools.NewJWKSServer(tb)
-
-	env := map[string]string{
-		// base64 encoded key for signing (well-known)
-		"STDAUTHN_SIGNING_KEY_SET_BASE64": base64.StdEncoding.EncodeToString(insecureaccesstools.WellKnownJWKS1),
-		"STDAUTHN_SIGNING_KEY_ID":         insecureaccesstools.WellKnownJWKS1KeyID,
-		// the endpoint from which to fetch the jwks for validation access tokens (local test server).
-		"STDAUTHN_TOKEN_VALIDATION_JWKS_ENDPOINT": jwksURL,
-		// the issuer for access tokens used in testing.
-		"STDAUTHN_TOKEN_ISSUER": insecureaccesstools.TestAccessTokenIssuer,
-		// the audience for access tokens used in audience.
-		"STDAUTHN_TOKEN_AUDIENCE": insecureaccesstools.TestAccessTokenAudience,
-		// set a fixed wall clock as far as access control is concerned (for making test tokens forever usable).
-		"STDAUTHN_FIXED_WALL_CLOCK_TIMESTAMP": "1760816072",
-		// anonymous access whitelist
-		"STDAUTHN_ANONYMOUS_ACCESS_WHITELIST": strings.Join(anonWhitelist, ","),
-	}
+	env, accessToken := testEnvironment(tb, anonWhitelist)
 
 	app := fxtest.New(tb,
 		stdzapfx.Fx(),
@@ -73,3 +57,24 @@ func setup(tb testing.TB, anonWhitelist []string, more ...any) (
 
 	return ctx, deps.AccessControl, accessToken
 }
+
+// testEnvironment returns the environment for testing access control, and an access token that is valid for it.
+func testEnvironment(tb testing.TB, anonWhitelist []string) (map[string]string, string) {
+	jwksURL, accessToken := insecureaccesstools.NewJWKSServer(tb)
+
+	return map[string]string{
+		// base64 encoded key for signing (well-known)
+		"STDAUTHN_SIGNING_KEY_SET_BASE64": base64.StdEncoding.EncodeToString(insecureaccesstools.WellKnownJWKS1),
+		"STDAUTHN_SIGNING_KEY_ID":         insecureaccesstools.WellKnownJWKS1KeyID,
+		// the endpoint from which to fetch the jwks for validation access tokens (local test server).
+		"STDAUTHN_TOKEN_VALIDATION_JWKS_ENDPOINT": jwksURL,
+		// the issuer for access tokens used in testing.
+		"STDAUTHN_TOKEN_ISSUER": insecureaccesstools.TestAccessTokenIssuer,
+		// the audience for access tokens used in audience.
+		"STDAUTHN_TOKEN_AUDIENCE": insecureaccesstools.TestAccessTokenAudience,
+		// set a fixed wall clock as far as access control is concerned (for making test tokens forever usable).
+		"STDAUTHN_FIXED_WALL_CLOCK_TIMESTAMP": "1760816072",
+		// anonymous access whitelist
+		"STDAUTHN_ANONYMOUS_ACCESS_WHITELIST": strings.Join(anonWhitelist, ","),
+	}, accessToken
+}
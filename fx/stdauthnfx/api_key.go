@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"strings"
+	"sync"
 
 	stdauthnfxv1 "github.com/advdv/stdgo/fx/stdauthnfx/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
 	"github.com/cockroachdb/errors"
 	"github.com/deatil/go-encoding/base62"
 	"github.com/lestrrat-go/jwx/v3/jwt"
@@ -52,6 +54,16 @@ func (ac *AccessControl) BuildAndSignAPIKey(acc *stdauthnfxv1.Access) (string, e
 	return APIKeyPrefix + base62.StdEncoding.EncodeToString(signed), nil
 }
 
+// SystemTokenSource returns a token source for [stdcrpcclient] clients that authenticates calls with system access.
+// The API key is signed once, on first use.
+func (ac *AccessControl) SystemTokenSource() stdcrpcclient.TokenSource {
+	key := sync.OnceValues(func() (string, error) {
+		return ac.BuildAndSignAPIKey(stdauthnfxv1.Access_builder{IsSystem: proto.Bool(true)}.Build())
+	})
+
+	return func(context.Context) (string, error) { return key() }
+}
+
 func (ac *AccessControl) authenticateAPIKey(ctx context.Context, apiKey string) (context.Context, error) {
 	noSuffixAPIKey := strings.TrimPrefix(apiKey, APIKeyPrefix)
 
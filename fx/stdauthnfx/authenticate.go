@@ -17,16 +17,17 @@ func (ac *AccessControl) Authenticate(ctx context.Context, rpcMethod, authzHeade
 
 	if authzHeader == "" {
 		// base on a whitelist in the environment, we allow anonymous access on some (or all) methods.
-		if checkWhiteList(rpcMethod, ac.config.AnonymousAccessWhitelist) {
+		whitelist := ac.anonymousAccessWhitelist()
+		if checkWhiteList(rpcMethod, whitelist) {
 			logs.Info("anonymous authentication",
 				zap.String("rpc_method", rpcMethod),
-				zap.Strings("whitelist", ac.config.AnonymousAccessWhitelist))
+				zap.Strings("whitelist", whitelist))
 			return WithAnonymousAccess(ctx, ac.validator), nil
 		}
 
 		logs.Info("no anonymous authentication",
 			zap.String("rpc_method", rpcMethod),
-			zap.Strings("whitelist", ac.config.AnonymousAccessWhitelist))
+			zap.Strings("whitelist", whitelist))
 
 		return ctx, errors.Errorf("no authorization header")
 	}
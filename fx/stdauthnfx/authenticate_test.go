@@ -4,9 +4,16 @@ import (
 	"strings"
 	"testing"
 
+	"buf.build/go/protovalidate"
 	"github.com/advdv/stdgo/fx/stdauthnfx"
 	stdauthnfxv1 "github.com/advdv/stdgo/fx/stdauthnfx/v1"
+	"github.com/advdv/stdgo/fx/stdzapfx"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdenvcfg"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -52,6 +59,22 @@ func TestAuthenticateAPIKey(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestSystemTokenSource(t *testing.T) {
+	t.Parallel()
+	ctx, ac, _ := setup(t, nil)
+
+	src := ac.SystemTokenSource()
+	key1, err := src(ctx)
+	require.NoError(t, err)
+	key2, err := src(ctx)
+	require.NoError(t, err)
+	require.Equal(t, key1, key2)
+
+	ctx, err = ac.Authenticate(ctx, "/acme.foo.v1.FooService/Bar", "Bearer "+key1)
+	require.NoError(t, err)
+	require.True(t, stdauthnfx.FromContext(ctx).GetIsSystem())
+}
+
 func TestAnonymousNoHeader(t *testing.T) {
 	t.Parallel()
 	ctx, ac, _ := setup(t, nil)
@@ -85,3 +108,37 @@ func TestAnonymousWildcard(t *testing.T) {
 	require.True(t, acc1.GetIsAnonymous())
 	require.Nil(t, acc1.GetWebuserIdentity())
 }
+
+func TestAnonymousReloadedWhitelist(t *testing.T) {
+	t.Parallel()
+
+	var deps struct {
+		fx.In
+		AccessControl *stdauthnfx.AccessControl
+		Reloader      *stdenvcfg.Reloader
+	}
+
+	env, _ := testEnvironment(t, nil)
+	app := fxtest.New(t,
+		stdzapfx.TestProvide(t),
+		stdenvcfg.ProvideExplicitEnvironment(env),
+		stdenvcfg.ProvideReloader(),
+		stdauthnfx.Provide(),
+		stdauthnfx.ProvideReloadable(),
+		fx.Provide(protovalidate.New),
+		fx.Populate(&deps))
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	ctx := stdctx.WithLogger(t.Context(), zap.NewNop())
+
+	_, err := deps.AccessControl.Authenticate(ctx, "/acme.foo.v1.FooService/Bar", "")
+	require.ErrorContains(t, err, "no authorization header")
+
+	env["STDAUTHN_ANONYMOUS_ACCESS_WHITELIST"] = "/acme.foo.v1.FooService/*"
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+
+	ctx, err = deps.AccessControl.Authenticate(ctx, "/acme.foo.v1.FooService/Bar", "")
+	require.NoError(t, err)
+	require.True(t, stdauthnfx.FromContext(ctx).GetIsAnonymous())
+}
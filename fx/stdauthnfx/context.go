@@ -46,6 +46,12 @@ func FromContext(ctx context.Context) *stdauthnfxv1.Access {
 	return v
 }
 
+// AccessFromContext retrieves the access information from the context, if present.
+func AccessFromContext(ctx context.Context) (*stdauthnfxv1.Access, bool) {
+	v, ok := ctx.Value(ctxKey("access")).(*stdauthnfxv1.Access)
+	return v, ok
+}
+
 // WithAPIKeyFingerprint stores the hashed API key fingerprint in the context.
 func WithAPIKeyFingerprint(ctx context.Context, hash hash.Hash, data []byte) context.Context {
 	if _, err := hash.Write(data); err != nil {
@@ -19,34 +19,65 @@ const (
 
 // DecorateEnvironment turns environment references to secret values into their actual secret value. This happens before
 // the environment is provided to the rest of the application. In order to trigger this behaviour the environment
-// variable needs to be encoded as "$$aws-secret-manager-resolve$$<secret_arn".
+// variable needs to be encoded as "$$aws-secret-manager-resolve$$<secret_arn". If the environment is layered, the
+// source of each resolved variable is recorded as the secret it was resolved from. Secrets are also resolved every
+// time reloadable configuration re-reads the environment, so rotated secrets are picked up without a redeploy.
 func DecorateEnvironment() fx.Option {
-	return fx.Decorate(func(env stdenvcfg.Environment, cache *secretcache.Cache) (stdenvcfg.Environment, error) {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-		defer cancel()
+	return fx.Options(
+		fx.Decorate(func(params struct {
+			fx.In
+			Env     stdenvcfg.Environment
+			Sources stdenvcfg.Sources `optional:"true"`
+			Cache   *secretcache.Cache
+		},
+		) (stdenvcfg.Environment, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
 
-		for key, val := range env {
-			if !strings.HasPrefix(val, resolvePrefix) {
-				continue
+			return params.Env, resolveSecrets(ctx, params.Cache, params.Env, params.Sources)
+		}),
+		fx.Decorate(func(load stdenvcfg.Loader, cache *secretcache.Cache) stdenvcfg.Loader {
+			return func(ctx context.Context) (stdenvcfg.Environment, stdenvcfg.Sources, error) {
+				env, sources, err := load(ctx)
+				if err != nil {
+					return env, sources, err
+				}
+
+				return env, sources, resolveSecrets(ctx, cache, env, sources)
 			}
+		}),
+	)
+}
 
-			secretID := strings.TrimPrefix(val, resolvePrefix)
-			secretID, jpath, hasJPath := strings.Cut(secretID, jsonPathSeparator)
+// resolveSecrets replaces all secret references in the environment with the secret value.
+func resolveSecrets(
+	ctx context.Context, cache *secretcache.Cache, env stdenvcfg.Environment, sources stdenvcfg.Sources,
+) error {
+	for key, val := range env {
+		if !strings.HasPrefix(val, resolvePrefix) {
+			continue
+		}
 
-			resolved, err := cache.GetSecretStringWithContext(ctx, secretID)
-			if err != nil {
-				return env, fmt.Errorf("failed to resolve secret %s: %w", secretID, err)
-			}
+		secretID := strings.TrimPrefix(val, resolvePrefix)
+		secretID, jpath, hasJPath := strings.Cut(secretID, jsonPathSeparator)
 
-			// if the string encodes a JSON path, resolve it instead of using the whole secret string. The secret string
-			// is now expected to be well-formatted JSON.
-			if hasJPath {
-				env[key] = gjson.Get(resolved, jpath).Str
-			} else {
-				env[key] = resolved
-			}
+		resolved, err := cache.GetSecretStringWithContext(ctx, secretID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secret %s: %w", secretID, err)
+		}
+
+		// if the string encodes a JSON path, resolve it instead of using the whole secret string. The secret string
+		// is now expected to be well-formatted JSON.
+		if hasJPath {
+			env[key] = gjson.Get(resolved, jpath).Str
+		} else {
+			env[key] = resolved
+		}
+
+		if sources != nil {
+			sources[key] = stdenvcfg.SecretSourcePrefix + "aws-secretsmanager:" + secretID
 		}
+	}
 
-		return env, nil
-	})
+	return nil
 }
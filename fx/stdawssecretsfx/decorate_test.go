@@ -17,7 +17,8 @@ func TestDecorate(t *testing.T) {
 
 	var deps struct {
 		fx.In
-		Env stdenvcfg.Environment
+		Env     stdenvcfg.Environment
+		Sources stdenvcfg.Sources
 	}
 
 	app := fxtest.New(t,
@@ -33,4 +34,50 @@ func TestDecorate(t *testing.T) {
 
 	require.Equal(t, "sosecret", deps.Env["FOO_BAR"])
 	require.Equal(t, "sosecret", deps.Env["FOO_BAR_JPATH"])
+	require.Equal(t, "secret:aws-secretsmanager:some:string:secret", deps.Sources["FOO_BAR"])
+}
+
+func TestDecorateWithoutLoader(t *testing.T) {
+	cache, err := secretcache.New(func(c *secretcache.Cache) { c.Client = client1{} })
+	require.NoError(t, err)
+
+	var deps struct {
+		fx.In
+		Env stdenvcfg.Environment
+	}
+
+	app := fxtest.New(t,
+		fx.Supply(stdenvcfg.Environment{"FOO_BAR": "$$aws-secret-manager-resolve$$some:string:secret"}),
+		stdawssecretsfx.DecorateEnvironment(),
+		fx.Populate(&deps),
+		fx.Supply(cache))
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	require.Equal(t, "sosecret", deps.Env["FOO_BAR"])
+}
+
+func TestDecorateReloadedEnvironment(t *testing.T) {
+	cache, err := secretcache.New(func(c *secretcache.Cache) { c.Client = client1{} })
+	require.NoError(t, err)
+
+	var deps struct {
+		fx.In
+		Load stdenvcfg.Loader
+	}
+
+	app := fxtest.New(t,
+		stdenvcfg.ProvideExplicitEnvironment(map[string]string{
+			"FOO_BAR": "$$aws-secret-manager-resolve$$some:string:secret",
+		}),
+		stdawssecretsfx.DecorateEnvironment(),
+		fx.Populate(&deps),
+		fx.Supply(cache))
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	env, sources, err := deps.Load(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "sosecret", env["FOO_BAR"])
+	require.Equal(t, "secret:aws-secretsmanager:some:string:secret", sources["FOO_BAR"])
 }
@@ -0,0 +1,76 @@
+// Package stdcrpcclientfx provides Connect RPC clients for calling other services, configured per target service.
+package stdcrpcclientfx
+
+import (
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
+	"github.com/advdv/stdgo/stdfx"
+	"go.uber.org/fx"
+)
+
+// Config configures the client of one target service.
+type Config struct {
+	// BaseURL of the target service, including the path that its RPC handlers are mounted on.
+	BaseURL string `env:"BASE_URL,required"`
+	// Timeout is the deadline of calls that don't have one already, including retries and hedges.
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s" validate:"min=0s"`
+	// MaxRetries of idempotent calls that fail with a retryable error, zero disables retries.
+	MaxRetries int `env:"MAX_RETRIES" envDefault:"3" validate:"min=0"`
+	// RetryMinBackoff is the delay before the first retry, it doubles for every retry after.
+	RetryMinBackoff time.Duration `env:"RETRY_MIN_BACKOFF" envDefault:"50ms" validate:"min=1ms"`
+	// RetryMaxBackoff caps the delay between retries.
+	RetryMaxBackoff time.Duration `env:"RETRY_MAX_BACKOFF" envDefault:"2s" validate:"min=1ms"`
+	// HedgeDelay is how long a side-effect free call may take before another request is sent, zero disables it.
+	HedgeDelay time.Duration `env:"HEDGE_DELAY" validate:"min=0s"`
+	// MaxHedges is the maximum number of extra requests that are sent for one call.
+	MaxHedges int `env:"MAX_HEDGES" envDefault:"1" validate:"min=0"`
+}
+
+// Options returns the client options for the configuration.
+func (cfg Config) Options() []stdcrpcclient.Option {
+	opts := []stdcrpcclient.Option{stdcrpcclient.WithTimeout(cfg.Timeout)}
+	if cfg.MaxRetries > 0 {
+		opts = append(opts, stdcrpcclient.WithRetries(cfg.MaxRetries, cfg.RetryMinBackoff, cfg.RetryMaxBackoff))
+	}
+
+	if cfg.HedgeDelay > 0 {
+		opts = append(opts, stdcrpcclient.WithHedging(cfg.HedgeDelay, cfg.MaxHedges))
+	}
+
+	return opts
+}
+
+// Provide provides the client of the target service with the given name, it is configured through the environment
+// with the STDCRPCCLIENT_<NAME>_ prefix. A token source that is provided with [ProvideTokenSource] for the same
+// name authenticates the calls. The options are applied after the configuration.
+func Provide[C any](
+	name string,
+	newClient func(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) C,
+	opts ...stdcrpcclient.Option,
+) fx.Option {
+	return stdfx.NamedNoProvideZapEnvCfgModule[Config]("stdcrpcclient", name,
+		fx.Provide(fx.Annotate(
+			func(cfg Config, tokens stdcrpcclient.TokenSource) C {
+				clientOpts := cfg.Options()
+				if tokens != nil {
+					clientOpts = append(clientOpts, stdcrpcclient.WithTokenSource(tokens))
+				}
+
+				return stdcrpcclient.New(newClient, cfg.BaseURL, append(clientOpts, opts...)...)
+			},
+			fx.ParamTags(tag(name), tag(name)+` optional:"true"`),
+		)),
+	)
+}
+
+// ProvideTokenSource provides the token source for the client of the target service with the given name. The
+// constructor returns the [stdcrpcclient.TokenSource], e.g.:
+//
+//	stdcrpcclientfx.ProvideTokenSource("billing", (*stdauthnfx.AccessControl).SystemTokenSource)
+func ProvideTokenSource(name string, newTokenSource any) fx.Option {
+	return fx.Provide(fx.Annotate(newTokenSource, fx.ResultTags(tag(name))))
+}
+
+func tag(name string) string { return `name:"` + name + `"` }
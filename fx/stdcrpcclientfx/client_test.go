@@ -0,0 +1,87 @@
+package stdcrpcclientfx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdcrpcclientfx"
+	internalv1 "github.com/advdv/stdgo/fx/stdcrpcclientfx/internal/v1"
+	"github.com/advdv/stdgo/fx/stdcrpcclientfx/internal/v1/internalv1connect"
+	"github.com/advdv/stdgo/fx/stdzapfx"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/protobuf/proto"
+)
+
+// testService fails the first call as unavailable.
+type testService struct{ attempts atomic.Int32 }
+
+func (s *testService) WhoAmI(
+	_ context.Context, req *connect.Request[internalv1.WhoAmIRequest],
+) (*connect.Response[internalv1.WhoAmIResponse], error) {
+	attempt := s.attempts.Add(1)
+	if attempt == 1 {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+	}
+
+	return connect.NewResponse(internalv1.WhoAmIResponse_builder{
+		Authorization: proto.String(req.Header().Get("Authorization")),
+		Attempt:       proto.Int32(attempt),
+	}.Build()), nil
+}
+
+func setup(tb testing.TB, env map[string]string, opts ...fx.Option) internalv1connect.TestServiceClient {
+	tb.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(&testService{}))
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	env["STDCRPCCLIENT_FOO_BAR_BASE_URL"] = srv.URL
+
+	var cln internalv1connect.TestServiceClient
+	app := fxtest.New(tb,
+		stdzapfx.Fx(),
+		stdzapfx.TestProvide(tb),
+		stdenvcfg.ProvideExplicitEnvironment(env),
+		stdcrpcclientfx.Provide("foo_bar", internalv1connect.NewTestServiceClient),
+		fx.Options(opts...),
+		fx.Populate(&cln))
+	app.RequireStart()
+	tb.Cleanup(app.RequireStop)
+
+	return cln
+}
+
+func TestProvide(t *testing.T) {
+	t.Parallel()
+
+	cln := setup(t, map[string]string{"STDCRPCCLIENT_FOO_BAR_RETRY_MIN_BACKOFF": "1ms"},
+		stdcrpcclientfx.ProvideTokenSource("foo_bar", func() stdcrpcclient.TokenSource {
+			return stdcrpcclient.StaticToken("tok1")
+		}))
+
+	resp, err := cln.WhoAmI(t.Context(), connect.NewRequest(internalv1.WhoAmIRequest_builder{}.Build()))
+	require.NoError(t, err)
+	require.Equal(t, "Bearer tok1", resp.Msg.GetAuthorization())
+	require.EqualValues(t, 2, resp.Msg.GetAttempt())
+}
+
+func TestProvideNoRetries(t *testing.T) {
+	t.Parallel()
+
+	cln := setup(t, map[string]string{"STDCRPCCLIENT_FOO_BAR_MAX_RETRIES": "0"})
+
+	_, err := cln.WhoAmI(t.Context(), connect.NewRequest(internalv1.WhoAmIRequest_builder{}.Build()))
+	require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+}
@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: fx/stdcrpcclientfx/internal/v1/internal.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WhoAmIRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIRequest) Reset() {
+	*x = WhoAmIRequest{}
+	mi := &file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIRequest) ProtoMessage() {}
+
+func (x *WhoAmIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type WhoAmIRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 WhoAmIRequest_builder) Build() *WhoAmIRequest {
+	m0 := &WhoAmIRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type WhoAmIResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Authorization *string                `protobuf:"bytes,1,opt,name=authorization"`
+	xxx_hidden_Attempt       int32                  `protobuf:"varint,2,opt,name=attempt"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *WhoAmIResponse) Reset() {
+	*x = WhoAmIResponse{}
+	mi := &file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIResponse) ProtoMessage() {}
+
+func (x *WhoAmIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WhoAmIResponse) GetAuthorization() string {
+	if x != nil {
+		if x.xxx_hidden_Authorization != nil {
+			return *x.xxx_hidden_Authorization
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WhoAmIResponse) GetAttempt() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempt
+	}
+	return 0
+}
+
+func (x *WhoAmIResponse) SetAuthorization(v string) {
+	x.xxx_hidden_Authorization = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WhoAmIResponse) SetAttempt(v int32) {
+	x.xxx_hidden_Attempt = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *WhoAmIResponse) HasAuthorization() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WhoAmIResponse) HasAttempt() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WhoAmIResponse) ClearAuthorization() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Authorization = nil
+}
+
+func (x *WhoAmIResponse) ClearAttempt() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Attempt = 0
+}
+
+type WhoAmIResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Authorization *string
+	Attempt       *int32
+}
+
+func (b0 WhoAmIResponse_builder) Build() *WhoAmIResponse {
+	m0 := &WhoAmIResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Authorization != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Authorization = b.Authorization
+	}
+	if b.Attempt != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Attempt = *b.Attempt
+	}
+	return m0
+}
+
+var File_fx_stdcrpcclientfx_internal_v1_internal_proto protoreflect.FileDescriptor
+
+const file_fx_stdcrpcclientfx_internal_v1_internal_proto_rawDesc = "" +
+	"\n" +
+	"-fx/stdcrpcclientfx/internal/v1/internal.proto\x12\x1efx.stdcrpcclientfx.internal.v1\"\x0f\n" +
+	"\rWhoAmIRequest\"P\n" +
+	"\x0eWhoAmIResponse\x12$\n" +
+	"\rauthorization\x18\x01 \x01(\tR\rauthorization\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\x05R\aattempt2{\n" +
+	"\vTestService\x12l\n" +
+	"\x06WhoAmI\x12-.fx.stdcrpcclientfx.internal.v1.WhoAmIRequest\x1a..fx.stdcrpcclientfx.internal.v1.WhoAmIResponse\"\x03\x90\x02\x01B\x90\x02\n" +
+	"\"com.fx.stdcrpcclientfx.internal.v1B\rInternalProtoP\x01Z@github.com/advdv/stdgo/fx/stdcrpcclientfx/internal/v1;internalv1\xa2\x02\x03FSI\xaa\x02\x1eFx.Stdcrpcclientfx.Internal.V1\xca\x02\x1eFx\\Stdcrpcclientfx\\Internal\\V1\xe2\x02*Fx\\Stdcrpcclientfx\\Internal\\V1\\GPBMetadata\xea\x02!Fx::Stdcrpcclientfx::Internal::V1b\beditionsp\xe8\a"
+
+var file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_fx_stdcrpcclientfx_internal_v1_internal_proto_goTypes = []any{
+	(*WhoAmIRequest)(nil),  // 0: fx.stdcrpcclientfx.internal.v1.WhoAmIRequest
+	(*WhoAmIResponse)(nil), // 1: fx.stdcrpcclientfx.internal.v1.WhoAmIResponse
+}
+var file_fx_stdcrpcclientfx_internal_v1_internal_proto_depIdxs = []int32{
+	0, // 0: fx.stdcrpcclientfx.internal.v1.TestService.WhoAmI:input_type -> fx.stdcrpcclientfx.internal.v1.WhoAmIRequest
+	1, // 1: fx.stdcrpcclientfx.internal.v1.TestService.WhoAmI:output_type -> fx.stdcrpcclientfx.internal.v1.WhoAmIResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_fx_stdcrpcclientfx_internal_v1_internal_proto_init() }
+func file_fx_stdcrpcclientfx_internal_v1_internal_proto_init() {
+	if File_fx_stdcrpcclientfx_internal_v1_internal_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fx_stdcrpcclientfx_internal_v1_internal_proto_rawDesc), len(file_fx_stdcrpcclientfx_internal_v1_internal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fx_stdcrpcclientfx_internal_v1_internal_proto_goTypes,
+		DependencyIndexes: file_fx_stdcrpcclientfx_internal_v1_internal_proto_depIdxs,
+		MessageInfos:      file_fx_stdcrpcclientfx_internal_v1_internal_proto_msgTypes,
+	}.Build()
+	File_fx_stdcrpcclientfx_internal_v1_internal_proto = out.File
+	file_fx_stdcrpcclientfx_internal_v1_internal_proto_goTypes = nil
+	file_fx_stdcrpcclientfx_internal_v1_internal_proto_depIdxs = nil
+}
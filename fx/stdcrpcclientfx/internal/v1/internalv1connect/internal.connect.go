@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: fx/stdcrpcclientfx/internal/v1/internal.proto
+
+package internalv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/advdv/stdgo/fx/stdcrpcclientfx/internal/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TestServiceName is the fully-qualified name of the TestService service.
+	TestServiceName = "fx.stdcrpcclientfx.internal.v1.TestService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TestServiceWhoAmIProcedure is the fully-qualified name of the TestService's WhoAmI RPC.
+	TestServiceWhoAmIProcedure = "/fx.stdcrpcclientfx.internal.v1.TestService/WhoAmI"
+)
+
+// TestServiceClient is a client for the fx.stdcrpcclientfx.internal.v1.TestService service.
+type TestServiceClient interface {
+	WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error)
+}
+
+// NewTestServiceClient constructs a client for the fx.stdcrpcclientfx.internal.v1.TestService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTestServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	testServiceMethods := v1.File_fx_stdcrpcclientfx_internal_v1_internal_proto.Services().ByName("TestService").Methods()
+	return &testServiceClient{
+		whoAmI: connect.NewClient[v1.WhoAmIRequest, v1.WhoAmIResponse](
+			httpClient,
+			baseURL+TestServiceWhoAmIProcedure,
+			connect.WithSchema(testServiceMethods.ByName("WhoAmI")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// testServiceClient implements TestServiceClient.
+type testServiceClient struct {
+	whoAmI *connect.Client[v1.WhoAmIRequest, v1.WhoAmIResponse]
+}
+
+// WhoAmI calls fx.stdcrpcclientfx.internal.v1.TestService.WhoAmI.
+func (c *testServiceClient) WhoAmI(ctx context.Context, req *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error) {
+	return c.whoAmI.CallUnary(ctx, req)
+}
+
+// TestServiceHandler is an implementation of the fx.stdcrpcclientfx.internal.v1.TestService
+// service.
+type TestServiceHandler interface {
+	WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error)
+}
+
+// NewTestServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTestServiceHandler(svc TestServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	testServiceMethods := v1.File_fx_stdcrpcclientfx_internal_v1_internal_proto.Services().ByName("TestService").Methods()
+	testServiceWhoAmIHandler := connect.NewUnaryHandler(
+		TestServiceWhoAmIProcedure,
+		svc.WhoAmI,
+		connect.WithSchema(testServiceMethods.ByName("WhoAmI")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/fx.stdcrpcclientfx.internal.v1.TestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TestServiceWhoAmIProcedure:
+			testServiceWhoAmIHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTestServiceHandler struct{}
+
+func (UnimplementedTestServiceHandler) WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fx.stdcrpcclientfx.internal.v1.TestService.WhoAmI is not implemented"))
+}
@@ -2,7 +2,9 @@ package stdcrpcenttenancyfx
 
 import (
 	"context"
+	"database/sql"
 
+	"github.com/advdv/stdgo/stdctx"
 	"github.com/advdv/stdgo/stdent"
 )
 
@@ -40,6 +42,14 @@ import (
 // The inp / fn shape (taking a typed input + returning a typed output)
 // mirrors common request/response transactional helpers.
 //
+// When T can execute raw sql (see [stdent.WTx.ExecContext]), the
+// output is passed to the [stdctx.TxRecorder] on ctx (if any) before
+// the tx commits — this is how idempotency keys get recorded in the
+// same tx as the handler's writes. The recorder decides what to keep:
+// stdidempotencyfx only records the handler's response message from a
+// read-write tx, and ignores other outputs. The other Transact*
+// variants don't record.
+//
 // Going around Transact (e.g. calling [stdent.Transact1] directly with
 // the same transactor) is detected at runtime: see [managedTxKey] and
 // the check at the top of [Authorize.BeginHook]. Such a tx will be
@@ -59,8 +69,33 @@ func Transact[T stdent.Tx, I any, O any, IP interface{ *I }, OP interface{ *O }]
 	}
 
 	return stdent.Transact1(ctx, txr, func(ctx context.Context, tx T) (OP, error) {
-		return fn(ctx, tx, inp)
+		out, err := fn(ctx, tx, inp)
+		if err != nil {
+			return out, err
+		}
+
+		return out, recordInTx(ctx, tx, out)
+	})
+}
+
+// recordInTx hands out to the [stdctx.TxRecorder] on ctx, if tx can
+// execute raw sql.
+func recordInTx(ctx context.Context, tx any, out any) error {
+	execer, ok := tx.(interface {
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	})
+	if !ok {
+		return nil
+	}
+
+	return stdctx.RecordInTx(ctx, func(ctx context.Context, query string, args ...any) (int64, error) {
+		res, err := execer.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return res.RowsAffected()
+	}, out)
 }
 
 // Transact0 is [Transact] for the common case where the inner function
@@ -0,0 +1,296 @@
+// Package stdhealthfx provides a registry of liveness and readiness checks that other modules contribute to.
+package stdhealthfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/advdv/stdgo/stdfx"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Config configures the health registry.
+type Config struct {
+	// DefaultTimeout is used for checks that don't specify their own timeout.
+	DefaultTimeout time.Duration `env:"DEFAULT_TIMEOUT" envDefault:"2s" validate:"min=1ms"`
+	// CacheTTL determines how long a report is re-used, so frequent probes don't overload dependencies.
+	CacheTTL time.Duration `env:"CACHE_TTL" envDefault:"5s" validate:"min=0s"`
+}
+
+// Kind of health check.
+type Kind string
+
+const (
+	// Liveness checks determine if the process should be restarted.
+	Liveness Kind = "liveness"
+	// Readiness checks determine if the process should receive traffic.
+	Readiness Kind = "readiness"
+)
+
+// Check is a named health check that modules contribute to the registry.
+type Check struct {
+	// Name identifies the check in the report, it must be unique per kind.
+	Name string
+	// Kind of check, either liveness or readiness.
+	Kind Kind
+	// Timeout for running the check, the registry's default timeout is used when zero.
+	Timeout time.Duration
+	// Critical checks fail the endpoint when they fail, other checks only mark the report as degraded.
+	Critical bool
+	// Run performs the check.
+	Run func(ctx context.Context) error
+}
+
+// Status of a check or report.
+type Status string
+
+const (
+	// StatusPass means the check succeeded.
+	StatusPass Status = "pass"
+	// StatusWarn means a non-critical check failed.
+	StatusWarn Status = "warn"
+	// StatusFail means a critical check failed.
+	StatusFail Status = "fail"
+)
+
+// Result of running a single check.
+type Result struct {
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report of running all checks of one kind.
+type Report struct {
+	Kind      Kind      `json:"kind"`
+	Status    Status    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Result  `json:"checks"`
+}
+
+// Registry runs the contributed health checks and caches the results.
+type Registry struct {
+	cfg    Config
+	logs   *zap.Logger
+	checks map[Kind][]Check
+
+	mu      sync.Mutex
+	flights map[Kind]*flight
+	cached  map[Kind]Report
+}
+
+// flight is a run of all checks of one kind that concurrent callers wait for.
+type flight struct {
+	done   chan struct{}
+	report Report
+}
+
+// New inits the registry from all checks in the "health_checks" group.
+func New(deps struct {
+	fx.In
+
+	Config Config
+	Logs   *zap.Logger
+	Checks []Check `group:"health_checks"`
+},
+) (*Registry, error) {
+	reg := &Registry{
+		cfg:     deps.Config,
+		logs:    deps.Logs,
+		checks:  map[Kind][]Check{},
+		flights: map[Kind]*flight{},
+		cached:  map[Kind]Report{},
+	}
+
+	seen := map[Kind]map[string]bool{Liveness: {}, Readiness: {}}
+	for _, check := range deps.Checks {
+		switch {
+		case seen[check.Kind] == nil:
+			return nil, fmt.Errorf("health check %q has unsupported kind: %q", check.Name, check.Kind)
+		case check.Name == "":
+			return nil, errors.New("health check without a name")
+		case seen[check.Kind][check.Name]:
+			return nil, fmt.Errorf("duplicate %s check: %q", check.Kind, check.Name)
+		case check.Run == nil:
+			return nil, fmt.Errorf("health check %q has no run function", check.Name)
+		}
+
+		seen[check.Kind][check.Name] = true
+		reg.checks[check.Kind] = append(reg.checks[check.Kind], check)
+	}
+
+	// group order is not deterministic, sort so reports are stable.
+	for _, checks := range reg.checks {
+		slices.SortFunc(checks, func(a, b Check) int { return strings.Compare(a.Name, b.Name) })
+	}
+
+	return reg, nil
+}
+
+// Check runs all checks of the kind, or returns the cached report if it is recent enough. Concurrent callers share
+// the same run of the checks, so the checks don't observe the cancellation of any single caller. No lock is held
+// while the checks run, a caller whose context is done before the run completes gets a failed report without results.
+func (reg *Registry) Check(ctx context.Context, kind Kind) Report {
+	if kind != Liveness && kind != Readiness {
+		return Report{Kind: kind, Status: StatusPass, CheckedAt: time.Now(), Checks: []Result{}}
+	}
+
+	reg.mu.Lock()
+	if cached, ok := reg.cached[kind]; ok && time.Since(cached.CheckedAt) < reg.cfg.CacheTTL {
+		reg.mu.Unlock()
+
+		return cached
+	}
+
+	flt, ok := reg.flights[kind]
+	if !ok {
+		flt = &flight{done: make(chan struct{})}
+		reg.flights[kind] = flt
+
+		go reg.fly(context.WithoutCancel(ctx), kind, flt)
+	}
+	reg.mu.Unlock()
+
+	select {
+	case <-flt.done:
+		return flt.report
+	case <-ctx.Done():
+		return Report{Kind: kind, Status: StatusFail, CheckedAt: time.Now(), Checks: []Result{}}
+	}
+}
+
+// fly runs all checks of the kind concurrently and caches the report.
+func (reg *Registry) fly(ctx context.Context, kind Kind, flt *flight) {
+	report := Report{
+		Kind:      kind,
+		Status:    StatusPass,
+		CheckedAt: time.Now(),
+		Checks:    make([]Result, len(reg.checks[kind])),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range reg.checks[kind] {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			report.Checks[i] = reg.run(ctx, check)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		switch {
+		case res.Status == StatusPass:
+		case res.Critical:
+			report.Status = StatusFail
+		case report.Status == StatusPass:
+			report.Status = StatusWarn
+		}
+	}
+
+	reg.mu.Lock()
+	reg.cached[kind] = report
+	delete(reg.flights, kind)
+	reg.mu.Unlock()
+
+	flt.report = report
+	close(flt.done)
+}
+
+// run a single check with its timeout, a panicking check is reported as failed.
+func (reg *Registry) run(ctx context.Context, check Check) (res Result) {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = reg.cfg.DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	res = Result{Name: check.Name, Status: StatusPass, Critical: check.Critical}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			res.Status, res.Error = StatusFail, fmt.Sprintf("panic: %v", rec)
+		}
+
+		if res.Status != StatusPass && !check.Critical {
+			res.Status = StatusWarn
+		}
+
+		res.Duration = time.Since(start).String()
+
+		if res.Error != "" {
+			reg.logs.Warn("health check failed",
+				zap.String("kind", string(check.Kind)),
+				zap.String("name", check.Name),
+				zap.Bool("critical", check.Critical),
+				zap.String("error", res.Error))
+		}
+	}()
+
+	if err := check.Run(ctx); err != nil {
+		res.Status, res.Error = StatusFail, err.Error()
+	}
+
+	return res
+}
+
+// Handler serves the report of all checks of the kind as JSON. It responds with 503 when a critical check failed.
+func (reg *Registry) Handler(kind Kind) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := reg.Check(r.Context(), kind)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if report.Status == StatusFail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			reg.logs.Error("failed to encode health report", zap.Error(err))
+		}
+	})
+}
+
+// Livez serves the liveness report.
+func (reg *Registry) Livez() http.Handler { return reg.Handler(Liveness) }
+
+// Readyz serves the readiness report.
+func (reg *Registry) Readyz() http.Handler { return reg.Handler(Readiness) }
+
+// Mount registers the "/livez" and "/readyz" endpoints on the mux.
+func (reg *Registry) Mount(mux *http.ServeMux) {
+	mux.Handle("/livez", reg.Livez())
+	mux.Handle("/readyz", reg.Readyz())
+}
+
+// AsCheck annotates a constructor of a [Check] so it is contributed to the registry.
+func AsCheck(f any) any {
+	return fx.Annotate(f, fx.ResultTags(`group:"health_checks"`))
+}
+
+// ProvideCheck contributes a check without dependencies to the registry.
+func ProvideCheck(check Check) fx.Option {
+	return fx.Supply(fx.Annotated{Group: "health_checks", Target: check})
+}
+
+// Provide the health registry.
+func Provide() fx.Option {
+	return stdfx.ZapEnvCfgModule[Config]("stdhealth", New)
+}
@@ -0,0 +1,169 @@
+package stdhealthfx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/advdv/stdgo/fx/stdhealthfx"
+	"github.com/advdv/stdgo/fx/stdzapfx"
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type dependency struct {
+	calls   atomic.Int32
+	healthy atomic.Bool
+}
+
+func (d *dependency) ping(context.Context) error {
+	d.calls.Add(1)
+	if !d.healthy.Load() {
+		return errors.New("unreachable")
+	}
+
+	return nil
+}
+
+func setup(tb testing.TB, env map[string]string, opts ...fx.Option) (reg *stdhealthfx.Registry) {
+	tb.Helper()
+
+	app := fxtest.New(tb, append(opts,
+		stdenvcfg.ProvideExplicitEnvironment(env),
+		stdzapfx.Fx(),
+		stdzapfx.TestProvide(tb),
+		stdhealthfx.Provide(),
+		fx.Populate(&reg),
+	)...)
+	app.RequireStart()
+	tb.Cleanup(app.RequireStop)
+
+	return reg
+}
+
+func serve(tb testing.TB, hdlr http.Handler) (int, stdhealthfx.Report) {
+	tb.Helper()
+
+	rec := httptest.NewRecorder()
+	hdlr.ServeHTTP(rec, httptest.NewRequestWithContext(tb.Context(), http.MethodGet, "/", nil))
+
+	var report stdhealthfx.Report
+	require.NoError(tb, json.NewDecoder(rec.Body).Decode(&report))
+
+	return rec.Code, report
+}
+
+func TestReadyz(t *testing.T) {
+	t.Parallel()
+
+	db, cache := &dependency{}, &dependency{}
+	db.healthy.Store(true)
+
+	reg := setup(t, map[string]string{"STDHEALTH_CACHE_TTL": "0s"},
+		fx.Supply(db),
+		fx.Provide(stdhealthfx.AsCheck(func(db *dependency) stdhealthfx.Check {
+			return stdhealthfx.Check{Name: "db", Kind: stdhealthfx.Readiness, Critical: true, Run: db.ping}
+		})),
+		stdhealthfx.ProvideCheck(stdhealthfx.Check{Name: "cache", Kind: stdhealthfx.Readiness, Run: cache.ping}),
+		stdhealthfx.ProvideCheck(stdhealthfx.Check{
+			Name: "slow", Kind: stdhealthfx.Liveness, Timeout: time.Millisecond, Critical: true,
+			Run: func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() },
+		}),
+	)
+
+	code, report := serve(t, reg.Readyz())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, stdhealthfx.StatusWarn, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "cache", report.Checks[0].Name)
+	assert.Equal(t, stdhealthfx.StatusWarn, report.Checks[0].Status)
+	assert.Equal(t, "unreachable", report.Checks[0].Error)
+	assert.Equal(t, stdhealthfx.StatusPass, report.Checks[1].Status)
+
+	db.healthy.Store(false)
+
+	code, report = serve(t, reg.Readyz())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, stdhealthfx.StatusFail, report.Status)
+
+	code, report = serve(t, reg.Livez())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "context deadline exceeded", report.Checks[0].Error)
+}
+
+func TestReportCaching(t *testing.T) {
+	t.Parallel()
+
+	db := &dependency{}
+	reg := setup(t, map[string]string{"STDHEALTH_CACHE_TTL": "1h"},
+		stdhealthfx.ProvideCheck(stdhealthfx.Check{Name: "db", Kind: stdhealthfx.Readiness, Run: db.ping}))
+
+	for range 5 {
+		reg.Check(t.Context(), stdhealthfx.Readiness)
+	}
+
+	assert.Equal(t, int32(1), db.calls.Load())
+}
+
+func TestSlowCheck(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	reg := setup(t, map[string]string{"STDHEALTH_CACHE_TTL": "1h"},
+		stdhealthfx.ProvideCheck(stdhealthfx.Check{
+			Name: "slow", Kind: stdhealthfx.Readiness, Timeout: time.Minute,
+			Run: func(context.Context) error { calls.Add(1); <-release; return nil },
+		}))
+
+	// a caller that gives up doesn't wait for the slow check.
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	report := reg.Check(ctx, stdhealthfx.Readiness)
+	assert.Equal(t, stdhealthfx.StatusFail, report.Status)
+	assert.Empty(t, report.Checks)
+
+	// callers share the run that is still in flight, or the report it cached.
+	reports := make(chan stdhealthfx.Report)
+	for range 3 {
+		go func() { reports <- reg.Check(t.Context(), stdhealthfx.Readiness) }()
+	}
+
+	close(release)
+
+	for range 3 {
+		report := <-reports
+		assert.Equal(t, stdhealthfx.StatusPass, report.Status)
+		assert.Len(t, report.Checks, 1)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDuplicateCheck(t *testing.T) {
+	t.Parallel()
+
+	check := stdhealthfx.Check{Name: "db", Kind: stdhealthfx.Readiness, Run: (&dependency{}).ping}
+	app := fx.New(
+		stdenvcfg.ProvideExplicitEnvironment(nil),
+		stdzapfx.Fx(),
+		stdzapfx.TestProvide(t),
+		stdhealthfx.Provide(),
+		stdhealthfx.ProvideCheck(check),
+		stdhealthfx.ProvideCheck(check),
+		fx.Invoke(func(*stdhealthfx.Registry) {}),
+	)
+
+	require.ErrorContains(t, app.Err(), `duplicate readiness check: "db"`)
+}
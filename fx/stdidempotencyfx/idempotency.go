@@ -0,0 +1,297 @@
+// Package stdidempotencyfx makes retries of side-effecting Connect procedures safe with an Idempotency-Key header.
+// The first response for a key is recorded, and replayed for retries with the same key. Only the response message is
+// recorded: a replayed response carries the Idempotent-Replayed header, but not the headers and trailers that the
+// handler set on the original response.
+package stdidempotencyfx
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdauthnfx"
+	"github.com/advdv/stdgo/fx/stdcrpcauthfx"
+	"github.com/advdv/stdgo/stdcrpc"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdfx"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// HeaderName is the request header that carries the idempotency key.
+	HeaderName = "Idempotency-Key"
+	// ReplayedHeaderName is set to "true" on responses that are replayed from a recorded response.
+	ReplayedHeaderName = "Idempotent-Replayed"
+)
+
+// ErrKeyInUse is returned when recording a response for a key that was recorded by another request. The
+// transaction it was returned in should be rolled back, the interceptor then replays the other request's response.
+var ErrKeyInUse = errors.New("idempotency key is in use by another request")
+
+// Config configures the idempotency interceptor.
+type Config struct {
+	// TTL is how long recorded responses are replayed. After that a key may be reused.
+	TTL time.Duration `env:"TTL" envDefault:"24h"`
+	// MaxKeyLength is the maximum length of an idempotency key, longer keys are rejected.
+	MaxKeyLength int `env:"MAX_KEY_LENGTH" envDefault:"255" validate:"min=1"`
+}
+
+// Record is a recorded response for an idempotency key.
+type Record struct {
+	// Scope separates the keys of different callers, see [ScopeFunc].
+	Scope string
+	// Key is the idempotency key as sent by the client.
+	Key string
+	// Owner identifies the request that recorded the response, so it may overwrite its own record.
+	Owner string
+	// Procedure is the Connect procedure that was called.
+	Procedure string
+	// RequestHash is the hash of the procedure and request message, to detect keys that are reused for another
+	// request.
+	RequestHash []byte
+	// Response is the serialized response message.
+	Response []byte
+}
+
+// Store records and looks up responses. Implementations must be safe for concurrent use.
+type Store interface {
+	// Lookup returns the record for the key, if it was recorded within the ttl.
+	Lookup(ctx context.Context, scope, key string, ttl time.Duration) (Record, bool, error)
+	// Save records the response. It returns [ErrKeyInUse] if the key was recorded by another owner within the ttl.
+	Save(ctx context.Context, rec Record, ttl time.Duration) error
+}
+
+// ScopeFunc determines the scope of idempotency keys, so callers can't replay each other's responses.
+type ScopeFunc func(ctx context.Context) string
+
+// ScopeBySubject scopes keys by the authenticated tenant and subject, or the API key fingerprint. Anonymous callers
+// share the empty scope.
+func ScopeBySubject(ctx context.Context) string {
+	claims := stdcrpcauthfx.ClaimsFromContext(ctx)
+	if claims.Subject != "" {
+		return claims.TenantID + "|" + claims.Subject
+	}
+
+	if access, ok := stdauthnfx.AccessFromContext(ctx); ok && access.HasWebuserIdentity() {
+		return "|" + access.GetWebuserIdentity().GetSubject()
+	}
+
+	if fp, ok := stdauthnfx.APIKeyFingerprint(ctx); ok {
+		return "api_key|" + hex.EncodeToString(fp)
+	}
+
+	return ""
+}
+
+// Idempotency provides the interceptor.
+type Idempotency struct {
+	cfg   Config
+	logs  *zap.Logger
+	store Store
+	scope ScopeFunc
+}
+
+// New inits the idempotency interceptor. Without a ScopeFunc provided, keys are scoped with [ScopeBySubject].
+func New(deps struct {
+	fx.In
+
+	Config Config
+	Logs   *zap.Logger
+	Store  Store
+	Scope  ScopeFunc `optional:"true"`
+},
+) (*Idempotency, error) {
+	idem := &Idempotency{cfg: deps.Config, logs: deps.Logs, store: deps.Store, scope: deps.Scope}
+	if idem.scope == nil {
+		idem.scope = ScopeBySubject
+	}
+
+	return idem, nil
+}
+
+// Provide the idempotency interceptor with a [PostgresStore] on the stdpgxfx pool with the given name.
+func Provide(poolName string) fx.Option {
+	return stdfx.ZapEnvCfgModule[Config]("stdidempotency", New,
+		ProvidePostgresStore(poolName))
+}
+
+// pending is the record the handler should write in its transaction, see [stdctx.RecordInTx].
+type pending struct {
+	rec    Record
+	ttl    time.Duration
+	store  Store
+	output protoreflect.FullName
+
+	// recorded is the response that was recorded in a transaction, nil if there was none.
+	recorded []byte
+}
+
+// response returns the serialized response if it is a message of the procedure's output type, so the outputs of
+// other transactions of the handler are not mistaken for its response.
+func (p *pending) response(resp any) ([]byte, bool, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok || msg.ProtoReflect().Descriptor().FullName() != p.output {
+		return nil, false, nil
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshal response: %w", err)
+	}
+
+	return data, true, nil
+}
+
+// Interceptor records the response of side-effecting procedures that are called with an Idempotency-Key header,
+// and replays it when the procedure is called again with the same key. Procedures that are marked NO_SIDE_EFFECTS
+// and requests without the header are passed through. Failed requests are not recorded, so they can be retried.
+//
+// Handlers that transact with stdpgxtxfx.CTx or stdcrpcenttenancyfx.Transact record the response in the same
+// transaction, so the side-effects and the record commit atomically. Only a read-write transaction that returns a
+// message of the procedure's output type records, other transactions of the handler are left alone. A concurrent
+// retry then waits for the record, and replays it. If the handler returns another response than the one that was
+// recorded, the record is overwritten after the handler returned.
+//
+// Otherwise, e.g. with a store other than the [PostgresStore], the response is only recorded after the handler
+// returned. Retries that arrive while the handler is still running are then not prevented from running it again,
+// only the first response is recorded and replayed afterwards. Only unary procedures are supported.
+func (idem *Idempotency) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			key := req.Header().Get(HeaderName)
+			if req.Spec().IsClient || key == "" || req.Spec().IdempotencyLevel == connect.IdempotencyNoSideEffects {
+				return next(ctx, req)
+			}
+
+			if len(key) > idem.cfg.MaxKeyLength {
+				return nil, connect.NewError(connect.CodeInvalidArgument,
+					fmt.Errorf("%s header must be at most %d characters", HeaderName, idem.cfg.MaxKeyLength))
+			}
+
+			hash, err := requestHash(req)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+
+			method, err := stdcrpc.MethodDescriptor(req.Spec())
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+
+			scope, procedure := idem.scope(ctx), req.Spec().Procedure
+			if resp, found, err := idem.replay(ctx, req, scope, key, hash); err != nil || found {
+				return resp, err
+			}
+
+			pend := &pending{ttl: idem.cfg.TTL, store: idem.store, output: method.Output().FullName(), rec: Record{
+				Scope: scope, Key: key, Owner: newOwner(), Procedure: procedure, RequestHash: hash,
+			}}
+
+			resp, err := next(stdctx.WithTxRecorder(ctx, pend.recordInTx), req)
+			if errors.Is(err, ErrKeyInUse) {
+				// a concurrent request with the same key committed first, our transaction was rolled back.
+				if resp, found, err := idem.replay(ctx, req, scope, key, hash); err != nil || found {
+					return resp, err
+				}
+
+				return nil, connect.NewError(connect.CodeAborted, ErrKeyInUse)
+			} else if err != nil {
+				return resp, err
+			}
+
+			if err := pend.save(ctx, resp.Any()); err != nil {
+				idem.logs.Error("failed to record response for idempotency key",
+					zap.String("procedure", procedure), zap.Error(err))
+			}
+
+			return resp, nil
+		}
+	}
+}
+
+// save records the response of the handler, unless it was already recorded in its transaction.
+func (p *pending) save(ctx context.Context, resp any) error {
+	data, ok, err := p.response(resp)
+	if err != nil || !ok || bytes.Equal(data, p.recorded) {
+		return err
+	}
+
+	rec := p.rec
+	rec.Response = data
+
+	return p.store.Save(ctx, rec, p.ttl)
+}
+
+// replay looks up the record for the key, and turns it into a response if it was found.
+func (idem *Idempotency) replay(
+	ctx context.Context, req connect.AnyRequest, scope, key string, hash []byte,
+) (connect.AnyResponse, bool, error) {
+	rec, found, err := idem.store.Lookup(ctx, scope, key, idem.cfg.TTL)
+	if err != nil {
+		idem.logs.Error("failed to lookup idempotency key", zap.Error(err))
+
+		return nil, false, connect.NewError(connect.CodeUnavailable, errors.New("failed to lookup idempotency key"))
+	} else if !found {
+		return nil, false, nil
+	}
+
+	if !bytes.Equal(rec.RequestHash, hash) {
+		return nil, false, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("%s was already used for a different request", HeaderName))
+	}
+
+	method, err := stdcrpc.MethodDescriptor(req.Spec())
+	if err != nil {
+		return nil, false, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// Connect only needs the message to send it, so a dynamic message of the output type will do.
+	msg := dynamicpb.NewMessage(method.Output())
+	if err := proto.Unmarshal(rec.Response, msg); err != nil {
+		return nil, false, connect.NewError(connect.CodeInternal, fmt.Errorf("unmarshal recorded response: %w", err))
+	}
+
+	// the original headers and trailers are not recorded, see the package doc.
+	resp := connect.NewResponse(msg)
+	resp.Header().Set(ReplayedHeaderName, "true")
+
+	return resp, true, nil
+}
+
+// requestHash hashes the procedure and the deterministically serialized request message.
+func requestHash(req connect.AnyRequest) ([]byte, error) {
+	msg, ok := req.Any().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("request of type %T is not a protobuf message", req.Any())
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Spec().Procedure))
+	hash.Write([]byte{0})
+	hash.Write(data)
+
+	return hash.Sum(nil), nil
+}
+
+// newOwner returns a random identifier for the request that records a response.
+func newOwner() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,179 @@
+package stdidempotencyfx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdidempotencyfx"
+	internalv1 "github.com/advdv/stdgo/fx/stdidempotencyfx/internal/v1"
+	"github.com/advdv/stdgo/fx/stdidempotencyfx/internal/v1/internalv1connect"
+	"github.com/advdv/stdgo/fx/stdzapfx"
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/advdv/stdgo/stdfx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryStore is a [stdidempotencyfx.Store] for testing, it ignores the ttl.
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]stdidempotencyfx.Record
+}
+
+func (s *memoryStore) Lookup(_ context.Context, scope, key string, _ time.Duration) (
+	stdidempotencyfx.Record, bool, error,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[scope+"|"+key]
+
+	return rec, ok, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, rec stdidempotencyfx.Record, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.records == nil {
+		s.records = map[string]stdidempotencyfx.Record{}
+	}
+
+	if existing, ok := s.records[rec.Scope+"|"+rec.Key]; ok && existing.Owner != rec.Owner {
+		return stdidempotencyfx.ErrKeyInUse
+	}
+
+	s.records[rec.Scope+"|"+rec.Key] = rec
+
+	return nil
+}
+
+type testService struct{ calls atomic.Int64 }
+
+func (s *testService) Create(
+	_ context.Context, req *connect.Request[internalv1.CreateRequest],
+) (*connect.Response[internalv1.CreateResponse], error) {
+	n := s.calls.Add(1)
+
+	return connect.NewResponse(internalv1.CreateResponse_builder{
+		Id: proto.String(req.Msg.GetName() + "-" + strconv.FormatInt(n, 10)),
+	}.Build()), nil
+}
+
+func (s *testService) Get(
+	context.Context, *connect.Request[internalv1.GetRequest],
+) (*connect.Response[internalv1.GetResponse], error) {
+	s.calls.Add(1)
+
+	return connect.NewResponse(&internalv1.GetResponse{}), nil
+}
+
+func setup(tb testing.TB, store stdidempotencyfx.Store) (
+	internalv1connect.TestServiceClient, *testService,
+) {
+	tb.Helper()
+
+	var idem *stdidempotencyfx.Idempotency
+
+	app := fxtest.New(tb,
+		stdenvcfg.ProvideExplicitEnvironment(map[string]string{"STDIDEMPOTENCY_MAX_KEY_LENGTH": "10"}),
+		stdzapfx.Fx(),
+		stdzapfx.TestProvide(tb),
+		stdfx.ZapEnvCfgModule[stdidempotencyfx.Config]("stdidempotency", stdidempotencyfx.New),
+		fx.Supply(fx.Annotate(store, fx.As(new(stdidempotencyfx.Store)))),
+		fx.Populate(&idem),
+	)
+	app.RequireStart()
+	tb.Cleanup(app.RequireStop)
+
+	svc := &testService{}
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(svc, connect.WithInterceptors(idem.Interceptor())))
+
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+
+	return internalv1connect.NewTestServiceClient(srv.Client(), srv.URL), svc
+}
+
+func createRequest(name, key string) *connect.Request[internalv1.CreateRequest] {
+	req := connect.NewRequest(internalv1.CreateRequest_builder{Name: proto.String(name)}.Build())
+	if key != "" {
+		req.Header().Set(stdidempotencyfx.HeaderName, key)
+	}
+
+	return req
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	client, svc := setup(t, &memoryStore{})
+
+	resp1, err := client.Create(t.Context(), createRequest("foo", "k1"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-1", resp1.Msg.GetId())
+	require.Empty(t, resp1.Header().Get(stdidempotencyfx.ReplayedHeaderName))
+
+	resp2, err := client.Create(t.Context(), createRequest("foo", "k1"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-1", resp2.Msg.GetId())
+	require.Equal(t, "true", resp2.Header().Get(stdidempotencyfx.ReplayedHeaderName))
+	require.EqualValues(t, 1, svc.calls.Load())
+
+	resp3, err := client.Create(t.Context(), createRequest("foo", "k2"))
+	require.NoError(t, err)
+	require.Equal(t, "foo-2", resp3.Msg.GetId(), "another key runs the handler")
+}
+
+func TestKeyReusedForOtherRequest(t *testing.T) {
+	t.Parallel()
+	client, _ := setup(t, &memoryStore{})
+
+	_, err := client.Create(t.Context(), createRequest("foo", "k1"))
+	require.NoError(t, err)
+
+	_, err = client.Create(t.Context(), createRequest("bar", "k1"))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorContains(t, err, "already used for a different request")
+}
+
+func TestPassThrough(t *testing.T) {
+	t.Parallel()
+	client, svc := setup(t, &memoryStore{})
+
+	for range 2 {
+		resp, err := client.Create(t.Context(), createRequest("foo", ""))
+		require.NoError(t, err)
+		require.Empty(t, resp.Header().Get(stdidempotencyfx.ReplayedHeaderName))
+	}
+
+	for range 2 {
+		req := connect.NewRequest(&internalv1.GetRequest{})
+		req.Header().Set(stdidempotencyfx.HeaderName, "k1")
+		resp, err := client.Get(t.Context(), req)
+		require.NoError(t, err)
+		require.Empty(t, resp.Header().Get(stdidempotencyfx.ReplayedHeaderName))
+	}
+
+	require.EqualValues(t, 4, svc.calls.Load())
+}
+
+func TestKeyTooLong(t *testing.T) {
+	t.Parallel()
+	client, svc := setup(t, &memoryStore{})
+
+	_, err := client.Create(t.Context(), createRequest("foo", strings.Repeat("k", 11)))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorContains(t, err, "at most 10 characters")
+	require.EqualValues(t, 0, svc.calls.Load())
+}
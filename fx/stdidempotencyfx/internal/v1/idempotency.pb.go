@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: fx/stdidempotencyfx/internal/v1/idempotency.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CreateRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type CreateRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name *string
+}
+
+func (b0 CreateRequest_builder) Build() *CreateRequest {
+	m0 := &CreateRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+type CreateResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateResponse) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateResponse) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CreateResponse) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateResponse) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type CreateResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 CreateResponse_builder) Build() *CreateResponse {
+	m0 := &CreateResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetRequest_builder) Build() *GetRequest {
+	m0 := &GetRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetResponse_builder) Build() *GetResponse {
+	m0 := &GetResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+var File_fx_stdidempotencyfx_internal_v1_idempotency_proto protoreflect.FileDescriptor
+
+const file_fx_stdidempotencyfx_internal_v1_idempotency_proto_rawDesc = "" +
+	"\n" +
+	"1fx/stdidempotencyfx/internal/v1/idempotency.proto\x12\x1ffx.stdidempotencyfx.internal.v1\"#\n" +
+	"\rCreateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\" \n" +
+	"\x0eCreateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\f\n" +
+	"\n" +
+	"GetRequest\"\r\n" +
+	"\vGetResponse2\xe1\x01\n" +
+	"\vTestService\x12k\n" +
+	"\x06Create\x12..fx.stdidempotencyfx.internal.v1.CreateRequest\x1a/.fx.stdidempotencyfx.internal.v1.CreateResponse\"\x00\x12e\n" +
+	"\x03Get\x12+.fx.stdidempotencyfx.internal.v1.GetRequest\x1a,.fx.stdidempotencyfx.internal.v1.GetResponse\"\x03\x90\x02\x01B\x99\x02\n" +
+	"#com.fx.stdidempotencyfx.internal.v1B\x10IdempotencyProtoP\x01ZAgithub.com/advdv/stdgo/fx/stdidempotencyfx/internal/v1;internalv1\xa2\x02\x03FSI\xaa\x02\x1fFx.Stdidempotencyfx.Internal.V1\xca\x02\x1fFx\\Stdidempotencyfx\\Internal\\V1\xe2\x02+Fx\\Stdidempotencyfx\\Internal\\V1\\GPBMetadata\xea\x02\"Fx::Stdidempotencyfx::Internal::V1b\beditionsp\xe8\a"
+
+var file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_fx_stdidempotencyfx_internal_v1_idempotency_proto_goTypes = []any{
+	(*CreateRequest)(nil),  // 0: fx.stdidempotencyfx.internal.v1.CreateRequest
+	(*CreateResponse)(nil), // 1: fx.stdidempotencyfx.internal.v1.CreateResponse
+	(*GetRequest)(nil),     // 2: fx.stdidempotencyfx.internal.v1.GetRequest
+	(*GetResponse)(nil),    // 3: fx.stdidempotencyfx.internal.v1.GetResponse
+}
+var file_fx_stdidempotencyfx_internal_v1_idempotency_proto_depIdxs = []int32{
+	0, // 0: fx.stdidempotencyfx.internal.v1.TestService.Create:input_type -> fx.stdidempotencyfx.internal.v1.CreateRequest
+	2, // 1: fx.stdidempotencyfx.internal.v1.TestService.Get:input_type -> fx.stdidempotencyfx.internal.v1.GetRequest
+	1, // 2: fx.stdidempotencyfx.internal.v1.TestService.Create:output_type -> fx.stdidempotencyfx.internal.v1.CreateResponse
+	3, // 3: fx.stdidempotencyfx.internal.v1.TestService.Get:output_type -> fx.stdidempotencyfx.internal.v1.GetResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_fx_stdidempotencyfx_internal_v1_idempotency_proto_init() }
+func file_fx_stdidempotencyfx_internal_v1_idempotency_proto_init() {
+	if File_fx_stdidempotencyfx_internal_v1_idempotency_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fx_stdidempotencyfx_internal_v1_idempotency_proto_rawDesc), len(file_fx_stdidempotencyfx_internal_v1_idempotency_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fx_stdidempotencyfx_internal_v1_idempotency_proto_goTypes,
+		DependencyIndexes: file_fx_stdidempotencyfx_internal_v1_idempotency_proto_depIdxs,
+		MessageInfos:      file_fx_stdidempotencyfx_internal_v1_idempotency_proto_msgTypes,
+	}.Build()
+	File_fx_stdidempotencyfx_internal_v1_idempotency_proto = out.File
+	file_fx_stdidempotencyfx_internal_v1_idempotency_proto_goTypes = nil
+	file_fx_stdidempotencyfx_internal_v1_idempotency_proto_depIdxs = nil
+}
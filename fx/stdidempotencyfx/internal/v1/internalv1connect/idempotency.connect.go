@@ -0,0 +1,139 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: fx/stdidempotencyfx/internal/v1/idempotency.proto
+
+package internalv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/advdv/stdgo/fx/stdidempotencyfx/internal/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TestServiceName is the fully-qualified name of the TestService service.
+	TestServiceName = "fx.stdidempotencyfx.internal.v1.TestService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TestServiceCreateProcedure is the fully-qualified name of the TestService's Create RPC.
+	TestServiceCreateProcedure = "/fx.stdidempotencyfx.internal.v1.TestService/Create"
+	// TestServiceGetProcedure is the fully-qualified name of the TestService's Get RPC.
+	TestServiceGetProcedure = "/fx.stdidempotencyfx.internal.v1.TestService/Get"
+)
+
+// TestServiceClient is a client for the fx.stdidempotencyfx.internal.v1.TestService service.
+type TestServiceClient interface {
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+}
+
+// NewTestServiceClient constructs a client for the fx.stdidempotencyfx.internal.v1.TestService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTestServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	testServiceMethods := v1.File_fx_stdidempotencyfx_internal_v1_idempotency_proto.Services().ByName("TestService").Methods()
+	return &testServiceClient{
+		create: connect.NewClient[v1.CreateRequest, v1.CreateResponse](
+			httpClient,
+			baseURL+TestServiceCreateProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[v1.GetRequest, v1.GetResponse](
+			httpClient,
+			baseURL+TestServiceGetProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// testServiceClient implements TestServiceClient.
+type testServiceClient struct {
+	create *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	get    *connect.Client[v1.GetRequest, v1.GetResponse]
+}
+
+// Create calls fx.stdidempotencyfx.internal.v1.TestService.Create.
+func (c *testServiceClient) Create(ctx context.Context, req *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls fx.stdidempotencyfx.internal.v1.TestService.Get.
+func (c *testServiceClient) Get(ctx context.Context, req *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// TestServiceHandler is an implementation of the fx.stdidempotencyfx.internal.v1.TestService
+// service.
+type TestServiceHandler interface {
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+}
+
+// NewTestServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTestServiceHandler(svc TestServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	testServiceMethods := v1.File_fx_stdidempotencyfx_internal_v1_idempotency_proto.Services().ByName("TestService").Methods()
+	testServiceCreateHandler := connect.NewUnaryHandler(
+		TestServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(testServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServiceGetHandler := connect.NewUnaryHandler(
+		TestServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(testServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/fx.stdidempotencyfx.internal.v1.TestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TestServiceCreateProcedure:
+			testServiceCreateHandler.ServeHTTP(w, r)
+		case TestServiceGetProcedure:
+			testServiceGetHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTestServiceHandler struct{}
+
+func (UnimplementedTestServiceHandler) Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fx.stdidempotencyfx.internal.v1.TestService.Create is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fx.stdidempotencyfx.internal.v1.TestService.Get is not implemented"))
+}
@@ -0,0 +1,157 @@
+package stdidempotencyfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+)
+
+// PostgresSchema creates the table that holds the records of the [PostgresStore]. It should be part of the
+// application's migrations.
+const PostgresSchema = `CREATE TABLE IF NOT EXISTS stdidempotency_records (
+	scope text NOT NULL,
+	key text NOT NULL,
+	owner text NOT NULL,
+	procedure text NOT NULL,
+	request_hash bytea NOT NULL,
+	response bytea NOT NULL,
+	created_at timestamptz NOT NULL,
+	PRIMARY KEY (scope, key)
+);`
+
+// saveSQL records the response, unless another owner recorded the key within the ttl. Concurrent requests for the
+// same key wait on each other's transaction because of the primary key.
+const saveSQL = `INSERT INTO stdidempotency_records AS r
+	(scope, key, owner, procedure, request_hash, response, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+ON CONFLICT (scope, key) DO UPDATE SET
+	owner = EXCLUDED.owner,
+	procedure = EXCLUDED.procedure,
+	request_hash = EXCLUDED.request_hash,
+	response = EXCLUDED.response,
+	created_at = EXCLUDED.created_at
+WHERE r.owner = EXCLUDED.owner OR r.created_at < now() - make_interval(secs => $7::float8)`
+
+// lookupSQL returns the record for the key if it is not expired.
+const lookupSQL = `SELECT owner, procedure, request_hash, response FROM stdidempotency_records
+WHERE scope = $1 AND key = $2 AND created_at >= now() - make_interval(secs => $3::float8)`
+
+// Querier is the subset of a pgx pool that is used by the [PostgresStore].
+type Querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// PostgresStore records responses in Postgres.
+type PostgresStore struct {
+	db Querier
+}
+
+// NewPostgresStore inits a store on the given database. The table from [PostgresSchema] must exist.
+func NewPostgresStore(db Querier) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Lookup implements [Store].
+func (s *PostgresStore) Lookup(ctx context.Context, scope, key string, ttl time.Duration) (Record, bool, error) {
+	rec := Record{Scope: scope, Key: key}
+	if err := s.db.QueryRow(ctx, lookupSQL, scope, key, ttl.Seconds()).Scan(
+		&rec.Owner, &rec.Procedure, &rec.RequestHash, &rec.Response,
+	); errors.Is(err, pgx.ErrNoRows) {
+		return Record{}, false, nil
+	} else if err != nil {
+		return Record{}, false, fmt.Errorf("lookup record: %w", err)
+	}
+
+	return rec, true, nil
+}
+
+// Save implements [Store].
+func (s *PostgresStore) Save(ctx context.Context, rec Record, ttl time.Duration) error {
+	tag, err := s.db.Exec(ctx, saveSQL, saveArgs(rec, ttl)...)
+	if err != nil {
+		return fmt.Errorf("save record: %w", err)
+	}
+
+	return checkSaved(tag.RowsAffected())
+}
+
+// PurgeExpired deletes the records that are older than the ttl. It can be run periodically to keep the table small,
+// expired records are overwritten when their key is reused either way.
+func (s *PostgresStore) PurgeExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	tag, err := s.db.Exec(ctx,
+		`DELETE FROM stdidempotency_records WHERE created_at < now() - make_interval(secs => $1::float8)`, ttl.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("purge expired records: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func saveArgs(rec Record, ttl time.Duration) []any {
+	return []any{rec.Scope, rec.Key, rec.Owner, rec.Procedure, rec.RequestHash, rec.Response, ttl.Seconds()}
+}
+
+func checkSaved(rowsAffected int64) error {
+	if rowsAffected == 0 {
+		return ErrKeyInUse
+	}
+
+	return nil
+}
+
+// readWriteSQL returns a row if the transaction is read-write.
+const readWriteSQL = `SELECT 1 WHERE current_setting('transaction_read_only') = 'off'`
+
+// recordInTx is the [stdctx.TxRecorder] for the pending record, so transaction helpers write it in the handler's
+// transaction. Only the output of a read-write transaction that is a message of the procedure's output type is
+// recorded, other outputs are ignored. It returns [ErrKeyInUse] if a concurrent request recorded the key first, the
+// transaction must then be rolled back.
+func (p *pending) recordInTx(ctx context.Context, exec stdctx.TxExecFunc, resp any) error {
+	// the statement is specific to our table, other stores record after the handler returned.
+	if _, ok := p.store.(*PostgresStore); !ok {
+		return nil
+	}
+
+	data, ok, err := p.response(resp)
+	if err != nil || !ok {
+		return err
+	}
+
+	if rows, err := exec(ctx, readWriteSQL); err != nil {
+		return fmt.Errorf("check read-write transaction: %w", err)
+	} else if rows == 0 {
+		return nil
+	}
+
+	rec := p.rec
+	rec.Response = data
+
+	rows, err := exec(ctx, saveSQL, saveArgs(rec, p.ttl)...)
+	if err != nil {
+		return fmt.Errorf("record response: %w", err)
+	}
+
+	if err := checkSaved(rows); err != nil {
+		return err
+	}
+
+	// a transaction that is retried records again, so this is only relied on when the handler succeeds.
+	p.recorded = data
+
+	return nil
+}
+
+// ProvidePostgresStore provides a [PostgresStore] on the pgx pool with the given name, see stdpgxfx.
+func ProvidePostgresStore(poolName string) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(db *pgxpool.Pool) Store { return NewPostgresStore(db) },
+		fx.ParamTags(`name:"`+poolName+`"`)))
+}
@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	"github.com/advdv/stdgo/stdfx"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
@@ -301,9 +303,53 @@ func Provide[DBT any](drv Driver[DBT], mainPoolName string, derivedPoolNames ...
 			fx.ResultTags(`name:"`+mainPoolName+`"`))),
 		// provide the "derived" pools (if any)
 		withDerivedPools(drv, mainPoolName, derivedPoolNames...),
+		// contribute a readiness check for every pool
+		withHealthChecks[DBT](append([]string{mainPoolName}, derivedPoolNames...)...),
 	)
 }
 
+// pinger is implemented by pgxpool.Pool.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// contextPinger is implemented by sql.DB.
+type contextPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// withHealthChecks contributes a readiness check to the health registry for each named pool, if the pool type
+// supports pinging the database.
+func withHealthChecks[DBT any](names ...string) fx.Option {
+	typ := reflect.TypeFor[DBT]()
+	if !typ.Implements(reflect.TypeFor[pinger]()) && !typ.Implements(reflect.TypeFor[contextPinger]()) {
+		return fx.Options()
+	}
+
+	options := make([]fx.Option, 0, len(names))
+	for _, name := range names {
+		options = append(options, fx.Provide(
+			fx.Annotate(func(db DBT) stdhealthfx.Check {
+				ping := func(ctx context.Context) error {
+					switch db := any(db).(type) {
+					case pinger:
+						return db.Ping(ctx)
+					case contextPinger:
+						return db.PingContext(ctx)
+					}
+
+					return nil
+				}
+
+				return stdhealthfx.Check{Name: "pgx:" + name, Kind: stdhealthfx.Readiness, Critical: true, Run: ping}
+			},
+				fx.ParamTags(`name:"`+name+`"`),
+				fx.ResultTags(`group:"health_checks"`))))
+	}
+
+	return fx.Options(options...)
+}
+
 // Deriver needs to be provided by the user of this module if derived pools are created.
 type Deriver func(logs *zap.Logger, base *pgxpool.Config) *pgxpool.Config
 
@@ -21,7 +21,8 @@ var PgErrorsToConnectError = map[string]func(lpgErr *pgconn.PgError, logs *zap.L
 }
 
 // CTx transacts while returning a protobuf message for the response. It is a shorthant for the most common
-// scenario in our connect RPC code and makes it way more succinct.
+// scenario in our connect RPC code and makes it way more succinct. The response is passed to the
+// [stdctx.TxRecorder] in the context (if any) before the transaction commits, which decides whether to record it.
 func CTx[I, O any](
 	ctx context.Context,
 	txr *stdtx.Transactor[pgx.Tx],
@@ -29,7 +30,17 @@ func CTx[I, O any](
 	fn func(context.Context, *zap.Logger, pgx.Tx, *I) (*O, error),
 ) (*connect.Response[O], error) {
 	m, err := stdtx.Transact1(ctx, txr,
-		func(ctx context.Context, tx pgx.Tx) (*O, error) { return fn(ctx, stdctx.Log(ctx), tx, req.Msg) })
+		func(ctx context.Context, tx pgx.Tx) (*O, error) {
+			m, err := fn(ctx, stdctx.Log(ctx), tx, req.Msg)
+			if err != nil {
+				return nil, err
+			}
+
+			return m, stdctx.RecordInTx(ctx, func(ctx context.Context, sql string, args ...any) (int64, error) {
+				tag, err := tx.Exec(ctx, sql, args...)
+				return tag.RowsAffected(), err
+			}, m)
+		})
 	return txResponse(ctx, m, err)
 }
 
@@ -0,0 +1,52 @@
+package stdpubprivrpcfx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"connectrpc.com/grpcreflect"
+	"github.com/advdv/stdgo/fx/stdhealthfx"
+)
+
+// serviceName returns the fully-qualified service name from the path that a generated handler constructor
+// returns, e.g. "/foo.v1.ReadOnlyService/" becomes "foo.v1.ReadOnlyService".
+func serviceName(path string) string { return strings.Trim(path, "/") }
+
+// healthChecker implements the grpc.health.v1 protocol with the readiness checks of the health registry. Every
+// service is as healthy as the process, since the checks are not per service.
+type healthChecker struct {
+	health   *stdhealthfx.Registry
+	services []string
+}
+
+func (hc healthChecker) Check(ctx context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	if req.Service != "" && !slices.Contains(hc.services, req.Service) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %q", req.Service))
+	}
+
+	if hc.health.Check(ctx, stdhealthfx.Readiness).Status == stdhealthfx.StatusFail {
+		return &grpchealth.CheckResponse{Status: grpchealth.StatusNotServing}, nil
+	}
+
+	return &grpchealth.CheckResponse{Status: grpchealth.StatusServing}, nil
+}
+
+// mountIntrospection mounts the grpc.health.v1 service and the gRPC server reflection (v1 and v1alpha) for the
+// services. Without a health registry every service always reports to be serving.
+func mountIntrospection(mux *http.ServeMux, health *stdhealthfx.Registry, services ...string) {
+	var checker grpchealth.Checker = grpchealth.NewStaticChecker(services...)
+	if health != nil {
+		checker = healthChecker{health: health, services: services}
+	}
+
+	reflector := grpcreflect.NewStaticReflector(slices.Concat(services, []string{grpchealth.HealthV1ServiceName})...)
+
+	mux.Handle(grpchealth.NewHandler(checker))
+	mux.Handle(grpcreflect.NewHandlerV1(reflector))
+	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+}
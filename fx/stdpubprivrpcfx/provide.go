@@ -5,13 +5,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
 	"connectrpc.com/validate"
 	"github.com/advdv/bhttp"
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcpgerr"
+	"github.com/advdv/stdgo/stdenvcfg"
 	"github.com/advdv/stdgo/stdfx"
 	"github.com/advdv/stdgo/stdhttpware"
 	"github.com/danielgtaylor/huma/v2"
@@ -28,14 +33,36 @@ type Config struct {
 	AllowForcedPanics bool `env:"ALLOW_FORCED_PANICS"`
 	// response validation can be enabled in testing to catch errors early.
 	ResponseValidation bool `env:"RESPONSE_VALIDATION"`
+	// log invalid responses instead of failing the call, so response validation can run in production.
+	ResponseValidationLogOnly bool `env:"RESPONSE_VALIDATION_LOG_ONLY"`
+	// timeout of calls without a deadline, for procedures that don't declare a default. Zero for none.
+	RPCDefaultTimeout time.Duration `env:"RPC_DEFAULT_TIMEOUT" validate:"min=0s"`
+	// maximum timeout of calls, client timeouts are lowered to it. Procedures may declare their own, zero for none.
+	RPCMaxTimeout time.Duration `env:"RPC_MAX_TIMEOUT" validate:"min=0s"`
+	// log the request and response messages, with sensitive fields redacted. At debug level unless configured.
+	LogPayloads bool `env:"LOG_PAYLOADS"`
 	// cache the pre-flight response more readily, it is not dynamic.
 	CORSMaxAgeSeconds int `env:"CONNECT_CORS_MAX_AGE_SECONDS" envDefault:"3600"`
-	// allow configuration of CORS allowed origins.
+	// also serve the gRPC health and reflection services publicly, they are always served privately. They are not
+	// behind the auth middleware, so probes and tooling can reach them without credentials.
+	PublicGRPCIntrospection bool `env:"PUBLIC_GRPC_INTROSPECTION"`
+	// allow configuration of CORS allowed origins: exact origins, wildcard subdomains of a registrable domain
+	// (https://*.example.com) or any port on localhost (http://localhost:*).
 	ConnectCORSAllowedOrigins []string `env:"CONNECT_CORS_ALLOWED_ORIGINS"`
+	// serve /debug/cors on the private handler, it explains why an origin is allowed or rejected.
+	CORSDebug bool `env:"CORS_DEBUG"`
 	// allow configuration for the OpenAPI endpoint.
 	OpenAPICORSAllowedOrigins []string `env:"OPENAPI_CORS_ALLOWED_ORIGINS"`
 	// for making the hosted openapi spec fully descriptive, the environment must specify how to reach it externally.
 	OpenAPIExternalBaseURL *url.URL `env:"OPENAPI_EXTERNAL_BASE_URL"`
+	// configure what is logged about every request.
+	AccessLog stdhttpware.AccessLogConfig `envPrefix:"ACCESS_LOG_"`
+	// configure which proxies are trusted to report the client IP.
+	ClientIP stdhttpware.ClientIPConfig `envPrefix:"CLIENT_IP_"`
+	// configure the security headers of every response.
+	SecurityHeaders stdhttpware.SecurityHeadersConfig `envPrefix:"SECURITY_HEADERS_"`
+	// the OpenAPI docs load scripts and styles from CDNs, so they need a looser Content-Security-Policy.
+	OpenAPIContentSecurityPolicy string `env:"OPENAPI_CONTENT_SECURITY_POLICY" envDefault:"default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.jsdelivr.net; style-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.jsdelivr.net https://fonts.googleapis.com; img-src 'self' data: https:; font-src 'self' data: https:; connect-src 'self' https:; frame-ancestors 'none'"` //nolint:lll
 
 	// configuration set via a depdency.
 	basePath RPCBasePath
@@ -65,6 +92,27 @@ func New[PUBRO, PUBRW, PRIVRW, PRIVRWC any](deps struct {
 
 	// optionally server a public.
 	PublicOpenAPIMount *openAPIMount `optional:"true"`
+
+	// optionally, the configuration may change while the process is running.
+	Reloadable *stdenvcfg.Reloadable[Config] `optional:"true"`
+
+	// optionally, serve the liveness and readiness endpoints of the health registry.
+	Health *stdhealthfx.Registry `optional:"true"`
+
+	// optionally, intercept requests to the public handlers before anything else, such as to limit their rate.
+	PublicInterceptors []connect.Interceptor `group:"public_interceptors"`
+
+	// optionally, map database errors that handlers return to meaningful Connect errors.
+	PgErrorMapper *stdcrpcpgerr.Mapper `optional:"true"`
+
+	// optionally, configure the response validation such as per-procedure opt-outs.
+	ResponseValidationOptions []stdcrpcintercept.ValidateResponseOption `group:"response_validation_options"`
+
+	// optionally, configure the deadlines such as the per-procedure timeout options.
+	DeadlinesOptions []stdcrpcintercept.DeadlinesOption `group:"deadlines_options"`
+
+	// optionally, configure the payload logging such as the procedures it is enabled for.
+	LogPayloadsOptions []stdcrpcintercept.LogPayloadsOption `group:"log_payloads_options"`
 }) (res struct {
 	fx.Out
 
@@ -74,51 +122,131 @@ func New[PUBRO, PUBRW, PRIVRW, PRIVRWC any](deps struct {
 ) {
 	deps.Config.basePath = deps.BasePath
 
+	if _, err := stdhttpware.ParseOriginPatterns(deps.Config.ConnectCORSAllowedOrigins...); err != nil {
+		return res, fmt.Errorf("invalid connect CORS allowed origins: %w", err)
+	}
+
 	reqValidator, err := validate.NewInterceptor(validate.WithValidator(deps.Validator))
 	if err != nil {
 		return res, fmt.Errorf("init validate interceptor: %w", err)
 	}
 
+	// the deadline is bounded first, so everything the call does inherits it.
+	interceptors := []connect.Interceptor{stdcrpcintercept.NewDeadlines(append([]stdcrpcintercept.DeadlinesOption{
+		stdcrpcintercept.WithDefaultTimeout(deps.Config.RPCDefaultTimeout),
+		stdcrpcintercept.WithMaxTimeout(deps.Config.RPCMaxTimeout),
+	}, deps.DeadlinesOptions...)...), reqValidator}
+
 	// optionally, we can also validate responses.
-	interceptors := []connect.Interceptor{reqValidator}
 	if deps.Config.ResponseValidation {
-		interceptors = append(interceptors, stdcrpcintercept.NewValidateResponse(deps.Validator))
+		valOpts := deps.ResponseValidationOptions
+		if deps.Config.ResponseValidationLogOnly {
+			valOpts = append(valOpts, stdcrpcintercept.WithLogViolations(deps.Logger))
+		}
+
+		interceptors = append(interceptors, stdcrpcintercept.NewValidateResponse(deps.Validator, valOpts...))
 	}
 
+	// payloads are logged outermost, so requests that fail validation are logged too.
+	if deps.Config.LogPayloads {
+		interceptors = append([]connect.Interceptor{
+			stdcrpcintercept.NewLogPayloads(deps.Logger, deps.LogPayloadsOptions...),
+		}, interceptors...)
+	}
+
+	// database errors are mapped closest to the handler, so the other interceptors see the mapped error.
+	if deps.PgErrorMapper != nil {
+		interceptors = append(interceptors, deps.PgErrorMapper.Interceptor())
+	}
+
+	// public requests pass the public interceptors before anything else, such as the rate limiter.
+	pubInterceptors := append(slices.Clone(deps.PublicInterceptors), interceptors...)
+
 	// setup the muxs
 	pubMux, privMux := http.NewServeMux(), http.NewServeMux()
-	path, handler := deps.NewPublicReadOnly(deps.PublicReadOnly, connect.WithInterceptors(interceptors...))
-	pubMux.Handle(path, handler)
+	roPath, handler := deps.NewPublicReadOnly(deps.PublicReadOnly, connect.WithInterceptors(pubInterceptors...))
+	pubMux.Handle(roPath, handler)
 
-	path, handler = deps.NewPublicReadWrite(deps.PublicReadWrite, connect.WithInterceptors(interceptors...))
-	pubMux.Handle(path, handler)
+	rwPath, handler := deps.NewPublicReadWrite(deps.PublicReadWrite, connect.WithInterceptors(pubInterceptors...))
+	pubMux.Handle(rwPath, handler)
 
-	path, handler = deps.NewPrivateReadWrite(deps.PrivateReadWrite, connect.WithInterceptors(interceptors...))
-	privMux.Handle(path, handler)
+	privPath, handler := deps.NewPrivateReadWrite(deps.PrivateReadWrite, connect.WithInterceptors(interceptors...))
+	privMux.Handle(privPath, handler)
+
+	// gRPC health checking and server reflection, for probes and tooling such as grpcurl.
+	mountIntrospection(privMux, deps.Health, serviceName(privPath))
 
 	// CORS for this part of the API, so web clients can call it.
-	corsMiddleware := stdhttpware.NewConnectCORSMiddleware(
-		deps.Config.CORSMaxAgeSeconds, deps.Config.ConnectCORSAllowedOrigins...)
+	corsOrigins := func() []string { return deps.Config.ConnectCORSAllowedOrigins }
+	if deps.Reloadable != nil {
+		corsOrigins = func() []string { return deps.Reloadable.Get().ConnectCORSAllowedOrigins }
+	}
+
+	corsMiddleware := stdhttpware.NewDynamicConnectCORSMiddleware(deps.Config.CORSMaxAgeSeconds, corsOrigins)
+
+	// explain CORS decisions on the private side only, and only when enabled, it reveals the configuration.
+	if deps.Config.CORSDebug {
+		privMux.Handle("/debug/cors", stdhttpware.NewCORSDebugHandler(corsOrigins))
+	}
 
 	// setup HTTP middleware for the public Connect RPC handler.
 	pubHdlr := deps.AuthMiddleware.Wrap(pubMux)
 	/* ^ */ pubHdlr = corsMiddleware(pubHdlr)
 
+	// optionally, also gRPC health checking and server reflection publicly. Not for browsers, so not behind CORS.
+	if deps.Config.PublicGRPCIntrospection {
+		pubRoot := http.NewServeMux()
+		pubRoot.Handle("/", pubHdlr)
+		mountIntrospection(pubRoot, deps.Health, serviceName(roPath), serviceName(rwPath))
+		pubHdlr = pubRoot
+	}
+
 	// public RPC and OpenAPI
 	res.Public = withNonRPCHandling(
 		deps.Lifecycle,
-		pubHdlr, deps.Logger, deps.Config, false, deps.HealthCheck, nil, deps.NewPrivateReadWriteClient,
-		deps.PublicOpenAPIMount)
+		pubHdlr, deps.Logger, deps.Config, false, deps.HealthCheck, deps.Health, nil,
+		deps.NewPrivateReadWriteClient, deps.PublicOpenAPIMount)
 
 	// private RPC and never a OpenAPI
 	res.Private = withNonRPCHandling(
 		deps.Lifecycle,
-		privMux, deps.Logger, deps.Config, true, deps.HealthCheck, deps.LambdaRelays, deps.NewPrivateReadWriteClient,
-		deps.PublicOpenAPIMount)
+		privMux, deps.Logger, deps.Config, true, deps.HealthCheck, deps.Health, deps.LambdaRelays,
+		deps.NewPrivateReadWriteClient, deps.PublicOpenAPIMount)
 
 	return res, nil
 }
 
+// ProvideResponseValidationOption configures the response validation, e.g. with
+// [stdcrpcintercept.WithSkipExtension] for per-procedure opt-outs.
+func ProvideResponseValidationOption(opt stdcrpcintercept.ValidateResponseOption) fx.Option {
+	return fx.Provide(fx.Annotate(func() stdcrpcintercept.ValidateResponseOption {
+		return opt
+	}, fx.ResultTags(`group:"response_validation_options"`)))
+}
+
+// ProvideDeadlinesOption configures the deadlines of calls, e.g. with [stdcrpcintercept.WithTimeoutExtensions] for
+// per-procedure timeouts.
+func ProvideDeadlinesOption(opt stdcrpcintercept.DeadlinesOption) fx.Option {
+	return fx.Provide(fx.Annotate(func() stdcrpcintercept.DeadlinesOption {
+		return opt
+	}, fx.ResultTags(`group:"deadlines_options"`)))
+}
+
+// ProvideLogPayloadsOption configures the payload logging, e.g. with [stdcrpcintercept.WithSensitiveExtension] to
+// redact the application's sensitive fields.
+func ProvideLogPayloadsOption(opt stdcrpcintercept.LogPayloadsOption) fx.Option {
+	return fx.Provide(fx.Annotate(func() stdcrpcintercept.LogPayloadsOption {
+		return opt
+	}, fx.ResultTags(`group:"log_payloads_options"`)))
+}
+
+// ProvidePublicInterceptor adds an interceptor in front of the public handlers, before any other interceptor.
+func ProvidePublicInterceptor(icp connect.Interceptor) fx.Option {
+	return fx.Provide(fx.Annotate(func() connect.Interceptor {
+		return icp
+	}, fx.ResultTags(`group:"public_interceptors"`)))
+}
+
 // newInMemSysClient uses an in-memory http server to create a rpc client.
 func newInMemSysClient[PRIVRWC any](
 	lc fx.Lifecycle,
@@ -206,6 +334,7 @@ func withNonRPCHandling[PRIVRWC any](
 	cfg Config,
 	isPrivate bool,
 	hcheck HealthCheck,
+	health *stdhealthfx.Registry,
 	lambdaRelays []*LambdaRelay,
 	newPrivateClientFn func(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PRIVRWC,
 	oapiMount *openAPIMount,
@@ -226,6 +355,16 @@ func withNonRPCHandling[PRIVRWC any](
 	if !isPrivate && oapiMount != nil {
 		logs.Info("mounting OpenAPI handler", zap.String("pattern", oapiMount.pattern))
 		base.Handle(oapiMount.pattern, oapiMount.stripped)
+
+		cfg.SecurityHeaders.PathOverrides = append(cfg.SecurityHeaders.PathOverrides, stdhttpware.SecurityHeadersOverride{
+			PathPrefix:            oapiMount.pattern,
+			ContentSecurityPolicy: cfg.OpenAPIContentSecurityPolicy,
+		})
+	}
+
+	// serve the liveness and readiness endpoints, if a health registry is provided.
+	if health != nil {
+		health.Mount(base)
 	}
 
 	// handle server errors.
@@ -237,7 +376,10 @@ func withNonRPCHandling[PRIVRWC any](
 	mux.HandleFunc("/healthz", healthz(cfg, hcheck, isPrivate)) // health check endpoint.
 
 	// lambda relays need to call to an in-memory server of the final mux setup.
-	final := stdhttpware.Apply(mux, logs)
+	final := stdhttpware.Apply(mux, logs,
+		stdhttpware.WithAccessLog(cfg.AccessLog),
+		stdhttpware.WithClientIP(cfg.ClientIP),
+		stdhttpware.WithSecurityHeaders(cfg.SecurityHeaders))
 	if len(lambdaRelays) > 0 {
 		sys, err := newInMemSysClient(licecycle, cfg, final, newPrivateClientFn)
 		if err != nil {
@@ -284,6 +426,12 @@ func Provide[PUBRO, PUBRW, PRIVRW, PRIVRWC any](
 	)
 }
 
+// ProvideReloadable makes the allowed CORS origins of the Connect RPC handlers reloadable while the process is
+// running. It requires the reloader provided by [stdenvcfg.ProvideReloader].
+func ProvideReloadable() fx.Option {
+	return stdenvcfg.ProvideReloadable[Config]("STDPUBPRIVRPC_")
+}
+
 // RPCBasePath is a type to carry the rpc base path.
 type RPCBasePath struct{ V string }
 
@@ -3,15 +3,18 @@ package stdpubprivrpcfx_test
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"testing"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	"github.com/advdv/stdgo/fx/stdpubprivrpcfx"
 	foov1 "github.com/advdv/stdgo/fx/stdpubprivrpcfx/internal/foo/v1"
 	"github.com/advdv/stdgo/fx/stdpubprivrpcfx/internal/foo/v1/foov1connect"
+	"github.com/advdv/stdgo/fx/stdratelimitfx"
 	"github.com/advdv/stdgo/fx/stdzapfx"
 	"github.com/advdv/stdgo/stdctx"
 	"github.com/advdv/stdgo/stdenvcfg"
@@ -32,7 +35,9 @@ func newRPC() (
 	return h, h, h
 }
 
-func (handler) WhoAmI(_ context.Context, req *connect.Request[foov1.WhoAmIRequest]) (*connect.Response[foov1.WhoAmIResponse], error) {
+func (handler) WhoAmI(
+	_ context.Context, req *connect.Request[foov1.WhoAmIRequest],
+) (*connect.Response[foov1.WhoAmIResponse], error) {
 	out := foov1.WhoAmIResponse_builder{}.Build()
 	if req.Msg.GetEcho() != "" {
 		out.SetGreeting(req.Msg.GetEcho())
@@ -45,7 +50,9 @@ func (handler) WhoAmI(_ context.Context, req *connect.Request[foov1.WhoAmIReques
 	return connect.NewResponse(out), nil
 }
 
-func (handler) InitOrganization(ctx context.Context, _ *connect.Request[foov1.InitOrganizationRequest]) (*connect.Response[foov1.InitOrganizationResponse], error) {
+func (handler) InitOrganization(
+	ctx context.Context, _ *connect.Request[foov1.InitOrganizationRequest],
+) (*connect.Response[foov1.InitOrganizationResponse], error) {
 	stdctx.Log(ctx).Info("init organization")
 
 	return connect.NewResponse(foov1.InitOrganizationResponse_builder{}.Build()), nil
@@ -69,15 +76,32 @@ func setupAll(tb testing.TB, more ...any) (
 		Sys     foov1connect.SystemServiceClient
 	}
 
+	env := map[string]string{
+		"STDPUBPRIVRPC_ALLOW_FORCED_PANICS":       "true",
+		"STDPUBPRIVRPC_RESPONSE_VALIDATION":       "true",
+		"STDPUBPRIVRPC_OPENAPI_EXTERNAL_BASE_URL": "https://foo.bar",
+	}
+
+	// a map in more overwrites the environment, options are added to the app and everything else is populated.
+	populate, opts := []any{}, []fx.Option{}
+	for _, m := range more {
+		switch m := m.(type) {
+		case map[string]string:
+			maps.Copy(env, m)
+		case fx.Option:
+			opts = append(opts, m)
+		default:
+			populate = append(populate, m)
+		}
+	}
+
 	app := fxtest.New(tb,
-		stdenvcfg.ProvideExplicitEnvironment(map[string]string{
-			"STDPUBPRIVRPC_ALLOW_FORCED_PANICS":       "true",
-			"STDPUBPRIVRPC_RESPONSE_VALIDATION":       "true",
-			"STDPUBPRIVRPC_OPENAPI_EXTERNAL_BASE_URL": "https://foo.bar",
-		}),
+		stdenvcfg.ProvideExplicitEnvironment(env),
 		stdzapfx.Fx(),
 		stdzapfx.TestProvide(tb),
-		fx.Populate(more...),
+		stdhealthfx.Provide(),
+		stdratelimitfx.Provide(),
+		fx.Populate(populate...),
 		fx.Provide(newRPC),
 		fx.Provide(protovalidate.New),
 		fx.Supply(stdpubprivrpcfx.HealthCheck(func(ctx context.Context, r *http.Request, isPrivate bool) error {
@@ -105,6 +129,7 @@ func setupAll(tb testing.TB, more ...any) (
 			return err
 		}),
 
+		fx.Options(opts...),
 		fx.Populate(&deps))
 	app.RequireStart()
 	tb.Cleanup(app.RequireStop)
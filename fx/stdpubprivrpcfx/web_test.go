@@ -7,15 +7,20 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	foov1 "github.com/advdv/stdgo/fx/stdpubprivrpcfx/internal/foo/v1"
 	"github.com/advdv/stdgo/fx/stdpubprivrpcfx/internal/foo/v1/foov1connect"
+	"github.com/advdv/stdgo/stdhttpware"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 const testRPCBasePath = "/xr"
@@ -81,18 +86,68 @@ func TestHealthzFail(t *testing.T) {
 	require.Equal(t, http.StatusPreconditionFailed, rec.Code)
 }
 
+func TestLivezReadyz(t *testing.T) {
+	t.Parallel()
+	ctx, pubh, privh, _, _, _ := setupAll(t)
+
+	for _, hdlr := range []http.Handler{pubh, privh} {
+		for _, path := range []string{"/livez", "/readyz"} {
+			rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+			hdlr.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var report struct{ Status string }
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			require.Equal(t, "pass", report.Status)
+		}
+	}
+}
+
+func TestCORSDebug(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		ctx, _, privh, _, _, _ := setupAll(t)
+
+		rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet,
+			testRPCBasePath+"/debug/cors?origin=https://evil.co.uk&host=api.example.co.uk", nil)
+		privh.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	ctx, pubh, privh, _, _, _ := setupAll(t, map[string]string{"STDPUBPRIVRPC_CORS_DEBUG": "true"})
+
+	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet,
+		testRPCBasePath+"/debug/cors?origin=https://evil.co.uk&host=api.example.co.uk", nil)
+	privh.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var dec stdhttpware.OriginDecision
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dec))
+	require.False(t, dec.Allowed)
+	require.Contains(t, dec.Reason, `"evil.co.uk" differs from host's "example.co.uk"`)
+
+	rec, req = httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet,
+		testRPCBasePath+"/debug/cors?origin=https://evil.co.uk", nil)
+	pubh.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 func TestHealthzPanic(t *testing.T) {
 	t.Parallel()
 	var obs *observer.ObservedLogs
 	ctx, pubh, _, _, _, _ := setupAll(t, &obs)
 
-	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/healthz?force_panic=true", nil)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/healthz?force_panic=true", nil)
 	req.Header.Set("X-Request-Id", "2201419daf154fb4acd2000000000009")
 	pubh.ServeHTTP(rec, req)
 
 	require.Equal(t, http.StatusInternalServerError, rec.Code)
 	logEntries := obs.FilterMessage("panic while serving request").All()
-	require.Equal(t, "2201419daf154fb4acd2000000000009", logEntries[0].ContextMap()["request_header"].(http.Header)["X-Request-Id"][0])
+	reqHeader := logEntries[0].ContextMap()["request_header"].(http.Header)
+	require.Equal(t, "2201419daf154fb4acd2000000000009", reqHeader["X-Request-Id"][0])
 	require.Len(t, logEntries, 1)
 }
 
@@ -121,6 +176,18 @@ func TestRPCCalling(t *testing.T) {
 		require.NotNil(t, resp.Msg)
 	})
 
+	t.Run("rate limited", func(t *testing.T) {
+		t.Parallel()
+		ctx, _, _, ro, _, _ := setupAll(t, map[string]string{"STDRATELIMIT_DEFAULT_QUOTA": "1/1h"})
+		req := foov1.WhoAmIRequest_builder{Echo: proto.String("abc")}.Build()
+
+		_, err := ro.WhoAmI(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+
+		_, err = ro.WhoAmI(ctx, connect.NewRequest(req))
+		require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	})
+
 	t.Run("panic", func(t *testing.T) {
 		t.Parallel()
 		var obs *observer.ObservedLogs
@@ -173,9 +240,23 @@ func TestOpenApi(t *testing.T) {
 	var obs *observer.ObservedLogs
 	_, pubh, _, _, _, _ := setupAll(t, &obs)
 
-	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(context.Background(), http.MethodGet, testRPCBasePath+"/o/docs", nil)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, testRPCBasePath+"/o/docs", nil)
 	pubh.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	require.Contains(t, rec.Header().Get("Content-Security-Policy"), "https://unpkg.com")
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	t.Parallel()
+	ctx, pubh, _, _, _, _ := setupAll(t)
+
+	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/healthz", nil)
+	pubh.ServeHTTP(rec, req)
+	require.Equal(t, "default-src 'none'; frame-ancestors 'none'", rec.Header().Get("Content-Security-Policy"))
+	require.Empty(t, rec.Header().Get("Strict-Transport-Security"), "HSTS is opt-in")
+	require.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
 }
 
 func TestOpenApiSpec(t *testing.T) {
@@ -183,9 +264,105 @@ func TestOpenApiSpec(t *testing.T) {
 	var obs *observer.ObservedLogs
 	_, pubh, _, _, _, _ := setupAll(t, &obs)
 
-	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(context.Background(), http.MethodGet, testRPCBasePath+"/o/openapi-3.0.json", nil)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet,
+		testRPCBasePath+"/o/openapi-3.0.json", nil)
 	pubh.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusOK, rec.Result().StatusCode)
 
 	require.Contains(t, rec.Body.String(), "https://foo.bar/xr/o") // report the absolute url
 }
+
+func checkGRPCHealth(ctx context.Context, tb testing.TB, hdlr http.Handler, service string) (int, string) {
+	tb.Helper()
+
+	rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodPost,
+		testRPCBasePath+"/grpc.health.v1.Health/Check", strings.NewReader(`{"service":"`+service+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	hdlr.ServeHTTP(rec, req)
+
+	var resp struct{ Status string }
+	if rec.Code == http.StatusOK {
+		require.NoError(tb, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+
+	return rec.Code, resp.Status
+}
+
+func TestGRPCHealth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("private only", func(t *testing.T) {
+		t.Parallel()
+		ctx, pubh, privh, _, _, _ := setupAll(t)
+
+		code, status := checkGRPCHealth(ctx, t, privh, "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "SERVING_STATUS_SERVING", status)
+
+		code, status = checkGRPCHealth(ctx, t, privh, foov1connect.SystemServiceName)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "SERVING_STATUS_SERVING", status)
+
+		code, _ = checkGRPCHealth(ctx, t, privh, foov1connect.ReadOnlyServiceName)
+		require.Equal(t, http.StatusNotFound, code)
+
+		code, _ = checkGRPCHealth(ctx, t, pubh, "")
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("public enabled", func(t *testing.T) {
+		t.Parallel()
+		ctx, pubh, _, _, _, _ := setupAll(t, map[string]string{"STDPUBPRIVRPC_PUBLIC_GRPC_INTROSPECTION": "true"})
+
+		code, status := checkGRPCHealth(ctx, t, pubh, foov1connect.ReadWriteServiceName)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "SERVING_STATUS_SERVING", status)
+
+		code, _ = checkGRPCHealth(ctx, t, pubh, foov1connect.SystemServiceName)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		t.Parallel()
+		ctx, _, privh, _, _, _ := setupAll(t, stdhealthfx.ProvideCheck(stdhealthfx.Check{
+			Name: "db", Kind: stdhealthfx.Readiness, Critical: true,
+			Run: func(context.Context) error { return errors.New("down") },
+		}))
+
+		code, status := checkGRPCHealth(ctx, t, privh, "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "SERVING_STATUS_NOT_SERVING", status)
+	})
+}
+
+func TestGRPCReflection(t *testing.T) {
+	t.Parallel()
+	ctx, pubh, privh, _, _, _ := setupAll(t, map[string]string{"STDPUBPRIVRPC_PUBLIC_GRPC_INTROSPECTION": "true"})
+
+	for _, tc := range []struct {
+		hdlr        http.Handler
+		expServices []protoreflect.FullName
+	}{
+		{privh, []protoreflect.FullName{foov1connect.SystemServiceName, "grpc.health.v1.Health"}},
+		{pubh, []protoreflect.FullName{
+			foov1connect.ReadOnlyServiceName, foov1connect.ReadWriteServiceName, "grpc.health.v1.Health",
+		}},
+	} {
+		srv := httptest.NewUnstartedServer(tc.hdlr)
+		srv.EnableHTTP2 = true
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		stream := grpcreflect.NewClient(srv.Client(), srv.URL+testRPCBasePath).NewStream(ctx)
+		services, listErr := stream.ListServices()
+		files, fileErr := stream.FileContainingSymbol(tc.expServices[0])
+		_, err := stream.Close()
+		require.NoError(t, err)
+
+		require.NoError(t, listErr)
+		require.ElementsMatch(t, tc.expServices, services)
+		require.NoError(t, fileErr)
+		require.NotEmpty(t, files)
+	}
+}
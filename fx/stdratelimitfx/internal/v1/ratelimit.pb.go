@@ -0,0 +1,272 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: fx/stdratelimitfx/internal/v1/ratelimit.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LimitedRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LimitedRequest) Reset() {
+	*x = LimitedRequest{}
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitedRequest) ProtoMessage() {}
+
+func (x *LimitedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type LimitedRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 LimitedRequest_builder) Build() *LimitedRequest {
+	m0 := &LimitedRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type LimitedResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LimitedResponse) Reset() {
+	*x = LimitedResponse{}
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitedResponse) ProtoMessage() {}
+
+func (x *LimitedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type LimitedResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 LimitedResponse_builder) Build() *LimitedResponse {
+	m0 := &LimitedResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type UnlimitedRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlimitedRequest) Reset() {
+	*x = UnlimitedRequest{}
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlimitedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlimitedRequest) ProtoMessage() {}
+
+func (x *UnlimitedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type UnlimitedRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 UnlimitedRequest_builder) Build() *UnlimitedRequest {
+	m0 := &UnlimitedRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type UnlimitedResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlimitedResponse) Reset() {
+	*x = UnlimitedResponse{}
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlimitedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlimitedResponse) ProtoMessage() {}
+
+func (x *UnlimitedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type UnlimitedResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 UnlimitedResponse_builder) Build() *UnlimitedResponse {
+	m0 := &UnlimitedResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+var file_fx_stdratelimitfx_internal_v1_ratelimit_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50100,
+		Name:          "fx.stdratelimitfx.internal.v1.quota",
+		Tag:           "bytes,50100,opt,name=quota",
+		Filename:      "fx/stdratelimitfx/internal/v1/ratelimit.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional string quota = 50100;
+	E_Quota = &file_fx_stdratelimitfx_internal_v1_ratelimit_proto_extTypes[0]
+)
+
+var File_fx_stdratelimitfx_internal_v1_ratelimit_proto protoreflect.FileDescriptor
+
+const file_fx_stdratelimitfx_internal_v1_ratelimit_proto_rawDesc = "" +
+	"\n" +
+	"-fx/stdratelimitfx/internal/v1/ratelimit.proto\x12\x1dfx.stdratelimitfx.internal.v1\x1a google/protobuf/descriptor.proto\"\x10\n" +
+	"\x0eLimitedRequest\"\x11\n" +
+	"\x0fLimitedResponse\"\x12\n" +
+	"\x10UnlimitedRequest\"\x13\n" +
+	"\x11UnlimitedResponse2\xf3\x01\n" +
+	"\vTestService\x12r\n" +
+	"\aLimited\x12-.fx.stdratelimitfx.internal.v1.LimitedRequest\x1a..fx.stdratelimitfx.internal.v1.LimitedResponse\"\b\xa2\xbb\x18\x042/1h\x12p\n" +
+	"\tUnlimited\x12/.fx.stdratelimitfx.internal.v1.UnlimitedRequest\x1a0.fx.stdratelimitfx.internal.v1.UnlimitedResponse\"\x00:6\n" +
+	"\x05quota\x12\x1e.google.protobuf.MethodOptions\x18\xb4\x87\x03 \x01(\tR\x05quotaB\x8b\x02\n" +
+	"!com.fx.stdratelimitfx.internal.v1B\x0eRatelimitProtoP\x01Z?github.com/advdv/stdgo/fx/stdratelimitfx/internal/v1;internalv1\xa2\x02\x03FSI\xaa\x02\x1dFx.Stdratelimitfx.Internal.V1\xca\x02\x1dFx\\Stdratelimitfx\\Internal\\V1\xe2\x02)Fx\\Stdratelimitfx\\Internal\\V1\\GPBMetadata\xea\x02 Fx::Stdratelimitfx::Internal::V1b\beditionsp\xe8\a"
+
+var file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_fx_stdratelimitfx_internal_v1_ratelimit_proto_goTypes = []any{
+	(*LimitedRequest)(nil),             // 0: fx.stdratelimitfx.internal.v1.LimitedRequest
+	(*LimitedResponse)(nil),            // 1: fx.stdratelimitfx.internal.v1.LimitedResponse
+	(*UnlimitedRequest)(nil),           // 2: fx.stdratelimitfx.internal.v1.UnlimitedRequest
+	(*UnlimitedResponse)(nil),          // 3: fx.stdratelimitfx.internal.v1.UnlimitedResponse
+	(*descriptorpb.MethodOptions)(nil), // 4: google.protobuf.MethodOptions
+}
+var file_fx_stdratelimitfx_internal_v1_ratelimit_proto_depIdxs = []int32{
+	4, // 0: fx.stdratelimitfx.internal.v1.quota:extendee -> google.protobuf.MethodOptions
+	0, // 1: fx.stdratelimitfx.internal.v1.TestService.Limited:input_type -> fx.stdratelimitfx.internal.v1.LimitedRequest
+	2, // 2: fx.stdratelimitfx.internal.v1.TestService.Unlimited:input_type -> fx.stdratelimitfx.internal.v1.UnlimitedRequest
+	1, // 3: fx.stdratelimitfx.internal.v1.TestService.Limited:output_type -> fx.stdratelimitfx.internal.v1.LimitedResponse
+	3, // 4: fx.stdratelimitfx.internal.v1.TestService.Unlimited:output_type -> fx.stdratelimitfx.internal.v1.UnlimitedResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_fx_stdratelimitfx_internal_v1_ratelimit_proto_init() }
+func file_fx_stdratelimitfx_internal_v1_ratelimit_proto_init() {
+	if File_fx_stdratelimitfx_internal_v1_ratelimit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fx_stdratelimitfx_internal_v1_ratelimit_proto_rawDesc), len(file_fx_stdratelimitfx_internal_v1_ratelimit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 1,
+			NumServices:   1,
+		},
+		GoTypes:           file_fx_stdratelimitfx_internal_v1_ratelimit_proto_goTypes,
+		DependencyIndexes: file_fx_stdratelimitfx_internal_v1_ratelimit_proto_depIdxs,
+		MessageInfos:      file_fx_stdratelimitfx_internal_v1_ratelimit_proto_msgTypes,
+		ExtensionInfos:    file_fx_stdratelimitfx_internal_v1_ratelimit_proto_extTypes,
+	}.Build()
+	File_fx_stdratelimitfx_internal_v1_ratelimit_proto = out.File
+	file_fx_stdratelimitfx_internal_v1_ratelimit_proto_goTypes = nil
+	file_fx_stdratelimitfx_internal_v1_ratelimit_proto_depIdxs = nil
+}
@@ -0,0 +1,90 @@
+package stdratelimitfx
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps token buckets in memory. Buckets are only shared within a single process.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	quota   Quota
+}
+
+// NewMemoryStore inits an in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return newMemoryStore(time.Now)
+}
+
+func newMemoryStore(now func() time.Time) *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: now, lastSweep: now()}
+}
+
+// Take implements [Store].
+func (s *MemoryStore) Take(_ context.Context, key string, quota Quota) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(quota.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	b.quota = quota
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return Decision{Remaining: 0, RetryAfter: quota.retryAfter(b.tokens)}, nil
+	}
+
+	b.tokens--
+
+	return Decision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// Len returns the number of buckets that are kept in memory.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets)
+}
+
+// sweep removes buckets that are full again, they behave the same as buckets that don't exist. It runs at most once
+// a minute so it doesn't add to every request.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if b.refill(now); b.tokens >= float64(b.quota.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// refill adds the tokens that accumulated since the last update.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.quota.Burst), b.tokens+elapsed*b.quota.rate())
+	}
+
+	b.updated = now
+}
@@ -0,0 +1,147 @@
+package stdratelimitfx
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// PostgresSchema creates the table that holds the token buckets of the [PostgresStore]. It should be part of the
+// application's migrations.
+const PostgresSchema = `CREATE TABLE IF NOT EXISTS stdratelimit_buckets (
+	key text PRIMARY KEY,
+	tokens double precision NOT NULL,
+	allowed boolean NOT NULL,
+	updated_at timestamptz NOT NULL,
+	full_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS stdratelimit_buckets_full_at ON stdratelimit_buckets (full_at);`
+
+// takeSQL refills the bucket for the time that passed and takes a token from it if there is one. It is a single
+// statement so concurrent requests for the same key are serialized by the row lock. A new bucket starts full. The
+// time at which the bucket is full again is recorded so it can be purged.
+const takeSQL = `INSERT INTO stdratelimit_buckets AS b (key, tokens, allowed, updated_at, full_at)
+VALUES ($1, $2::float8 - 1, true, clock_timestamp(), clock_timestamp() + make_interval(secs => 1 / $3::float8))
+ON CONFLICT (key) DO UPDATE SET (tokens, allowed, updated_at, full_at) = (
+	SELECT n.tokens, n.allowed, n.now, n.now + make_interval(secs => ($2::float8 - n.tokens) / $3::float8)
+	FROM (
+		SELECT CASE WHEN r.refilled >= 1 THEN r.refilled - 1 ELSE r.refilled END AS tokens,
+			r.refilled >= 1 AS allowed, r.now
+		FROM (
+			SELECT LEAST($2::float8, b.tokens + GREATEST(0, EXTRACT(EPOCH FROM t.now - b.updated_at)) * $3::float8)
+				AS refilled, t.now
+			FROM (SELECT clock_timestamp() AS now) t
+		) r
+	) n
+)
+RETURNING tokens, allowed`
+
+// purgeSQL deletes the buckets that are full again, they behave the same as buckets that don't exist.
+const purgeSQL = `DELETE FROM stdratelimit_buckets WHERE full_at < clock_timestamp()`
+
+// Querier is the subset of a pgx pool that is used by the [PostgresStore].
+type Querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// PostgresStore keeps token buckets in Postgres so they are shared between processes.
+type PostgresStore struct {
+	db Querier
+}
+
+// NewPostgresStore inits a store on the given database. The table from [PostgresSchema] must exist.
+func NewPostgresStore(db Querier) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take implements [Store].
+func (s *PostgresStore) Take(ctx context.Context, key string, quota Quota) (Decision, error) {
+	var (
+		tokens  float64
+		allowed bool
+	)
+
+	if err := s.db.QueryRow(ctx, takeSQL, key, float64(quota.Burst), quota.rate()).Scan(&tokens, &allowed); err != nil {
+		return Decision{}, fmt.Errorf("take token: %w", err)
+	}
+
+	if !allowed {
+		return Decision{RetryAfter: quota.retryAfter(tokens)}, nil
+	}
+
+	return Decision{Allowed: true, Remaining: int(math.Floor(tokens))}, nil
+}
+
+// PurgeFull deletes the buckets that are full again, so the table doesn't grow with every key that was ever seen.
+// It returns the number of deleted buckets.
+func (s *PostgresStore) PurgeFull(ctx context.Context) (int64, error) {
+	tag, err := s.db.Exec(ctx, purgeSQL)
+	if err != nil {
+		return 0, fmt.Errorf("purge full buckets: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// purgeInterval is how often [ProvidePostgresStore] purges buckets that are full again.
+const purgeInterval = time.Minute
+
+// ProvidePostgresStore provides a [PostgresStore] on the pgx pool with the given name, see stdpgxfx. The rate
+// limiter will use it instead of the in-memory store. Buckets that are full again are purged every minute while the
+// application runs.
+func ProvidePostgresStore(poolName string) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(lc fx.Lifecycle, logs *zap.Logger, db *pgxpool.Pool) Store {
+			store := NewPostgresStore(db)
+
+			var (
+				done = make(chan struct{})
+				wg   sync.WaitGroup
+			)
+
+			lc.Append(fx.Hook{
+				OnStart: func(context.Context) error {
+					wg.Add(1)
+
+					go func() {
+						defer wg.Done()
+
+						ticker := time.NewTicker(purgeInterval)
+						defer ticker.Stop()
+
+						for {
+							select {
+							case <-done:
+								return
+							case <-ticker.C:
+							}
+
+							if _, err := store.PurgeFull(context.Background()); err != nil {
+								logs.Error("failed to purge rate limit buckets", zap.Error(err))
+							}
+						}
+					}()
+
+					return nil
+				},
+				OnStop: func(context.Context) error {
+					close(done)
+					wg.Wait()
+
+					return nil
+				},
+			})
+
+			return store
+		},
+		fx.ParamTags(``, ``, `name:"`+poolName+`"`)))
+}
@@ -0,0 +1,390 @@
+// Package stdratelimitfx provides rate limiting of requests as a Connect interceptor.
+package stdratelimitfx
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdauthnfx"
+	"github.com/advdv/stdgo/fx/stdcrpcauthfx"
+	"github.com/advdv/stdgo/stdcrpc"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdfx"
+	"github.com/advdv/stdgo/stdhttpware"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Config configures the rate limiter.
+type Config struct {
+	// DefaultQuota applies to every request for which no more specific quota is declared, formatted as
+	// "LIMIT/PERIOD" or "LIMIT/PERIOD/BURST". It is off by default, so only procedures that declare a quota are
+	// limited.
+	DefaultQuota Quota `env:"DEFAULT_QUOTA" envDefault:"off"`
+	// Keys determine what requests are counted together. The first key that is available for a request is used.
+	Keys []string `env:"KEYS" envDefault:"api_key,subject,ip" validate:"oneof=api_key|subject|tenant|ip"`
+}
+
+// Quota allows for Limit requests per Period, with bursts of up to Burst requests.
+type Quota struct {
+	Limit  int
+	Period time.Duration
+	Burst  int
+}
+
+// ParseQuota parses a quota formatted as "LIMIT/PERIOD" or "LIMIT/PERIOD/BURST", for example "100/1m". When the
+// burst is not specified it is equal to the limit. An empty string or "off" returns the zero quota.
+func ParseQuota(s string) (q Quota, err error) {
+	return q, q.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so quotas can be read from the environment.
+func (q *Quota) UnmarshalText(text []byte) (err error) {
+	*q = Quota{}
+	if len(text) == 0 || string(text) == "off" {
+		return nil
+	}
+
+	parts := strings.Split(string(text), "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid quota %q, must be formatted as LIMIT/PERIOD or LIMIT/PERIOD/BURST", text)
+	}
+
+	if q.Limit, err = strconv.Atoi(parts[0]); err != nil || q.Limit < 1 {
+		return fmt.Errorf("invalid quota %q, limit must be a positive integer", text)
+	}
+
+	if q.Period, err = time.ParseDuration(parts[1]); err != nil || q.Period <= 0 {
+		return fmt.Errorf("invalid quota %q, period must be a positive duration", text)
+	}
+
+	q.Burst = q.Limit
+	if len(parts) == 3 {
+		if q.Burst, err = strconv.Atoi(parts[2]); err != nil || q.Burst < 1 {
+			return fmt.Errorf("invalid quota %q, burst must be a positive integer", text)
+		}
+	}
+
+	return nil
+}
+
+// String formats the quota so it can be parsed again.
+func (q Quota) String() string {
+	if q.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("%d/%s/%d", q.Limit, q.Period, q.Burst)
+}
+
+// IsZero returns true if the quota doesn't limit anything.
+func (q Quota) IsZero() bool { return q.Limit == 0 }
+
+// rate returns the number of tokens that are added to the bucket every second.
+func (q Quota) rate() float64 { return float64(q.Limit) / q.Period.Seconds() }
+
+// retryAfter returns how long it takes for the bucket to hold a whole token again.
+func (q Quota) retryAfter(tokens float64) time.Duration {
+	return time.Duration(math.Ceil((1 - tokens) / q.rate() * float64(time.Second)))
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	// Allowed is true if a token was taken.
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// RetryAfter is how long the client should wait before trying again, if not allowed.
+	RetryAfter time.Duration
+}
+
+// Store holds a token bucket for every key. Implementations must be safe for concurrent use.
+type Store interface {
+	Take(ctx context.Context, key string, quota Quota) (Decision, error)
+}
+
+// KeyFunc determines the key requests are counted by. It returns false if the key is not available for the request.
+type KeyFunc func(ctx context.Context) (string, bool)
+
+// KeyByAPIKey counts requests by the fingerprint of the API key they were authenticated with.
+func KeyByAPIKey(ctx context.Context) (string, bool) {
+	fp, ok := stdauthnfx.APIKeyFingerprint(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return "api_key:" + hex.EncodeToString(fp), true
+}
+
+// KeyBySubject counts requests by the subject they were authenticated as.
+func KeyBySubject(ctx context.Context) (string, bool) {
+	if access, ok := stdauthnfx.AccessFromContext(ctx); ok && access.HasWebuserIdentity() {
+		return "subject:" + access.GetWebuserIdentity().GetSubject(), true
+	}
+
+	if sub := stdcrpcauthfx.ClaimsFromContext(ctx).Subject; sub != "" {
+		return "subject:" + sub, true
+	}
+
+	return "", false
+}
+
+// KeyByTenant counts requests by the tenant of the authenticated claims.
+func KeyByTenant(ctx context.Context) (string, bool) {
+	if tenant := stdcrpcauthfx.ClaimsFromContext(ctx).TenantID; tenant != "" {
+		return "tenant:" + tenant, true
+	}
+
+	return "", false
+}
+
+// KeyByClientIP counts requests by the IP of the client, as resolved by stdhttpware.
+func KeyByClientIP(ctx context.Context) (string, bool) {
+	ip, ok := stdctx.ClientIP(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return "ip:" + ip.String(), true
+}
+
+// keyFuncs maps the configurable key names to their implementation.
+var keyFuncs = map[string]KeyFunc{
+	"api_key": KeyByAPIKey,
+	"subject": KeyBySubject,
+	"tenant":  KeyByTenant,
+	"ip":      KeyByClientIP,
+}
+
+// QuotaResolver determines the quota for a Connect procedure. It returns false if the procedure has no specific quota.
+type QuotaResolver interface {
+	Quota(procedure string) (Quota, bool, error)
+}
+
+// protoExtensionQuotaResolver resolves quotas from a string-typed protobuf method option extension.
+type protoExtensionQuotaResolver struct {
+	ext    protoreflect.ExtensionType
+	quotas sync.Map
+}
+
+// resolvedQuota is cached per procedure.
+type resolvedQuota struct {
+	quota Quota
+	ok    bool
+}
+
+func (r *protoExtensionQuotaResolver) Quota(procedure string) (Quota, bool, error) {
+	if v, ok := r.quotas.Load(procedure); ok {
+		res := v.(resolvedQuota) //nolint:forcetypeassert
+
+		return res.quota, res.ok, nil
+	}
+
+	method, err := stdcrpc.MethodDescriptor(connect.Spec{Procedure: procedure})
+	if err != nil {
+		return Quota{}, false, err
+	}
+
+	var res resolvedQuota
+	if opts, ok := method.Options().(*descriptorpb.MethodOptions); ok && proto.HasExtension(opts, r.ext) {
+		raw, ok := proto.GetExtension(opts, r.ext).(string)
+		if !ok {
+			return Quota{}, false, fmt.Errorf("method %q: quota extension is not a string", method.FullName())
+		}
+
+		if res.quota, err = ParseQuota(raw); err != nil {
+			return Quota{}, false, fmt.Errorf("method %q: %w", method.FullName(), err)
+		}
+
+		res.ok = true
+	}
+
+	r.quotas.Store(procedure, res)
+
+	return res.quota, res.ok, nil
+}
+
+// ProtoExtensionQuota returns an fx.Option that provides a QuotaResolver backed by the given string-typed protobuf
+// method option extension. The value is formatted like [ParseQuota], "off" exempts the procedure from rate limiting.
+// Procedures without the option fall back to the default quota.
+func ProtoExtensionQuota(ext protoreflect.ExtensionType) fx.Option {
+	return fx.Provide(func() QuotaResolver {
+		return &protoExtensionQuotaResolver{ext: ext}
+	})
+}
+
+// Limiter limits the rate of requests per key.
+type Limiter struct {
+	cfg    Config
+	logs   *zap.Logger
+	store  Store
+	quotas QuotaResolver
+	keys   []KeyFunc
+}
+
+// New inits the limiter. Without a store provided, an in-memory store is used that only limits requests within this
+// process.
+func New(deps struct {
+	fx.In
+
+	Config Config
+	Logs   *zap.Logger
+	Store  Store         `optional:"true"`
+	Quotas QuotaResolver `optional:"true"`
+},
+) (*Limiter, error) {
+	lim := &Limiter{cfg: deps.Config, logs: deps.Logs, store: deps.Store, quotas: deps.Quotas}
+	if lim.store == nil {
+		lim.store = NewMemoryStore()
+	}
+
+	for _, name := range deps.Config.Keys {
+		keyFunc, ok := keyFuncs[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported rate limit key: %q", name)
+		}
+
+		lim.keys = append(lim.keys, keyFunc)
+	}
+
+	return lim, nil
+}
+
+// Take takes a token for the request in the context. The procedure determines the quota if a quota resolver is
+// provided, the default quota is used otherwise. Requests for which no key or quota is available are always allowed,
+// and so are requests for which the store fails.
+func (lim *Limiter) Take(ctx context.Context, procedure string) Decision {
+	key, ok := lim.key(ctx)
+	if !ok {
+		return Decision{Allowed: true}
+	}
+
+	quota := lim.cfg.DefaultQuota
+	if lim.quotas != nil && procedure != "" {
+		switch pquota, ok, err := lim.quotas.Quota(procedure); {
+		case err != nil:
+			lim.logs.Error("failed to resolve quota, using default",
+				zap.String("procedure", procedure), zap.Error(err))
+		case ok:
+			// procedures with their own quota also have their own bucket.
+			quota, key = pquota, key+"|"+procedure
+		}
+	}
+
+	if quota.IsZero() {
+		return Decision{Allowed: true}
+	}
+
+	dec, err := lim.store.Take(ctx, key, quota)
+	if err != nil {
+		lim.logs.Error("failed to take from rate limit bucket, allowing request", zap.Error(err))
+
+		return Decision{Allowed: true}
+	}
+
+	return dec
+}
+
+// key returns the first key that is available for the request.
+func (lim *Limiter) key(ctx context.Context) (string, bool) {
+	for _, keyFunc := range lim.keys {
+		if key, ok := keyFunc(ctx); ok {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// Interceptor limits Connect requests with the quota of the procedure. It fails with CodeResourceExhausted when the
+// quota is exceeded, with a Retry-After header and RetryInfo detail.
+func (lim *Limiter) Interceptor() connect.Interceptor { return interceptor{lim} }
+
+type interceptor struct{ lim *Limiter }
+
+func (i interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		if err := i.take(ctx, req.Spec().Procedure, req.Peer().Addr); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (i interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.take(ctx, conn.Spec().Procedure, conn.Peer().Addr); err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
+	}
+}
+
+func (i interceptor) take(ctx context.Context, procedure, peerAddr string) error {
+	dec := i.lim.Take(withPeerIP(ctx, peerAddr), procedure)
+	if dec.Allowed {
+		return nil
+	}
+
+	cerr := connect.NewError(connect.CodeResourceExhausted,
+		errors.New("rate limit exceeded, retry after "+dec.RetryAfter.String()))
+	cerr.Meta().Set("Retry-After", retryAfterSeconds(dec.RetryAfter))
+
+	if detail, err := connect.NewErrorDetail(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(dec.RetryAfter),
+	}); err == nil {
+		cerr.AddDetail(detail)
+	}
+
+	return cerr
+}
+
+// withPeerIP adds the IP of the peer as the client IP, if the client IP was not already resolved by stdhttpware.
+func withPeerIP(ctx context.Context, peerAddr string) context.Context {
+	if _, ok := stdctx.ClientIP(ctx); ok {
+		return ctx
+	}
+
+	req := &http.Request{RemoteAddr: peerAddr}
+	if ip, _ := stdhttpware.ResolveClientIP(req, stdhttpware.ClientIPConfig{}); ip.IsValid() {
+		return stdctx.WithClientIP(ctx, ip, nil)
+	}
+
+	return ctx
+}
+
+// retryAfterSeconds formats the duration as whole seconds for the Retry-After header, rounded up.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// Provide the rate limiter. Provide a [Store] to share buckets between processes, see [ProvidePostgresStore]. Its
+// interceptor is contributed to the "public_interceptors" group, so stdpubprivrpcfx limits its public handlers.
+func Provide() fx.Option {
+	return stdfx.ZapEnvCfgModule[Config]("stdratelimit", New,
+		fx.Provide(fx.Annotate((*Limiter).Interceptor, fx.ResultTags(`group:"public_interceptors"`))))
+}
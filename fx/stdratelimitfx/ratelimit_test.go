@@ -0,0 +1,190 @@
+package stdratelimitfx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/fx/stdratelimitfx"
+	internalv1 "github.com/advdv/stdgo/fx/stdratelimitfx/internal/v1"
+	"github.com/advdv/stdgo/fx/stdratelimitfx/internal/v1/internalv1connect"
+	"github.com/advdv/stdgo/fx/stdzapfx"
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestParseQuota(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in     string
+		exp    stdratelimitfx.Quota
+		expErr string
+	}{
+		{in: "", exp: stdratelimitfx.Quota{}},
+		{in: "off", exp: stdratelimitfx.Quota{}},
+		{in: "100/1m", exp: stdratelimitfx.Quota{Limit: 100, Period: time.Minute, Burst: 100}},
+		{in: "2/1s/10", exp: stdratelimitfx.Quota{Limit: 2, Period: time.Second, Burst: 10}},
+		{in: "100", expErr: "must be formatted as"},
+		{in: "0/1m", expErr: "limit must be a positive integer"},
+		{in: "1/0s", expErr: "period must be a positive duration"},
+		{in: "1/1s/x", expErr: "burst must be a positive integer"},
+	} {
+		quota, err := stdratelimitfx.ParseQuota(tc.in)
+		if tc.expErr != "" {
+			require.ErrorContains(t, err, tc.expErr)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, tc.exp, quota)
+
+		if !tc.exp.IsZero() {
+			again, err := stdratelimitfx.ParseQuota(quota.String())
+			require.NoError(t, err)
+			require.Equal(t, quota, again)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := stdratelimitfx.NewMemoryStore()
+	quota := stdratelimitfx.Quota{Limit: 1, Period: time.Hour, Burst: 2}
+
+	dec, err := store.Take(t.Context(), "a", quota)
+	require.NoError(t, err)
+	require.Equal(t, stdratelimitfx.Decision{Allowed: true, Remaining: 1}, dec)
+
+	dec, err = store.Take(t.Context(), "a", quota)
+	require.NoError(t, err)
+	require.Equal(t, stdratelimitfx.Decision{Allowed: true, Remaining: 0}, dec)
+
+	dec, err = store.Take(t.Context(), "a", quota)
+	require.NoError(t, err)
+	require.False(t, dec.Allowed)
+	require.InDelta(t, time.Hour, dec.RetryAfter, float64(time.Second))
+
+	dec, err = store.Take(t.Context(), "b", quota)
+	require.NoError(t, err)
+	require.True(t, dec.Allowed, "other keys have their own bucket")
+	require.Equal(t, 2, store.Len())
+
+	fast := stdratelimitfx.Quota{Limit: 1, Period: 10 * time.Millisecond, Burst: 1}
+	dec, _ = store.Take(t.Context(), "c", fast)
+	require.True(t, dec.Allowed)
+	dec, _ = store.Take(t.Context(), "c", fast)
+	require.False(t, dec.Allowed)
+
+	time.Sleep(20 * time.Millisecond)
+
+	dec, _ = store.Take(t.Context(), "c", fast)
+	require.True(t, dec.Allowed, "bucket should have refilled")
+}
+
+type testService struct{}
+
+func (testService) Limited(
+	context.Context, *connect.Request[internalv1.LimitedRequest],
+) (*connect.Response[internalv1.LimitedResponse], error) {
+	return connect.NewResponse(&internalv1.LimitedResponse{}), nil
+}
+
+func (testService) Unlimited(
+	context.Context, *connect.Request[internalv1.UnlimitedRequest],
+) (*connect.Response[internalv1.UnlimitedResponse], error) {
+	return connect.NewResponse(&internalv1.UnlimitedResponse{}), nil
+}
+
+func setup(tb testing.TB, env map[string]string, opts ...fx.Option) (lim *stdratelimitfx.Limiter) {
+	tb.Helper()
+
+	app := fxtest.New(tb, append(opts,
+		stdenvcfg.ProvideExplicitEnvironment(env),
+		stdzapfx.Fx(),
+		stdzapfx.TestProvide(tb),
+		stdratelimitfx.Provide(),
+		fx.Populate(&lim),
+	)...)
+	app.RequireStart()
+	tb.Cleanup(app.RequireStop)
+
+	return lim
+}
+
+func TestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	lim := setup(t, map[string]string{"STDRATELIMIT_DEFAULT_QUOTA": "3/1h"},
+		stdratelimitfx.ProtoExtensionQuota(internalv1.E_Quota))
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(testService{}, connect.WithInterceptors(lim.Interceptor())))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := internalv1connect.NewTestServiceClient(srv.Client(), srv.URL)
+
+	for range 2 {
+		_, err := client.Limited(t.Context(), connect.NewRequest(&internalv1.LimitedRequest{}))
+		require.NoError(t, err)
+	}
+
+	_, err := client.Limited(t.Context(), connect.NewRequest(&internalv1.LimitedRequest{}))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+	var cerr *connect.Error
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, "1800", cerr.Meta().Get("Retry-After"))
+	require.Len(t, cerr.Details(), 1)
+
+	detail, err := cerr.Details()[0].Value()
+	require.NoError(t, err)
+	require.InDelta(t, 30*time.Minute, detail.(*errdetails.RetryInfo).GetRetryDelay().AsDuration(), float64(time.Second))
+
+	// the procedure without its own quota uses the default bucket.
+	for range 3 {
+		_, err := client.Unlimited(t.Context(), connect.NewRequest(&internalv1.UnlimitedRequest{}))
+		require.NoError(t, err)
+	}
+
+	_, err = client.Unlimited(t.Context(), connect.NewRequest(&internalv1.UnlimitedRequest{}))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestNoDefaultQuota(t *testing.T) {
+	t.Parallel()
+
+	// limiting is opt-in, only procedures that declare a quota are limited by default.
+	lim := setup(t, map[string]string{}, stdratelimitfx.ProtoExtensionQuota(internalv1.E_Quota))
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(testService{}, connect.WithInterceptors(lim.Interceptor())))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := internalv1connect.NewTestServiceClient(srv.Client(), srv.URL)
+
+	for range 100 {
+		_, err := client.Unlimited(t.Context(), connect.NewRequest(&internalv1.UnlimitedRequest{}))
+		require.NoError(t, err)
+	}
+
+	for range 2 {
+		_, err := client.Limited(t.Context(), connect.NewRequest(&internalv1.LimitedRequest{}))
+		require.NoError(t, err)
+	}
+
+	_, err := client.Limited(t.Context(), connect.NewRequest(&internalv1.LimitedRequest{}))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
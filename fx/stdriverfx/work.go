@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	"github.com/advdv/stdgo/stdctx"
 	"github.com/advdv/stdgo/stdfx"
 	"github.com/jackc/pgx/v5"
@@ -106,6 +107,11 @@ func (w Workers) Ping(ctx context.Context) error {
 	return nil
 }
 
+// newHealthCheck contributes the ping of the job tables as a readiness check.
+func newHealthCheck(w *Workers) stdhealthfx.Check {
+	return stdhealthfx.Check{Name: "river", Kind: stdhealthfx.Readiness, Critical: true, Run: w.Ping}
+}
+
 // GetJobByKinds returns all jobs by the kind string(s).
 func (w Workers) GetJobByKinds(
 	ctx context.Context, tx pgx.Tx, kind string, moreKinds ...string,
@@ -234,6 +240,7 @@ func Provide(cbf ...ClientBuilderFunc) fx.Option {
 		fx.Provide(fx.Annotate(cbf[0], fx.ParamTags(`name:"rw"`))),
 		fx.Provide(newRiverWorkers),
 		fx.Provide(NewUIServer),
+		fx.Provide(stdhealthfx.AsCheck(newHealthCheck)),
 
 		// ensure the client is actually started
 		fx.Invoke(func(*Workers) {}),
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/advdv/stdgo/fx/stdhealthfx"
 	"github.com/advdv/stdgo/stdfx"
 	slogzap "github.com/samber/slog-zap/v2"
 	"go.uber.org/fx"
@@ -156,6 +157,11 @@ func (c *Temporal) CheckHealth(ctx context.Context) error {
 	return nil
 }
 
+// newHealthCheck contributes the health of the Temporal client as a readiness check.
+func newHealthCheck(c *Temporal) stdhealthfx.Check {
+	return stdhealthfx.Check{Name: "temporal", Kind: stdhealthfx.Readiness, Critical: true, Run: c.CheckHealth}
+}
+
 // Namespace this client is using.
 func (c *Temporal) Namespace() string {
 	return c.namespace
@@ -189,5 +195,7 @@ func Provide() fx.Option {
 		fx.Provide(NewDefaultWorkerInterceptor, NewDefaultClientInterceptor),
 		// provide the workers
 		fx.Provide(NewWorkers),
+		// contribute to the health registry
+		fx.Provide(stdhealthfx.AsCheck(newHealthCheck)),
 	)
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/advdv/stdgo/stdenvcfg"
 	"github.com/advdv/stdgo/stdfx"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -77,8 +78,22 @@ func newWriteSyncer(cfg Config) (zapcore.WriteSyncer, error) {
 	return sync, nil
 }
 
-func newLevelEnabler(cfg Config) zapcore.LevelEnabler {
-	return cfg.Level
+func newLevelEnabler(deps struct {
+	fx.In
+
+	Config     Config
+	Reloadable *stdenvcfg.Reloadable[Config] `optional:"true"`
+},
+) zapcore.LevelEnabler {
+	if deps.Reloadable == nil {
+		return deps.Config.Level
+	}
+
+	// with reloadable configuration, the level can change while the process is running.
+	level := zap.NewAtomicLevelAt(deps.Reloadable.Get().Level)
+	deps.Reloadable.Subscribe(func(cfg Config) { level.SetLevel(cfg.Level) })
+
+	return level
 }
 
 // newEncoder constructs the encoder based on the encoder config and our env config.
@@ -99,6 +114,12 @@ func newEncoderConfig(cfg Config) zapcore.EncoderConfig {
 	return zap.NewProductionEncoderConfig()
 }
 
+// ProvideReloadable makes the logging level reloadable while the process is running. It requires the reloader
+// provided by [stdenvcfg.ProvideReloader].
+func ProvideReloadable() fx.Option {
+	return stdenvcfg.ProvideReloadable[Config]("STDZAP_")
+}
+
 // Provide provides the package's components as an fx module.
 func Provide() fx.Option {
 	return stdfx.ZapEnvCfgModule[Config]("stdzap", New,
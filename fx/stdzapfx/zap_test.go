@@ -46,3 +46,31 @@ func TestNonTestingLogger(t *testing.T) {
 	assert.NotContains(t, string(data), "some-info-message")
 	assert.Contains(t, string(data), "some-warn-message")
 }
+
+func TestReloadableLevel(t *testing.T) {
+	cfgfp := filepath.Join(t.TempDir(), "config.env")
+	require.NoError(t, os.WriteFile(cfgfp, []byte("STDZAP_LEVEL=warn"), 0o600))
+
+	var deps struct {
+		fx.In
+		Logs     *zap.Logger
+		Reloader *stdenvcfg.Reloader
+	}
+
+	app := fxtest.New(t,
+		stdzapfx.TestProvide(t),
+		stdzapfx.ProvideReloadable(),
+		stdenvcfg.ProvideReloader(),
+		stdenvcfg.ProvideLayeredEnvironment(stdenvcfg.FileLayer(cfgfp)),
+		fx.Populate(&deps),
+	)
+
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	assert.False(t, deps.Logs.Core().Enabled(zap.InfoLevel))
+
+	require.NoError(t, os.WriteFile(cfgfp, []byte("STDZAP_LEVEL=debug"), 0o600))
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+	assert.True(t, deps.Logs.Core().Enabled(zap.DebugLevel))
+}
@@ -3,30 +3,85 @@ package stdcrpc
 
 import (
 	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdent/stdenttypeid"
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // ArgRead makes it more ergonomic to read from protobuf messages input that might turn out to be invalid. It
-// can perform the error handling for this in one go and return well formatted Connect error for it.
-type ArgRead struct{ errs []error }
+// can perform the error handling for this in one go and return well formatted Connect error for it. Every failed
+// read is reported as a field violation, the field path is set with [ArgRead.Field] before the read:
+//
+//	id, ar := ar.Field("id").UUID(req.Msg.GetId())
+//	at, ar := ar.Field("starts_at").Time(req.Msg.GetStartsAt())
+//	if err := ar.Error(); err != nil {
+//		return nil, err
+//	}
+type ArgRead struct {
+	field      string
+	errs       []error
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+// Field sets the path of the field that the next read reports violations for, e.g. "user.email" or "ids[2]".
+func (abi ArgRead) Field(path string) (abo ArgRead) {
+	abi.field = path
+
+	return abi
+}
+
+// Invalid reports a violation for the field, for checks that are specific to the handler.
+func (abi ArgRead) Invalid(description string) (abo ArgRead) {
+	return abi.check(errors.New(description))
+}
+
+// check records the error as a violation of the current field, if it is not nil. The field is reset either way.
+func (abi ArgRead) check(err error) ArgRead {
+	field := abi.field
+	abi.field = ""
+
+	if err == nil {
+		return abi
+	}
+
+	// clip so reads that branch from the same ArgRead never share their backing arrays.
+	abi.violations = append(slices.Clip(abi.violations), &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+	})
+
+	if field != "" {
+		err = fmt.Errorf("%s: %w", field, err)
+	}
+
+	abi.errs = append(slices.Clip(abi.errs), err)
+
+	return abi
+}
 
 // UUID parses a uuid string.
 func (abi ArgRead) UUID(s string) (uid uuid.UUID, abo ArgRead) {
 	uid, err := uuid.Parse(s)
 	if err != nil {
-		abi.errs = append(abi.errs, err)
 		uid = uuid.Nil
 	}
 
-	return uid, abi
+	return uid, abi.check(err)
 }
 
 // UUIDp parses a string pointer into a pointer uuid.
 func (abi ArgRead) UUIDp(s *string) (uidp *uuid.UUID, abo ArgRead) {
 	if s == nil {
-		return nil, abi
+		return nil, abi.check(nil)
 	}
 
 	uid, abo := abi.UUID(*s)
@@ -37,12 +92,135 @@ func (abi ArgRead) UUIDp(s *string) (uidp *uuid.UUID, abo ArgRead) {
 	return &uid, abo
 }
 
-// Error returns the joined error as an InvalidArgument connect error or nil if there were no errors.
+// TypeID parses the printable form of a typeid and checks that it has the given prefix.
+func (abi ArgRead) TypeID(prefix, s string) (id stdenttypeid.ID, abo ArgRead) {
+	id, err := stdenttypeid.Parse(s)
+	if err == nil && id.Prefix() != prefix {
+		err = fmt.Errorf("typeid must have prefix %q, got %q", prefix, id.Prefix())
+	}
+
+	if err != nil {
+		return "", abi.check(err)
+	}
+
+	return id, abi.check(nil)
+}
+
+// Time parses a RFC 3339 timestamp.
+func (abi ArgRead) Time(s string) (t time.Time, abo ArgRead) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, abi.check(fmt.Errorf("must be a RFC 3339 timestamp: %w", err))
+	}
+
+	return t, abi.check(nil)
+}
+
+// Duration parses a duration such as "1h30m", see [time.ParseDuration].
+func (abi ArgRead) Duration(s string) (d time.Duration, abo ArgRead) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, abi.check(fmt.Errorf("must be a duration: %w", err))
+	}
+
+	return d, abi.check(nil)
+}
+
+// Int checks that the integer is within the inclusive bounds.
+func (abi ArgRead) Int(v, minV, maxV int64) (i int64, abo ArgRead) {
+	if v < minV || v > maxV {
+		return 0, abi.check(fmt.Errorf("must be between %d and %d, got %d", minV, maxV, v))
+	}
+
+	return v, abi.check(nil)
+}
+
+// IntString parses a base 10 integer and checks that it is within the inclusive bounds.
+func (abi ArgRead) IntString(s string, minV, maxV int64) (i int64, abo ArgRead) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, abi.check(fmt.Errorf("must be an integer: %w", err))
+	}
+
+	return abi.Int(v, minV, maxV)
+}
+
+// Email parses an e-mail address without a display name, e.g. "foo@example.com".
+func (abi ArgRead) Email(s string) (email string, abo ArgRead) {
+	addr, err := mail.ParseAddress(s)
+	if err == nil && addr.Address != s {
+		err = errors.New("must not have a display name")
+	}
+
+	if err != nil {
+		return "", abi.check(fmt.Errorf("must be an e-mail address: %w", err))
+	}
+
+	return addr.Address, abi.check(nil)
+}
+
+// URL parses an absolute URL. If schemes are provided, the URL's scheme must be one of them.
+func (abi ArgRead) URL(s string, schemes ...string) (u *url.URL, abo ArgRead) {
+	u, err := url.Parse(s)
+
+	switch {
+	case err != nil:
+		err = fmt.Errorf("must be a URL: %w", err)
+	case !u.IsAbs() || u.Host == "":
+		err = errors.New("must be an absolute URL")
+	case len(schemes) > 0 && !slices.Contains(schemes, u.Scheme):
+		err = fmt.Errorf("must have one of the schemes %q", schemes)
+	}
+
+	if err != nil {
+		return nil, abi.check(err)
+	}
+
+	return u, abi.check(nil)
+}
+
+// ArgEnum checks that the enum value is defined and not the zero (unspecified) value.
+func ArgEnum[E protoreflect.Enum](abi ArgRead, v E) (e E, abo ArgRead) {
+	var zero E
+
+	if v.Number() == 0 || v.Descriptor().Values().ByNumber(v.Number()) == nil {
+		return zero, abi.check(fmt.Errorf("must be a defined %s other than the zero value, got %d",
+			v.Descriptor().Name(), v.Number()))
+	}
+
+	return v, abi.check(nil)
+}
+
+// ArgEach reads every element of a repeated field. Violations are reported for the elements with their index
+// appended to the field path, e.g. "ids[2]".
+//
+//	ids, ar := stdcrpc.ArgEach(ar.Field("ids"), req.Msg.GetIds(), stdcrpc.ArgRead.UUID)
+func ArgEach[S, T any](abi ArgRead, vals []S, read func(ArgRead, S) (T, ArgRead)) (ts []T, abo ArgRead) {
+	field := abi.field
+	ts = make([]T, 0, len(vals))
+
+	for i, v := range vals {
+		var t T
+
+		t, abi = read(abi.Field(field+"["+strconv.Itoa(i)+"]"), v)
+		ts = append(ts, t)
+	}
+
+	return ts, abi.check(nil)
+}
+
+// Error returns the joined error as an InvalidArgument connect error or nil if there were no errors. The error
+// carries the violations as a google.rpc.BadRequest detail.
 func (abi ArgRead) Error() error {
 	joined := errors.Join(abi.errs...)
 	if joined == nil {
 		return nil
 	}
 
-	return connect.NewError(connect.CodeInvalidArgument, joined)
+	cerr := connect.NewError(connect.CodeInvalidArgument, joined)
+	if detail, err := connect.NewErrorDetail(&errdetails.BadRequest{FieldViolations: abi.violations}); err == nil {
+		cerr.AddDetail(detail)
+	}
+
+	return cerr
 }
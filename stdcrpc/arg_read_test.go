@@ -2,11 +2,15 @@ package stdcrpc_test
 
 import (
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/advdv/stdgo/stdcrpc"
 	"github.com/advdv/stdgo/stdlo"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 func TestArgReadUUID(t *testing.T) {
@@ -57,3 +61,66 @@ func TestArgNoErr(t *testing.T) {
 	require.Equal(t, "a03d1ab9-f506-4153-adea-bec1ef3dd8e7", uid1.String())
 	require.Equal(t, "a03d1ab9-f506-4153-adea-bec1ef3dd8e8", uid2.String())
 }
+
+func TestArgReadFieldViolations(t *testing.T) {
+	var ar stdcrpc.ArgRead
+
+	id, ar := ar.Field("id").TypeID("upld", "upld_01h455vb4pex5vvjndtv4bxrp4")
+	_, ar = ar.Field("other_id").TypeID("upld", "org_01h455vb4pex5vvjndtv4bxrp4")
+	at, ar := ar.Field("starts_at").Time("2025-01-02T03:04:05Z")
+	_, ar = ar.Field("ends_at").Time("2025-01-02")
+	dur, ar := ar.Field("timeout").Duration("1m30s")
+	_, ar = ar.Field("page_size").Int(1001, 1, 1000)
+	size, ar := ar.Field("max_size").IntString("10", 1, 100)
+	email, ar := ar.Field("email").Email("foo@example.com")
+	_, ar = ar.Field("reply_to").Email("Foo <foo@example.com>")
+	_, ar = ar.Field("callback").URL("ftp://example.com/x", "https")
+	_, ar = ar.Field("home").URL("/relative")
+	kind, ar := stdcrpc.ArgEnum(ar.Field("kind"), descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	_, ar = stdcrpc.ArgEnum(ar.Field("other_kind"), descriptorpb.FieldDescriptorProto_Type(99))
+	uids, ar := stdcrpc.ArgEach(ar.Field("ids"), []string{"a03d1ab9-f506-4153-adea-bec1ef3dd8e7", "x"},
+		stdcrpc.ArgRead.UUID)
+	ar = ar.Field("name").Invalid("must be unique")
+
+	require.Equal(t, "upld_01h455vb4pex5vvjndtv4bxrp4", id.String())
+	require.Equal(t, 2025, at.Year())
+	require.Equal(t, 90*time.Second, dur)
+	require.EqualValues(t, 10, size)
+	require.Equal(t, "foo@example.com", email)
+	require.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_STRING, kind)
+	require.Len(t, uids, 2)
+	require.Equal(t, "a03d1ab9-f506-4153-adea-bec1ef3dd8e7", uids[0].String())
+
+	err := ar.Error()
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorContains(t, err, `other_id: typeid must have prefix "upld", got "org"`)
+
+	var cerr *connect.Error
+	require.ErrorAs(t, err, &cerr)
+	require.Len(t, cerr.Details(), 1)
+
+	detail, err := cerr.Details()[0].Value()
+	require.NoError(t, err)
+
+	var fields []string
+	for _, v := range detail.(*errdetails.BadRequest).GetFieldViolations() {
+		require.NotEmpty(t, v.GetDescription())
+		fields = append(fields, v.GetField())
+	}
+
+	require.Equal(t, []string{
+		"other_id", "ends_at", "page_size", "reply_to", "callback", "home", "other_kind", "ids[1]", "name",
+	}, fields)
+}
+
+func TestArgReadBranching(t *testing.T) {
+	var ar stdcrpc.ArgRead
+
+	_, ar = ar.Field("a").UUID("x")
+	_, ar1 := ar.Field("b").UUID("x")
+	_, ar2 := ar.Field("c").UUID("x")
+
+	require.ErrorContains(t, ar1.Error(), "b: invalid UUID")
+	require.NotContains(t, ar1.Error().Error(), "c: ")
+	require.NotContains(t, ar2.Error().Error(), "b: ")
+}
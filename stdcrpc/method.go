@@ -0,0 +1,32 @@
+package stdcrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// MethodDescriptor returns the descriptor of the procedure in the spec. It is taken from the spec's schema when the
+// handler or client provides one, and looked up in the global registry by the procedure name otherwise.
+func MethodDescriptor(spec connect.Spec) (protoreflect.MethodDescriptor, error) {
+	if method, ok := spec.Schema.(protoreflect.MethodDescriptor); ok {
+		return method, nil
+	}
+
+	fullName := strings.Replace(strings.TrimPrefix(spec.Procedure, "/"), "/", ".", 1)
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(fullName))
+	if err != nil {
+		return nil, fmt.Errorf("find descriptor for %q: %w", fullName, err)
+	}
+
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a method descriptor", fullName)
+	}
+
+	return method, nil
+}
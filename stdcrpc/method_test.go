@@ -0,0 +1,36 @@
+package stdcrpc_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestMethodDescriptor(t *testing.T) {
+	t.Run("from registry", func(t *testing.T) {
+		method, err := stdcrpc.MethodDescriptor(connect.Spec{Procedure: grpc_health_v1.Health_Check_FullMethodName})
+		require.NoError(t, err)
+		require.Equal(t, "grpc.health.v1.Health.Check", string(method.FullName()))
+	})
+
+	t.Run("from schema", func(t *testing.T) {
+		want := grpc_health_v1.File_grpc_health_v1_health_proto.Services().Get(0).Methods().ByName("Watch")
+
+		method, err := stdcrpc.MethodDescriptor(connect.Spec{Procedure: "/unknown.v1.Service/Method", Schema: want})
+		require.NoError(t, err)
+		require.Equal(t, want, method)
+	})
+
+	t.Run("unknown procedure", func(t *testing.T) {
+		_, err := stdcrpc.MethodDescriptor(connect.Spec{Procedure: "/unknown.v1.Service/Method"})
+		require.ErrorContains(t, err, `find descriptor for "unknown.v1.Service.Method"`)
+	})
+
+	t.Run("not a method", func(t *testing.T) {
+		_, err := stdcrpc.MethodDescriptor(connect.Spec{Procedure: "/grpc.health.v1/Health"})
+		require.ErrorContains(t, err, `"grpc.health.v1.Health" is not a method descriptor`)
+	})
+}
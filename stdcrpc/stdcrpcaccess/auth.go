@@ -10,6 +10,7 @@ import (
 
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
 	"github.com/advdv/stdgo/stdctx"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
@@ -107,6 +108,27 @@ func (ac *AccessControl[T]) SignAccessToken(
 	return signed, nil
 }
 
+// TokenSource returns a token source for [stdcrpcclient] clients that signs an access token for every call, with
+// the claims of the builder that is returned by the claims function. Wrap it with [stdcrpcclient.ReuseToken] to
+// re-use tokens that have an expiration time.
+func (ac *AccessControl[T]) TokenSource(
+	signingKeyID string, claims func(ctx context.Context) (*jwt.Builder, error),
+) stdcrpcclient.TokenSource {
+	return func(ctx context.Context) (string, error) {
+		bldr, err := claims(ctx)
+		if err != nil {
+			return "", fmt.Errorf("claims: %w", err)
+		}
+
+		signed, err := ac.SignAccessToken(bldr, signingKeyID)
+		if err != nil {
+			return "", err
+		}
+
+		return string(signed), nil
+	}
+}
+
 // Close cancels the lifecycle context.
 func (ac *AccessControl[T]) Close(context.Context) error { ac.stop(); return nil }
 
@@ -218,6 +218,36 @@ func TestSigning(t *testing.T) {
 		require.Equal(t, http.StatusOK, rec.Result().StatusCode)
 	})
 
+	t.Run("token-source", func(t *testing.T) {
+		keys, err := jwk.Parse(fixedJwks2Data)
+		require.NoError(t, err)
+
+		ctx := stdctx.WithLogger(t.Context(), zap.NewNop())
+
+		ac := stdcrpcaccess.New(
+			authLogic{},
+			stdcrpcaccess.NewTestAuthBackend(),
+			keys,
+			"access-test",
+			"auth-backend",
+			"self-sign",
+			nil)
+
+		src := ac.TokenSource("key2", func(context.Context) (*jwt.Builder, error) {
+			return jwt.NewBuilder().Claim("permissions", []string{"/a/b"}), nil
+		})
+
+		token, err := src(ctx)
+		require.NoError(t, err)
+
+		rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/a/b", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		ac.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	})
+
 	t.Run("invalid", func(t *testing.T) {
 		keys, err := jwk.Parse(fixedJwks2Data)
 		require.NoError(t, err)
@@ -0,0 +1,217 @@
+// Package stdcrpcclient builds Connect RPC clients for calling other services. The clients attach access tokens,
+// retry idempotent procedures, optionally hedge side-effect free procedures and propagate the request id.
+package stdcrpcclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// Option configures the clients that are built with [New].
+type Option func(*options)
+
+type options struct {
+	httpClient connect.HTTPClient
+	tokens     TokenSource
+	timeout    time.Duration
+	retry      struct {
+		maxRetries int
+		minBackoff time.Duration
+		maxBackoff time.Duration
+	}
+	hedge struct {
+		delay     time.Duration
+		maxHedges int
+	}
+	clientOpts []connect.ClientOption
+}
+
+// WithHTTPClient sets the HTTP client that performs the calls, [http.DefaultClient] is used otherwise.
+func WithHTTPClient(hc connect.HTTPClient) Option {
+	return func(o *options) { o.httpClient = hc }
+}
+
+// WithTokenSource attaches the token from the source as a bearer token to every call, unless the caller already
+// set the Authorization header.
+func WithTokenSource(src TokenSource) Option {
+	return func(o *options) { o.tokens = src }
+}
+
+// WithTimeout sets the deadline of calls whose context doesn't have one yet. Retries and hedges all happen within
+// this deadline.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithRetries retries unary calls of procedures that are marked NO_SIDE_EFFECTS or IDEMPOTENT when they fail with
+// [connect.CodeUnavailable] or [connect.CodeAborted], with a capped exponential backoff and full jitter.
+func WithRetries(maxRetries int, minBackoff, maxBackoff time.Duration) Option {
+	return func(o *options) {
+		o.retry.maxRetries, o.retry.minBackoff, o.retry.maxBackoff = maxRetries, minBackoff, maxBackoff
+	}
+}
+
+// WithHedging sends up to maxHedges extra requests for unary calls of procedures that are marked NO_SIDE_EFFECTS,
+// when the previous request didn't respond within the delay. The first response is used and the other requests are
+// canceled. It trades load on the target for lower tail latency.
+func WithHedging(delay time.Duration, maxHedges int) Option {
+	return func(o *options) { o.hedge.delay, o.hedge.maxHedges = delay, maxHedges }
+}
+
+// WithClientOptions adds Connect client options, e.g. to pick the protocol or add interceptors. Interceptors that
+// are added here run within the retries, so once per attempt.
+func WithClientOptions(opts ...connect.ClientOption) Option {
+	return func(o *options) { o.clientOpts = append(o.clientOpts, opts...) }
+}
+
+// New builds a client for the service at the base URL with a generated client constructor, e.g.:
+//
+//	cln := stdcrpcclient.New(foov1connect.NewFooServiceClient, "https://foo.internal", opts...)
+func New[C any](
+	newClient func(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) C,
+	baseURL string,
+	opts ...Option,
+) C {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if o.hedge.delay > 0 && o.hedge.maxHedges > 0 {
+		httpClient = &hedgingClient{next: httpClient, delay: o.hedge.delay, maxHedges: o.hedge.maxHedges}
+	}
+
+	return newClient(httpClient, baseURL, append([]connect.ClientOption{
+		connect.WithInterceptors(&interceptor{opts: o}),
+	}, o.clientOpts...)...)
+}
+
+// interceptor implements [connect.Interceptor] for the client side.
+type interceptor struct{ opts options }
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := i.withTimeout(ctx)
+		defer cancel()
+
+		if err := i.setHeaders(ctx, req.Header()); err != nil {
+			return nil, err
+		}
+
+		level := req.Spec().IdempotencyLevel
+		if level == connect.IdempotencyNoSideEffects && i.opts.hedge.maxHedges > 0 {
+			ctx = withHedging(ctx)
+		}
+
+		if i.opts.retry.maxRetries < 1 || level == connect.IdempotencyUnknown {
+			return next(ctx, req)
+		}
+
+		return i.retry(ctx, req, next)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		if err := i.setHeaders(ctx, conn.RequestHeader()); err != nil {
+			return &failedConn{StreamingClientConn: conn, err: err}
+		}
+
+		return conn
+	}
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// withTimeout applies the configured timeout if the context has no deadline yet.
+func (i *interceptor) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || i.opts.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, i.opts.timeout)
+}
+
+// setHeaders adds the access token and the id of the request that is being handled, if any.
+func (i *interceptor) setHeaders(ctx context.Context, hdr http.Header) error {
+	if reqID := chimiddleware.GetReqID(ctx); reqID != "" && hdr.Get(chimiddleware.RequestIDHeader) == "" {
+		hdr.Set(chimiddleware.RequestIDHeader, reqID)
+	}
+
+	if i.opts.tokens == nil || hdr.Get("Authorization") != "" {
+		return nil
+	}
+
+	tok, err := i.opts.tokens(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	hdr.Set("Authorization", "Bearer "+tok)
+
+	return nil
+}
+
+// retry calls next until it succeeds, fails with an error that is not retryable or the retries run out.
+func (i *interceptor) retry(
+	ctx context.Context, req connect.AnyRequest, next connect.UnaryFunc,
+) (connect.AnyResponse, error) {
+	logs := stdctx.MaybeLog(ctx)
+	policy := retrypolicy.Builder[connect.AnyResponse]().
+		HandleIf(func(_ connect.AnyResponse, err error) bool {
+			switch connect.CodeOf(err) { //nolint:exhaustive
+			case connect.CodeUnavailable, connect.CodeAborted:
+				return true
+			default:
+				return false
+			}
+		}).
+		WithBackoff(i.opts.retry.minBackoff, i.opts.retry.maxBackoff).
+		WithJitterFactor(1.0).
+		WithMaxRetries(i.opts.retry.maxRetries).
+		ReturnLastFailure().
+		Build()
+
+	return failsafe.
+		NewExecutor(policy).
+		WithContext(ctx).
+		GetWithExecution(func(exec failsafe.Execution[connect.AnyResponse]) (connect.AnyResponse, error) {
+			if !exec.IsFirstAttempt() {
+				logs.Info("retrying call",
+					zap.String("procedure", req.Spec().Procedure),
+					zap.Int("attempt", exec.Attempts()),
+					zap.Error(exec.LastError()))
+			}
+
+			return next(exec.Context(), req) //nolint:contextcheck
+		})
+}
+
+// failedConn fails a stream that could not be set up, the error surfaces on the first send or receive.
+type failedConn struct {
+	connect.StreamingClientConn
+	err error
+}
+
+func (c *failedConn) Send(any) error    { return c.err }
+func (c *failedConn) Receive(any) error { return c.err }
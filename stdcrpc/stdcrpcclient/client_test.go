@@ -0,0 +1,228 @@
+package stdcrpcclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
+	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcclient/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient/internal/v1/internalv1connect"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testService fails the first n attempts for "fail:<n>", responds slowly to the first attempt for "slow" and
+// blocks until the call is canceled for "block".
+type testService struct{ attempts atomic.Int32 }
+
+func (s *testService) respond(
+	ctx context.Context, req *connect.Request[internalv1.CallRequest],
+) (*connect.Response[internalv1.CallResponse], error) {
+	attempt := s.attempts.Add(1)
+
+	text := req.Msg.GetText()
+	switch {
+	case strings.HasPrefix(text, "fail:"):
+		if n, _ := strconv.Atoi(strings.TrimPrefix(text, "fail:")); int(attempt) <= n {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+		}
+	case text == "slow" && attempt == 1:
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	case text == "block":
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	return connect.NewResponse(internalv1.CallResponse_builder{
+		Text:          proto.String(text),
+		Authorization: proto.String(req.Header().Get("Authorization")),
+		RequestId:     proto.String(req.Header().Get(chimiddleware.RequestIDHeader)),
+		Attempt:       proto.Int32(attempt),
+	}.Build()), nil
+}
+
+func (s *testService) Get(
+	ctx context.Context, req *connect.Request[internalv1.CallRequest],
+) (*connect.Response[internalv1.CallResponse], error) {
+	return s.respond(ctx, req)
+}
+
+func (s *testService) Put(
+	ctx context.Context, req *connect.Request[internalv1.CallRequest],
+) (*connect.Response[internalv1.CallResponse], error) {
+	return s.respond(ctx, req)
+}
+
+func (s *testService) Create(
+	ctx context.Context, req *connect.Request[internalv1.CallRequest],
+) (*connect.Response[internalv1.CallResponse], error) {
+	return s.respond(ctx, req)
+}
+
+func (s *testService) Watch(
+	ctx context.Context, req *connect.Request[internalv1.CallRequest],
+	stream *connect.ServerStream[internalv1.CallResponse],
+) error {
+	resp, err := s.respond(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(resp.Msg)
+}
+
+func setup(t *testing.T, opts ...stdcrpcclient.Option) (*testService, internalv1connect.TestServiceClient) {
+	t.Helper()
+
+	svc := &testService{}
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(svc))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	opts = append([]stdcrpcclient.Option{stdcrpcclient.WithHTTPClient(srv.Client())}, opts...)
+
+	return svc, stdcrpcclient.New(internalv1connect.NewTestServiceClient, srv.URL, opts...)
+}
+
+func call(text string) *connect.Request[internalv1.CallRequest] {
+	return connect.NewRequest(internalv1.CallRequest_builder{Text: proto.String(text)}.Build())
+}
+
+func TestHeaders(t *testing.T) {
+	t.Parallel()
+
+	_, cln := setup(t, stdcrpcclient.WithTokenSource(stdcrpcclient.StaticToken("tok1")))
+	ctx := context.WithValue(t.Context(), chimiddleware.RequestIDKey, "req-1")
+
+	resp, err := cln.Create(ctx, call("a"))
+	require.NoError(t, err)
+	require.Equal(t, "Bearer tok1", resp.Msg.GetAuthorization())
+	require.Equal(t, "req-1", resp.Msg.GetRequestId())
+
+	// explicit authorization is left alone.
+	req := call("a")
+	req.Header().Set("Authorization", "Bearer other")
+	resp, err = cln.Create(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer other", resp.Msg.GetAuthorization())
+
+	stream, err := cln.Watch(ctx, call("a"))
+	require.NoError(t, err)
+	require.True(t, stream.Receive())
+	require.Equal(t, "Bearer tok1", stream.Msg().GetAuthorization())
+	require.Equal(t, "req-1", stream.Msg().GetRequestId())
+	require.NoError(t, stream.Close())
+}
+
+func TestTokenSourceError(t *testing.T) {
+	t.Parallel()
+
+	svc, cln := setup(t, stdcrpcclient.WithTokenSource(func(context.Context) (string, error) {
+		return "", errors.New("no token")
+	}))
+
+	_, err := cln.Create(t.Context(), call("a"))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	require.Zero(t, svc.attempts.Load())
+
+	_, err = cln.Watch(t.Context(), call("a"))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	require.Zero(t, svc.attempts.Load())
+}
+
+func TestRetries(t *testing.T) {
+	t.Parallel()
+
+	retries := stdcrpcclient.WithRetries(2, time.Millisecond, 5*time.Millisecond)
+
+	t.Run("idempotent", func(t *testing.T) {
+		t.Parallel()
+		svc, cln := setup(t, retries)
+
+		resp, err := cln.Put(t.Context(), call("fail:2"))
+		require.NoError(t, err)
+		require.EqualValues(t, 3, resp.Msg.GetAttempt())
+		require.EqualValues(t, 3, svc.attempts.Load())
+	})
+
+	t.Run("no side effects", func(t *testing.T) {
+		t.Parallel()
+		svc, cln := setup(t, retries)
+
+		_, err := cln.Get(t.Context(), call("fail:3"))
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+		require.EqualValues(t, 3, svc.attempts.Load())
+	})
+
+	t.Run("side effects", func(t *testing.T) {
+		t.Parallel()
+		svc, cln := setup(t, retries)
+
+		_, err := cln.Create(t.Context(), call("fail:1"))
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+		require.EqualValues(t, 1, svc.attempts.Load())
+	})
+}
+
+func TestHedging(t *testing.T) {
+	t.Parallel()
+
+	hedging := stdcrpcclient.WithHedging(20*time.Millisecond, 1)
+
+	t.Run("no side effects", func(t *testing.T) {
+		t.Parallel()
+		svc, cln := setup(t, hedging)
+
+		start := time.Now()
+		resp, err := cln.Get(t.Context(), call("slow"))
+		require.NoError(t, err)
+		require.EqualValues(t, 2, resp.Msg.GetAttempt())
+		require.Less(t, time.Since(start), time.Second)
+		require.EqualValues(t, 2, svc.attempts.Load())
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		t.Parallel()
+		svc, cln := setup(t, hedging)
+
+		resp, err := cln.Put(t.Context(), call("slow"))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, resp.Msg.GetAttempt())
+		require.EqualValues(t, 1, svc.attempts.Load())
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	timeout := stdcrpcclient.WithTimeout(50 * time.Millisecond)
+
+	_, cln := setup(t, timeout)
+	_, err := cln.Create(t.Context(), call("block"))
+	require.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+
+	// a deadline of the caller takes precedence.
+	_, cln = setup(t, timeout)
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second)
+	defer cancel()
+
+	resp, err := cln.Create(ctx, call("slow"))
+	require.NoError(t, err)
+	require.Equal(t, "slow", resp.Msg.GetText())
+}
@@ -0,0 +1,78 @@
+package stdcrpcclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+)
+
+type ctxKey string
+
+// withHedging marks the context of a call whose HTTP request may be hedged.
+func withHedging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey("hedging"), true)
+}
+
+func hedging(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxKey("hedging")).(bool)
+	return v
+}
+
+// hedgingClient hedges the HTTP requests of calls that are marked by the interceptor. It hedges on the HTTP level,
+// since the Connect request of a call can't be sent concurrently.
+type hedgingClient struct {
+	next      connect.HTTPClient
+	delay     time.Duration
+	maxHedges int
+}
+
+func (c *hedgingClient) Do(req *http.Request) (*http.Response, error) {
+	if !hedging(req.Context()) {
+		return c.next.Do(req)
+	}
+
+	// Connect buffers the body of unary requests, but re-uses a single reader for it.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+
+		if err := req.Body.Close(); err != nil {
+			return nil, fmt.Errorf("close request body: %w", err)
+		}
+	}
+
+	// the first response wins, also when it is an error, the retries deal with those.
+	policy := hedgepolicy.BuilderWithDelay[*http.Response](c.delay).
+		WithMaxHedges(c.maxHedges).
+		CancelIf(func(*http.Response, error) bool { return true }).
+		Build()
+
+	return failsafe.
+		NewExecutor(policy).
+		WithContext(req.Context()).
+		GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+			attempt := req.Clone(exec.Context()) //nolint:contextcheck
+			attempt.Body = io.NopCloser(bytes.NewReader(body))
+			attempt.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+
+			resp, err := c.next.Do(attempt)
+			if err == nil && exec.IsCanceled() {
+				// another request won, nobody reads this response.
+				resp.Body.Close()
+
+				return nil, context.Canceled
+			}
+
+			return resp, err
+		})
+}
@@ -0,0 +1,338 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: stdcrpc/stdcrpcclient/internal/v1/client.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CallRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Text        *string                `protobuf:"bytes,1,opt,name=text"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CallRequest) Reset() {
+	*x = CallRequest{}
+	mi := &file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallRequest) ProtoMessage() {}
+
+func (x *CallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CallRequest) GetText() string {
+	if x != nil {
+		if x.xxx_hidden_Text != nil {
+			return *x.xxx_hidden_Text
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CallRequest) SetText(v string) {
+	x.xxx_hidden_Text = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CallRequest) HasText() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CallRequest) ClearText() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Text = nil
+}
+
+type CallRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Text *string
+}
+
+func (b0 CallRequest_builder) Build() *CallRequest {
+	m0 := &CallRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Text != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Text = b.Text
+	}
+	return m0
+}
+
+type CallResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Text          *string                `protobuf:"bytes,1,opt,name=text"`
+	xxx_hidden_Authorization *string                `protobuf:"bytes,2,opt,name=authorization"`
+	xxx_hidden_RequestId     *string                `protobuf:"bytes,3,opt,name=request_id,json=requestId"`
+	xxx_hidden_Attempt       int32                  `protobuf:"varint,4,opt,name=attempt"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CallResponse) Reset() {
+	*x = CallResponse{}
+	mi := &file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallResponse) ProtoMessage() {}
+
+func (x *CallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CallResponse) GetText() string {
+	if x != nil {
+		if x.xxx_hidden_Text != nil {
+			return *x.xxx_hidden_Text
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CallResponse) GetAuthorization() string {
+	if x != nil {
+		if x.xxx_hidden_Authorization != nil {
+			return *x.xxx_hidden_Authorization
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CallResponse) GetRequestId() string {
+	if x != nil {
+		if x.xxx_hidden_RequestId != nil {
+			return *x.xxx_hidden_RequestId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CallResponse) GetAttempt() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempt
+	}
+	return 0
+}
+
+func (x *CallResponse) SetText(v string) {
+	x.xxx_hidden_Text = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *CallResponse) SetAuthorization(v string) {
+	x.xxx_hidden_Authorization = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *CallResponse) SetRequestId(v string) {
+	x.xxx_hidden_RequestId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *CallResponse) SetAttempt(v int32) {
+	x.xxx_hidden_Attempt = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *CallResponse) HasText() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CallResponse) HasAuthorization() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CallResponse) HasRequestId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CallResponse) HasAttempt() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CallResponse) ClearText() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Text = nil
+}
+
+func (x *CallResponse) ClearAuthorization() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Authorization = nil
+}
+
+func (x *CallResponse) ClearRequestId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_RequestId = nil
+}
+
+func (x *CallResponse) ClearAttempt() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Attempt = 0
+}
+
+type CallResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Text          *string
+	Authorization *string
+	RequestId     *string
+	Attempt       *int32
+}
+
+func (b0 CallResponse_builder) Build() *CallResponse {
+	m0 := &CallResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Text != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Text = b.Text
+	}
+	if b.Authorization != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Authorization = b.Authorization
+	}
+	if b.RequestId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_RequestId = b.RequestId
+	}
+	if b.Attempt != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Attempt = *b.Attempt
+	}
+	return m0
+}
+
+var File_stdcrpc_stdcrpcclient_internal_v1_client_proto protoreflect.FileDescriptor
+
+const file_stdcrpc_stdcrpcclient_internal_v1_client_proto_rawDesc = "" +
+	"\n" +
+	".stdcrpc/stdcrpcclient/internal/v1/client.proto\x12!stdcrpc.stdcrpcclient.internal.v1\"!\n" +
+	"\vCallRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\x81\x01\n" +
+	"\fCallResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12$\n" +
+	"\rauthorization\x18\x02 \x01(\tR\rauthorization\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt2\xc2\x03\n" +
+	"\vTestService\x12k\n" +
+	"\x03Get\x12..stdcrpc.stdcrpcclient.internal.v1.CallRequest\x1a/.stdcrpc.stdcrpcclient.internal.v1.CallResponse\"\x03\x90\x02\x01\x12k\n" +
+	"\x03Put\x12..stdcrpc.stdcrpcclient.internal.v1.CallRequest\x1a/.stdcrpc.stdcrpcclient.internal.v1.CallResponse\"\x03\x90\x02\x02\x12k\n" +
+	"\x06Create\x12..stdcrpc.stdcrpcclient.internal.v1.CallRequest\x1a/.stdcrpc.stdcrpcclient.internal.v1.CallResponse\"\x00\x12l\n" +
+	"\x05Watch\x12..stdcrpc.stdcrpcclient.internal.v1.CallRequest\x1a/.stdcrpc.stdcrpcclient.internal.v1.CallResponse\"\x000\x01B\xa0\x02\n" +
+	"%com.stdcrpc.stdcrpcclient.internal.v1B\vClientProtoP\x01ZCgithub.com/advdv/stdgo/stdcrpc/stdcrpcclient/internal/v1;internalv1\xa2\x02\x03SSI\xaa\x02!Stdcrpc.Stdcrpcclient.Internal.V1\xca\x02!Stdcrpc\\Stdcrpcclient\\Internal\\V1\xe2\x02-Stdcrpc\\Stdcrpcclient\\Internal\\V1\\GPBMetadata\xea\x02$Stdcrpc::Stdcrpcclient::Internal::V1b\beditionsp\xe8\a"
+
+var file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_stdcrpc_stdcrpcclient_internal_v1_client_proto_goTypes = []any{
+	(*CallRequest)(nil),  // 0: stdcrpc.stdcrpcclient.internal.v1.CallRequest
+	(*CallResponse)(nil), // 1: stdcrpc.stdcrpcclient.internal.v1.CallResponse
+}
+var file_stdcrpc_stdcrpcclient_internal_v1_client_proto_depIdxs = []int32{
+	0, // 0: stdcrpc.stdcrpcclient.internal.v1.TestService.Get:input_type -> stdcrpc.stdcrpcclient.internal.v1.CallRequest
+	0, // 1: stdcrpc.stdcrpcclient.internal.v1.TestService.Put:input_type -> stdcrpc.stdcrpcclient.internal.v1.CallRequest
+	0, // 2: stdcrpc.stdcrpcclient.internal.v1.TestService.Create:input_type -> stdcrpc.stdcrpcclient.internal.v1.CallRequest
+	0, // 3: stdcrpc.stdcrpcclient.internal.v1.TestService.Watch:input_type -> stdcrpc.stdcrpcclient.internal.v1.CallRequest
+	1, // 4: stdcrpc.stdcrpcclient.internal.v1.TestService.Get:output_type -> stdcrpc.stdcrpcclient.internal.v1.CallResponse
+	1, // 5: stdcrpc.stdcrpcclient.internal.v1.TestService.Put:output_type -> stdcrpc.stdcrpcclient.internal.v1.CallResponse
+	1, // 6: stdcrpc.stdcrpcclient.internal.v1.TestService.Create:output_type -> stdcrpc.stdcrpcclient.internal.v1.CallResponse
+	1, // 7: stdcrpc.stdcrpcclient.internal.v1.TestService.Watch:output_type -> stdcrpc.stdcrpcclient.internal.v1.CallResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_stdcrpc_stdcrpcclient_internal_v1_client_proto_init() }
+func file_stdcrpc_stdcrpcclient_internal_v1_client_proto_init() {
+	if File_stdcrpc_stdcrpcclient_internal_v1_client_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stdcrpc_stdcrpcclient_internal_v1_client_proto_rawDesc), len(file_stdcrpc_stdcrpcclient_internal_v1_client_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stdcrpc_stdcrpcclient_internal_v1_client_proto_goTypes,
+		DependencyIndexes: file_stdcrpc_stdcrpcclient_internal_v1_client_proto_depIdxs,
+		MessageInfos:      file_stdcrpc_stdcrpcclient_internal_v1_client_proto_msgTypes,
+	}.Build()
+	File_stdcrpc_stdcrpcclient_internal_v1_client_proto = out.File
+	file_stdcrpc_stdcrpcclient_internal_v1_client_proto_goTypes = nil
+	file_stdcrpc_stdcrpcclient_internal_v1_client_proto_depIdxs = nil
+}
@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: stdcrpc/stdcrpcclient/internal/v1/client.proto
+
+package internalv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/advdv/stdgo/stdcrpc/stdcrpcclient/internal/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TestServiceName is the fully-qualified name of the TestService service.
+	TestServiceName = "stdcrpc.stdcrpcclient.internal.v1.TestService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TestServiceGetProcedure is the fully-qualified name of the TestService's Get RPC.
+	TestServiceGetProcedure = "/stdcrpc.stdcrpcclient.internal.v1.TestService/Get"
+	// TestServicePutProcedure is the fully-qualified name of the TestService's Put RPC.
+	TestServicePutProcedure = "/stdcrpc.stdcrpcclient.internal.v1.TestService/Put"
+	// TestServiceCreateProcedure is the fully-qualified name of the TestService's Create RPC.
+	TestServiceCreateProcedure = "/stdcrpc.stdcrpcclient.internal.v1.TestService/Create"
+	// TestServiceWatchProcedure is the fully-qualified name of the TestService's Watch RPC.
+	TestServiceWatchProcedure = "/stdcrpc.stdcrpcclient.internal.v1.TestService/Watch"
+)
+
+// TestServiceClient is a client for the stdcrpc.stdcrpcclient.internal.v1.TestService service.
+type TestServiceClient interface {
+	Get(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Put(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Create(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Watch(context.Context, *connect.Request[v1.CallRequest]) (*connect.ServerStreamForClient[v1.CallResponse], error)
+}
+
+// NewTestServiceClient constructs a client for the stdcrpc.stdcrpcclient.internal.v1.TestService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTestServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	testServiceMethods := v1.File_stdcrpc_stdcrpcclient_internal_v1_client_proto.Services().ByName("TestService").Methods()
+	return &testServiceClient{
+		get: connect.NewClient[v1.CallRequest, v1.CallResponse](
+			httpClient,
+			baseURL+TestServiceGetProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Get")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		put: connect.NewClient[v1.CallRequest, v1.CallResponse](
+			httpClient,
+			baseURL+TestServicePutProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Put")),
+			connect.WithIdempotency(connect.IdempotencyIdempotent),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[v1.CallRequest, v1.CallResponse](
+			httpClient,
+			baseURL+TestServiceCreateProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		watch: connect.NewClient[v1.CallRequest, v1.CallResponse](
+			httpClient,
+			baseURL+TestServiceWatchProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Watch")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// testServiceClient implements TestServiceClient.
+type testServiceClient struct {
+	get    *connect.Client[v1.CallRequest, v1.CallResponse]
+	put    *connect.Client[v1.CallRequest, v1.CallResponse]
+	create *connect.Client[v1.CallRequest, v1.CallResponse]
+	watch  *connect.Client[v1.CallRequest, v1.CallResponse]
+}
+
+// Get calls stdcrpc.stdcrpcclient.internal.v1.TestService.Get.
+func (c *testServiceClient) Get(ctx context.Context, req *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Put calls stdcrpc.stdcrpcclient.internal.v1.TestService.Put.
+func (c *testServiceClient) Put(ctx context.Context, req *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return c.put.CallUnary(ctx, req)
+}
+
+// Create calls stdcrpc.stdcrpcclient.internal.v1.TestService.Create.
+func (c *testServiceClient) Create(ctx context.Context, req *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Watch calls stdcrpc.stdcrpcclient.internal.v1.TestService.Watch.
+func (c *testServiceClient) Watch(ctx context.Context, req *connect.Request[v1.CallRequest]) (*connect.ServerStreamForClient[v1.CallResponse], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
+// TestServiceHandler is an implementation of the stdcrpc.stdcrpcclient.internal.v1.TestService
+// service.
+type TestServiceHandler interface {
+	Get(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Put(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Create(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error)
+	Watch(context.Context, *connect.Request[v1.CallRequest], *connect.ServerStream[v1.CallResponse]) error
+}
+
+// NewTestServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTestServiceHandler(svc TestServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	testServiceMethods := v1.File_stdcrpc_stdcrpcclient_internal_v1_client_proto.Services().ByName("TestService").Methods()
+	testServiceGetHandler := connect.NewUnaryHandler(
+		TestServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(testServiceMethods.ByName("Get")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServicePutHandler := connect.NewUnaryHandler(
+		TestServicePutProcedure,
+		svc.Put,
+		connect.WithSchema(testServiceMethods.ByName("Put")),
+		connect.WithIdempotency(connect.IdempotencyIdempotent),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServiceCreateHandler := connect.NewUnaryHandler(
+		TestServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(testServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServiceWatchHandler := connect.NewServerStreamHandler(
+		TestServiceWatchProcedure,
+		svc.Watch,
+		connect.WithSchema(testServiceMethods.ByName("Watch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/stdcrpc.stdcrpcclient.internal.v1.TestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TestServiceGetProcedure:
+			testServiceGetHandler.ServeHTTP(w, r)
+		case TestServicePutProcedure:
+			testServicePutHandler.ServeHTTP(w, r)
+		case TestServiceCreateProcedure:
+			testServiceCreateHandler.ServeHTTP(w, r)
+		case TestServiceWatchProcedure:
+			testServiceWatchHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTestServiceHandler struct{}
+
+func (UnimplementedTestServiceHandler) Get(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcclient.internal.v1.TestService.Get is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) Put(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcclient.internal.v1.TestService.Put is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) Create(context.Context, *connect.Request[v1.CallRequest]) (*connect.Response[v1.CallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcclient.internal.v1.TestService.Create is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) Watch(context.Context, *connect.Request[v1.CallRequest], *connect.ServerStream[v1.CallResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcclient.internal.v1.TestService.Watch is not implemented"))
+}
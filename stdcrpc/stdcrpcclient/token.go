@@ -0,0 +1,43 @@
+package stdcrpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenSource returns the access token that is attached to calls as a bearer token.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken always returns the same token, e.g. an API key.
+func StaticToken(tok string) TokenSource {
+	return func(context.Context) (string, error) { return tok, nil }
+}
+
+// ReuseToken returns the token from the source until it is older than the ttl, so tokens that are expensive to
+// get or sign are not re-created for every call. The ttl must be shorter than the lifetime of the tokens.
+func ReuseToken(src TokenSource, ttl time.Duration) TokenSource {
+	var (
+		mu        sync.Mutex
+		tok       string
+		createdAt time.Time
+	)
+
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if tok != "" && time.Since(createdAt) < ttl {
+			return tok, nil
+		}
+
+		fresh, err := src(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		tok, createdAt = fresh, time.Now()
+
+		return tok, nil
+	}
+}
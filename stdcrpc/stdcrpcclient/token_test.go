@@ -0,0 +1,34 @@
+package stdcrpcclient_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcclient"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReuseToken(t *testing.T) {
+	t.Parallel()
+
+	var n int
+	src := stdcrpcclient.ReuseToken(func(context.Context) (string, error) {
+		n++
+		return "tok" + strconv.Itoa(n), nil
+	}, 50*time.Millisecond)
+
+	tok1, err := src(t.Context())
+	require.NoError(t, err)
+	tok2, err := src(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "tok1", tok1)
+	require.Equal(t, tok1, tok2)
+
+	time.Sleep(60 * time.Millisecond)
+
+	tok3, err := src(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "tok2", tok3)
+}
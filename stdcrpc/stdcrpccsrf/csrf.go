@@ -0,0 +1,341 @@
+// Package stdcrpccsrf protects cookie-authenticated ConnectRPC
+// endpoints against cross-site request forgery.
+//
+// Like stdcrpcwritefence it is composed of an HTTP middleware and a
+// server-side Connect interceptor that share a package-private ctx
+// value:
+//
+//   - [Middleware] or [OriginMiddleware] — an HTTP middleware that
+//     verifies the request and stamps the outcome on the request
+//     ctx. It never short-circuits the request itself: whether a
+//     failed verification matters depends on the procedure, which
+//     is only known to the interceptor.
+//
+//   - [Interceptor] — rejects any procedure whose idempotency level
+//     is not NO_SIDE_EFFECTS with [connect.CodePermissionDenied]
+//     when the middleware did not verify the request. Pure reads
+//     are exempt: Connect serves them over GET and a forged read
+//     can't change state.
+//
+// Two verification strategies are offered:
+//
+//   - Double-submit token ([Middleware]): a random token is stored
+//     in a signed, HttpOnly cookie and front-ends echo it in the
+//     [DefaultHeaderName] header. A cross-site attacker can make the
+//     browser send the cookie but can't read the token to set the
+//     header. Front-ends fetch the token from [TokenHandler].
+//
+//   - Fetch metadata ([OriginMiddleware]): the browser-controlled
+//     Sec-Fetch-Site header (falling back to Origin) must identify
+//     the request as same-origin, or come from a trusted origin.
+//     No token plumbing is needed, but it relies on browsers that
+//     send these headers.
+//
+// Requests without any cookie are always verified: without ambient
+// credentials there is nothing to forge, so API-key and bearer
+// token clients are unaffected.
+package stdcrpccsrf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gorilla/securecookie"
+)
+
+const (
+	// DefaultCookieName is the cookie name used when [WithCookieName]
+	// is not supplied.
+	DefaultCookieName = "_csrf"
+
+	// DefaultHeaderName is the request header front-ends echo the
+	// token in, when [WithHeaderName] is not supplied.
+	DefaultHeaderName = "X-Csrf-Token"
+
+	// DefaultTTL is how long an issued token remains valid when
+	// [WithTTL] is not supplied.
+	DefaultTTL = 12 * time.Hour
+
+	// MinHashKeyLen is the minimum hash key length enforced at
+	// construction time, the size of an HMAC-SHA-256 key.
+	MinHashKeyLen = 32
+
+	// tokenLen is the number of random bytes in a token.
+	tokenLen = 32
+)
+
+var (
+	// ErrMissingToken is reported when a request with cookies doesn't
+	// carry the token cookie or header.
+	ErrMissingToken = errors.New("csrf: missing token")
+	// ErrInvalidToken is reported when the token cookie can't be
+	// verified or doesn't match the header.
+	ErrInvalidToken = errors.New("csrf: invalid token")
+	// ErrCrossOrigin is reported when fetch metadata or the Origin
+	// header identifies the request as cross-origin.
+	ErrCrossOrigin = errors.New("csrf: cross-origin request")
+	// ErrNotVerified is reported when no middleware of this package
+	// ran for the request.
+	ErrNotVerified = errors.New("csrf: request was not verified")
+)
+
+// verificationKey is the unexported context key the middleware
+// stamps the verification outcome under.
+type verificationKey struct{}
+
+// verification holds the outcome so a nil error can be told apart
+// from a missing stamp.
+type verification struct{ err error }
+
+// Check returns nil if a middleware of this package verified the
+// request in ctx, or the reason it didn't. The [Interceptor] is the
+// canonical caller; this helper exists for hand-rolled HTTP handlers
+// that change state outside the Connect chain.
+func Check(ctx context.Context) error {
+	v, ok := ctx.Value(verificationKey{}).(verification)
+	if !ok {
+		return ErrNotVerified
+	}
+
+	return v.err
+}
+
+func withVerification(r *http.Request, err error) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), verificationKey{}, verification{err: err}))
+}
+
+type config struct {
+	cookieName     string
+	headerName     string
+	path           string
+	domain         string
+	sameSite       http.SameSite
+	secure         bool
+	ttl            time.Duration
+	trustedOrigins []string
+	allowSameSite  bool
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		cookieName: DefaultCookieName,
+		headerName: DefaultHeaderName,
+		path:       "/",
+		sameSite:   http.SameSiteLaxMode,
+		secure:     true,
+		ttl:        DefaultTTL,
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return cfg
+}
+
+// Option configures the middleware and token handler.
+type Option func(*config)
+
+// WithCookieName overrides the name of the token cookie. The
+// [Middleware] and [TokenHandler] must be configured with the same
+// name.
+func WithCookieName(name string) Option { return func(c *config) { c.cookieName = name } }
+
+// WithHeaderName overrides the request header the token is read
+// from. Remember to allow it in CORS when front-ends are served
+// from another origin.
+func WithHeaderName(name string) Option { return func(c *config) { c.headerName = name } }
+
+// WithPath overrides the token cookie's Path attribute. Defaults to
+// "/".
+func WithPath(p string) Option { return func(c *config) { c.path = p } }
+
+// WithDomain sets the token cookie's Domain attribute. Empty (the
+// default) means host-only.
+func WithDomain(d string) Option { return func(c *config) { c.domain = d } }
+
+// WithSameSite overrides the token cookie's SameSite attribute.
+// Defaults to [http.SameSiteLaxMode].
+func WithSameSite(s http.SameSite) Option { return func(c *config) { c.sameSite = s } }
+
+// WithInsecure drops the Secure attribute from the token cookie.
+// Use only for local development over plain HTTP.
+func WithInsecure() Option { return func(c *config) { c.secure = false } }
+
+// WithTTL overrides how long an issued token remains valid. It is
+// enforced both by the cookie's Max-Age and by the signature's
+// embedded timestamp.
+func WithTTL(d time.Duration) Option { return func(c *config) { c.ttl = d } }
+
+// WithTrustedOrigins allows cross-origin requests from the given
+// origins (e.g. "https://app.example.com") in [OriginMiddleware].
+func WithTrustedOrigins(origins ...string) Option {
+	return func(c *config) { c.trustedOrigins = append(c.trustedOrigins, origins...) }
+}
+
+// WithSameSiteRequests allows requests that the browser reports as
+// "same-site" (e.g. from a sibling subdomain) in [OriginMiddleware].
+// By default only same-origin requests are allowed.
+func WithSameSiteRequests() Option { return func(c *config) { c.allowSameSite = true } }
+
+// newCodec returns the codec that signs the token cookie. hashKey
+// MUST be at least [MinHashKeyLen] bytes; shorter keys panic
+// (programmer error).
+func newCodec(hashKey []byte, cfg config) (*securecookie.SecureCookie, int) {
+	if len(hashKey) < MinHashKeyLen {
+		panic("stdcrpccsrf: hashKey must be at least 32 bytes")
+	}
+
+	maxAgeSeconds := max(int(cfg.ttl.Seconds()), 1)
+
+	return securecookie.New(hashKey, nil).MaxAge(maxAgeSeconds), maxAgeSeconds
+}
+
+// Middleware builds an HTTP middleware that verifies the
+// double-submit token: the token in the signed cookie must equal the
+// token in the configured header. The outcome is enforced by the
+// [Interceptor], see [Check].
+func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler {
+	cfg := newConfig(opts...)
+	codec, _ := newCodec(hashKey, cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, withVerification(r, verifyToken(r, codec, cfg)))
+		})
+	}
+}
+
+func verifyToken(r *http.Request, codec *securecookie.SecureCookie, cfg config) error {
+	if len(r.Cookies()) == 0 {
+		return nil
+	}
+
+	cookie, err := r.Cookie(cfg.cookieName)
+	if err != nil {
+		return ErrMissingToken
+	}
+
+	header := r.Header.Get(cfg.headerName)
+	if header == "" {
+		return ErrMissingToken
+	}
+
+	var token string
+	if err := codec.Decode(cfg.cookieName, cookie.Value, &token); err != nil {
+		return ErrInvalidToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(header)) != 1 {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// TokenHandler returns a handler front-ends call to fetch the token
+// they must echo in the header. It responds with a JSON object
+// `{"token": "..."}` and reuses the token from a valid cookie, or
+// issues a new token and cookie otherwise. The response can't be
+// read cross-origin unless CORS allows it, which is what keeps the
+// token secret from attackers.
+func TokenHandler(hashKey []byte, opts ...Option) http.Handler {
+	cfg := newConfig(opts...)
+	codec, maxAgeSeconds := newCodec(hashKey, cfg)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var token string
+		if cookie, err := r.Cookie(cfg.cookieName); err != nil ||
+			codec.Decode(cfg.cookieName, cookie.Value, &token) != nil {
+			buf := make([]byte, tokenLen)
+			_, _ = rand.Read(buf)
+			token = base64.RawURLEncoding.EncodeToString(buf)
+
+			encoded, err := codec.Encode(cfg.cookieName, token)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+				return
+			}
+
+			// gosec G124 flags cookies whose Secure attribute may be
+			// false. WithInsecure() is an explicit opt-in for local
+			// dev only.
+			http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct,gosec
+				Name:     cfg.cookieName,
+				Value:    encoded,
+				Path:     cfg.path,
+				Domain:   cfg.domain,
+				MaxAge:   maxAgeSeconds,
+				HttpOnly: true,
+				Secure:   cfg.secure,
+				SameSite: cfg.sameSite,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(struct {
+			Token string `json:"token"`
+		}{Token: token})
+	})
+}
+
+// OriginMiddleware builds an HTTP middleware that verifies the
+// request came from the same origin, using the Sec-Fetch-Site header
+// and falling back to the Origin header for browsers that don't send
+// fetch metadata. Requests with neither header are not verified. The
+// outcome is enforced by the [Interceptor], see [Check].
+func OriginMiddleware(opts ...Option) func(http.Handler) http.Handler {
+	cfg := newConfig(opts...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, withVerification(r, verifyOrigin(r, cfg)))
+		})
+	}
+}
+
+func verifyOrigin(r *http.Request, cfg config) error {
+	if len(r.Cookies()) == 0 {
+		return nil
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin != "" && slices.Contains(cfg.trustedOrigins, origin) {
+		return nil
+	}
+
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "same-origin", "none":
+		return nil
+	case "same-site":
+		if cfg.allowSameSite {
+			return nil
+		}
+
+		return ErrCrossOrigin
+	case "":
+	default:
+		return ErrCrossOrigin
+	}
+
+	if origin == "" || origin == "null" {
+		return ErrCrossOrigin
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Host != r.Host {
+		return ErrCrossOrigin
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package stdcrpccsrf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/advdv/stdgo/stdcrpc/stdcrpccsrf"
+	"github.com/stretchr/testify/require"
+)
+
+var hashKey = bytes.Repeat([]byte("k"), stdcrpccsrf.MinHashKeyLen)
+
+// fetchToken calls the token handler like a front-end would and
+// returns the token and the cookie it was issued with.
+func fetchToken(t *testing.T, cookie *http.Cookie) (string, *http.Cookie) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/csrf", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	stdcrpccsrf.TokenHandler(hashKey).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var body struct{ Token string }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.NotEmpty(t, body.Token)
+
+	if cookies := rec.Result().Cookies(); len(cookies) > 0 {
+		require.True(t, cookies[0].HttpOnly)
+		require.True(t, cookies[0].Secure)
+
+		return body.Token, cookies[0]
+	}
+
+	return body.Token, cookie
+}
+
+// verify runs the handler behind the middleware and returns what
+// [stdcrpccsrf.Check] reported.
+func verify(mw func(http.Handler) http.Handler, req *http.Request) (err error) {
+	mw(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		err = stdcrpccsrf.Check(r.Context())
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return err
+}
+
+func TestTokenHandler(t *testing.T) {
+	t.Parallel()
+
+	token, cookie := fetchToken(t, nil)
+
+	again, sameCookie := fetchToken(t, cookie)
+	require.Equal(t, token, again, "valid cookie should be reused")
+	require.Equal(t, cookie, sameCookie)
+
+	tampered := *cookie
+	tampered.Value += "x"
+	other, _ := fetchToken(t, &tampered)
+	require.NotEqual(t, token, other)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	token, cookie := fetchToken(t, nil)
+	mw := stdcrpccsrf.Middleware(hashKey)
+
+	for _, tc := range []struct {
+		name   string
+		cookie *http.Cookie
+		header string
+		expErr error
+	}{
+		{name: "no cookies at all"},
+		{name: "matching token", cookie: cookie, header: token},
+		{name: "missing header", cookie: cookie, expErr: stdcrpccsrf.ErrMissingToken},
+		{name: "other cookie only", cookie: &http.Cookie{Name: "session", Value: "x"}, header: token,
+			expErr: stdcrpccsrf.ErrMissingToken},
+		{name: "mismatching header", cookie: cookie, header: token + "x", expErr: stdcrpccsrf.ErrInvalidToken},
+		{name: "forged cookie", cookie: &http.Cookie{Name: stdcrpccsrf.DefaultCookieName, Value: token},
+			header: token, expErr: stdcrpccsrf.ErrInvalidToken},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/", nil)
+			if tc.cookie != nil {
+				req.AddCookie(tc.cookie)
+			}
+
+			if tc.header != "" {
+				req.Header.Set(stdcrpccsrf.DefaultHeaderName, tc.header)
+			}
+
+			require.ErrorIs(t, verify(mw, req), tc.expErr)
+		})
+	}
+}
+
+func TestOriginMiddleware(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		opts   []stdcrpccsrf.Option
+		header http.Header
+		expErr error
+	}{
+		{name: "same-origin fetch", header: http.Header{"Sec-Fetch-Site": {"same-origin"}}},
+		{name: "user initiated", header: http.Header{"Sec-Fetch-Site": {"none"}}},
+		{name: "cross-site fetch", header: http.Header{"Sec-Fetch-Site": {"cross-site"}}, expErr: stdcrpccsrf.ErrCrossOrigin},
+		{name: "same-site fetch", header: http.Header{"Sec-Fetch-Site": {"same-site"}}, expErr: stdcrpccsrf.ErrCrossOrigin},
+		{
+			name: "same-site fetch allowed", opts: []stdcrpccsrf.Option{stdcrpccsrf.WithSameSiteRequests()},
+			header: http.Header{"Sec-Fetch-Site": {"same-site"}},
+		},
+		{
+			name:   "trusted cross-site origin",
+			opts:   []stdcrpccsrf.Option{stdcrpccsrf.WithTrustedOrigins("https://app.example.com")},
+			header: http.Header{"Sec-Fetch-Site": {"cross-site"}, "Origin": {"https://app.example.com"}},
+		},
+		{name: "same origin header", header: http.Header{"Origin": {"http://example.com"}}},
+		{name: "other origin header", header: http.Header{"Origin": {"http://evil.com"}}, expErr: stdcrpccsrf.ErrCrossOrigin},
+		{name: "null origin", header: http.Header{"Origin": {"null"}}, expErr: stdcrpccsrf.ErrCrossOrigin},
+		{name: "no metadata", expErr: stdcrpccsrf.ErrCrossOrigin},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "http://example.com/", nil)
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+
+			req.AddCookie(&http.Cookie{Name: "session", Value: "x"})
+			require.ErrorIs(t, verify(stdcrpccsrf.OriginMiddleware(tc.opts...), req), tc.expErr)
+		})
+	}
+
+	t.Run("no cookies", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "http://example.com/", nil)
+		require.NoError(t, verify(stdcrpccsrf.OriginMiddleware(), req))
+	})
+}
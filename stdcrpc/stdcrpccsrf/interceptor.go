@@ -0,0 +1,60 @@
+package stdcrpccsrf
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// Interceptor returns a server-side Connect interceptor that rejects
+// requests to procedures whose idempotency level is anything other
+// than [connect.IdempotencyNoSideEffects] with
+// [connect.CodePermissionDenied], unless [Middleware] or
+// [OriginMiddleware] verified the request.
+//
+// Unlike the write fence, a missing middleware is not fail-quiet:
+// forgetting to install it rejects every unsafe procedure, which
+// surfaces the wiring mistake immediately instead of silently
+// leaving the endpoints unprotected.
+//
+// Procedures that don't declare an idempotency level are treated as
+// unsafe. The interceptor is a no-op on the client side.
+func Interceptor() connect.Interceptor { return interceptor{} }
+
+type interceptor struct{}
+
+func (interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := check(ctx, req.Spec()); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := check(ctx, conn.Spec()); err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
+	}
+}
+
+func check(ctx context.Context, spec connect.Spec) error {
+	if spec.IsClient || spec.IdempotencyLevel == connect.IdempotencyNoSideEffects {
+		return nil
+	}
+
+	if err := Check(ctx); err != nil {
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package stdcrpccsrf_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpccsrf"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testProcedure = "/test.v1.TestService/Test"
+
+func newTestServer(t *testing.T, idem connect.IdempotencyLevel, mw func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+
+	handler := connect.NewUnaryHandler(
+		testProcedure,
+		func(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithIdempotency(idem),
+		connect.WithInterceptors(stdcrpccsrf.Interceptor()),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(testProcedure, handler)
+
+	srv := httptest.NewServer(mw(mux))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func call(t *testing.T, srv *httptest.Server, hdr http.Header) error {
+	t.Helper()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+testProcedure)
+	req := connect.NewRequest(&emptypb.Empty{})
+	for k, v := range hdr {
+		req.Header()[k] = v
+	}
+
+	_, err := client.CallUnary(t.Context(), req)
+
+	return err
+}
+
+func TestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	token, cookie := fetchToken(t, nil)
+	withCookie := http.Header{"Cookie": {cookie.String()}}
+	withToken := http.Header{"Cookie": {cookie.String()}, stdcrpccsrf.DefaultHeaderName: {token}}
+
+	t.Run("unsafe procedure", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestServer(t, connect.IdempotencyUnknown, stdcrpccsrf.Middleware(hashKey))
+		require.NoError(t, call(t, srv, nil))
+		require.NoError(t, call(t, srv, withToken))
+
+		err := call(t, srv, withCookie)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		require.ErrorContains(t, err, stdcrpccsrf.ErrMissingToken.Error())
+	})
+
+	t.Run("no side effects is exempt", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestServer(t, connect.IdempotencyNoSideEffects, stdcrpccsrf.Middleware(hashKey))
+		require.NoError(t, call(t, srv, withCookie))
+	})
+
+	t.Run("missing middleware", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestServer(t, connect.IdempotencyIdempotent, func(h http.Handler) http.Handler { return h })
+		err := call(t, srv, nil)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		require.ErrorContains(t, err, stdcrpccsrf.ErrNotVerified.Error())
+	})
+}
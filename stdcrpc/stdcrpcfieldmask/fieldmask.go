@@ -0,0 +1,373 @@
+// Package stdcrpcfieldmask applies the field mask of update procedures to ent mutations. Only the fields in the mask
+// are set on the mutation, fields in the mask that are not set in the message are cleared:
+//
+//	var userMask = stdcrpcfieldmask.New[*usersv1.User](
+//		stdcrpcfieldmask.WithImmutable("id", "create_time"),
+//		stdcrpcfieldmask.WithField("address.city", "city"))
+//
+//	upd := tx.User.UpdateOneID(id)
+//	if err := userMask.Apply(req.Msg.GetUpdateMask(), req.Msg.GetUser(), upd.Mutation()); err != nil {
+//		return nil, err
+//	}
+//
+//go:generate go tool entgo.io/ent/cmd/ent generate ./testdata/schema --target testdata/model
+package stdcrpcfieldmask
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"entgo.io/ent"
+	"github.com/advdv/stdgo/stdcrpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Convert converts the value of a message field into the value of the ent field. An error is reported to the
+// client as a violation of the field, so conversions can also validate the value.
+type Convert func(v protoreflect.Value) (ent.Value, error)
+
+// Option configures the [Mapping].
+type Option func(*options)
+
+type options struct {
+	maskField string
+	entFields map[string]string
+	immutable []string
+	converts  map[string]Convert
+}
+
+// WithField maps the field path of the message to the ent field. By default the ent field is named after the
+// path, with dots replaced by underscores: "address.city" maps to "address_city".
+func WithField(path, entField string) Option {
+	return func(o *options) { o.entFields[path] = entField }
+}
+
+// WithImmutable marks the field paths that can't be updated, e.g. the ID or output-only fields. The fields inside an
+// immutable message field can't be updated either.
+func WithImmutable(paths ...string) Option {
+	return func(o *options) { o.immutable = append(o.immutable, paths...) }
+}
+
+// WithConvert sets the conversion for the value of the field path. It is required for enums, maps and repeated
+// fields other than strings and integers, or when the ent field has another type than the default conversion.
+func WithConvert(path string, conv Convert) Option {
+	return func(o *options) { o.converts[path] = conv }
+}
+
+// WithMaskField sets the name of the field mask in the request, it is used in the field violations of errors. By
+// default it is "update_mask".
+func WithMaskField(name string) Option {
+	return func(o *options) { o.maskField = name }
+}
+
+// field is a field path of the message that can be updated.
+type field struct {
+	chain    []protoreflect.FieldDescriptor
+	entField string
+	convert  Convert
+}
+
+// Mapping maps the field paths of message M to the fields of an ent entity.
+type Mapping[M proto.Message] struct {
+	desc      protoreflect.MessageDescriptor
+	maskField string
+	declared  map[string]*field
+	immutable []string
+}
+
+// New inits the mapping for message M. It panics when the options refer to paths that don't exist in M.
+func New[M proto.Message](opts ...Option) *Mapping[M] {
+	opt := options{maskField: "update_mask", entFields: map[string]string{}, converts: map[string]Convert{}}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	var msg M
+	mp := &Mapping[M]{
+		desc:      msg.ProtoReflect().Descriptor(),
+		maskField: opt.maskField,
+		declared:  map[string]*field{},
+	}
+
+	declare := func(path string) *field {
+		if fld, ok := mp.declared[path]; ok {
+			return fld
+		}
+
+		fld, err := mp.resolve(path)
+		if err != nil {
+			panic("stdcrpcfieldmask: " + err.Error())
+		}
+
+		mp.declared[path] = fld
+
+		return fld
+	}
+
+	for path, entField := range opt.entFields {
+		declare(path).entField = entField
+	}
+
+	for _, path := range opt.immutable {
+		declare(path)
+	}
+
+	mp.immutable = opt.immutable
+
+	for path, conv := range opt.converts {
+		declare(path).convert = conv
+	}
+
+	return mp
+}
+
+// resolve the field path by naming convention.
+func (mp *Mapping[M]) resolve(path string) (*field, error) {
+	var chain []protoreflect.FieldDescriptor
+
+	desc := mp.desc
+	for name := range strings.SplitSeq(path, ".") {
+		if desc == nil {
+			return nil, fmt.Errorf("field %q is not a message, %q can't select a field of it",
+				chain[len(chain)-1].Name(), path)
+		}
+
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("unknown field %q in %s", name, desc.FullName())
+		}
+
+		chain, desc = append(chain, fd), nil
+		if fd.Kind() == protoreflect.MessageKind && fd.Cardinality() != protoreflect.Repeated {
+			desc = fd.Message()
+		}
+	}
+
+	return &field{chain: chain, entField: strings.ReplaceAll(path, ".", "_")}, nil
+}
+
+// Apply sets the fields in the mask to their value in msg on the mutation, and clears the fields in the mask that
+// are not set in msg. Unknown and immutable paths, and fields that can't be cleared, are reported in one
+// [connect.CodeInvalidArgument] error with a field violation per path. The mutation must not be saved when an error
+// is returned.
+func (mp *Mapping[M]) Apply(mask *fieldmaskpb.FieldMask, msg M, mut ent.Mutation) error {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		return stdcrpc.ArgRead{}.Field(mp.maskField).Invalid("must contain at least one path").Error()
+	}
+
+	var (
+		ar     stdcrpc.ArgRead
+		fields = make([]*field, len(paths))
+	)
+
+	for i, path := range paths {
+		ar = ar.Field(fmt.Sprintf("%s.paths[%d]", mp.maskField, i))
+
+		fld, ok := mp.declared[path]
+		if !ok {
+			var err error
+			if fld, err = mp.resolve(path); err != nil {
+				ar = ar.Invalid(err.Error())
+
+				continue
+			}
+		}
+
+		if imm, ok := mp.immutableOf(path); ok && imm == path {
+			ar = ar.Invalid(fmt.Sprintf("field %q is immutable", path))
+
+			continue
+		} else if ok {
+			ar = ar.Invalid(fmt.Sprintf("field %q is part of immutable field %q", path, imm))
+
+			continue
+		}
+
+		fields[i] = fld
+	}
+
+	if err := ar.Error(); err != nil {
+		return err
+	}
+
+	return mp.apply(paths, fields, msg.ProtoReflect(), mut)
+}
+
+// immutableOf returns the immutable path that is the path itself, or a message field that contains it.
+func (mp *Mapping[M]) immutableOf(path string) (string, bool) {
+	for _, imm := range mp.immutable {
+		if path == imm || strings.HasPrefix(path, imm+".") {
+			return imm, true
+		}
+	}
+
+	return "", false
+}
+
+func (mp *Mapping[M]) apply(paths []string, fields []*field, msg protoreflect.Message, mut ent.Mutation) error {
+	type setField struct {
+		name  string
+		value ent.Value
+	}
+
+	var (
+		ar      stdcrpc.ArgRead
+		sets    []setField
+		clears  []int
+		unknown []error
+	)
+
+	for i, fld := range fields {
+		ar = ar.Field(fmt.Sprintf("%s.paths[%d]", mp.maskField, i))
+
+		val, ok := fld.value(msg)
+		if !ok {
+			clears = append(clears, i)
+
+			continue
+		}
+
+		conv := fld.convert
+		if conv == nil {
+			if conv = defaultConvert(fld.chain[len(fld.chain)-1]); conv == nil {
+				unknown = append(unknown, fmt.Errorf("no conversion for field %q", paths[i]))
+
+				continue
+			}
+		}
+
+		entVal, err := conv(val)
+		if err != nil {
+			ar = ar.Invalid(fmt.Sprintf("invalid value for field %q: %v", paths[i], err))
+
+			continue
+		}
+
+		sets = append(sets, setField{fld.entField, entVal})
+	}
+
+	if len(unknown) > 0 {
+		return connect.NewError(connect.CodeInternal, errors.Join(unknown...))
+	}
+
+	if err := ar.Error(); err != nil {
+		return err
+	}
+
+	// clearing only fails for ent fields that are not nullable, so the client has to provide the value.
+	for _, i := range clears {
+		if err := mut.ClearField(fields[i].entField); err != nil {
+			ar = ar.Field(fmt.Sprintf("%s.paths[%d]", mp.maskField, i)).
+				Invalid(fmt.Sprintf("field %q is required and can't be cleared", paths[i]))
+		}
+	}
+
+	if err := ar.Error(); err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+		if err := mut.SetField(set.name, set.value); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("set field: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// value returns the value of the field in the message, it is not ok when the field, or a message that contains it,
+// is not set.
+func (fld *field) value(msg protoreflect.Message) (protoreflect.Value, bool) {
+	for _, fd := range fld.chain[:len(fld.chain)-1] {
+		if !msg.Has(fd) {
+			return protoreflect.Value{}, false
+		}
+
+		msg = msg.Get(fd).Message()
+	}
+
+	leaf := fld.chain[len(fld.chain)-1]
+	if (leaf.HasPresence() || leaf.IsList()) && !msg.Has(leaf) {
+		return protoreflect.Value{}, false
+	}
+
+	return msg.Get(leaf), true
+}
+
+// defaultConvert returns the conversion of the field's values into the common ent field types, or nil if there is
+// none: integers are converted into int (or uint), floats into float64, timestamps into time.Time and durations into
+// time.Duration. Other messages are passed as is, for JSON fields.
+func defaultConvert(fd protoreflect.FieldDescriptor) Convert {
+	switch {
+	case fd.IsMap():
+		return nil
+	case fd.IsList():
+		return listConvert(fd)
+	}
+
+	if conv := scalarConvert(fd); conv != nil {
+		return func(v protoreflect.Value) (ent.Value, error) { return conv(v), nil }
+	}
+
+	if fd.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+
+	return func(v protoreflect.Value) (ent.Value, error) {
+		switch msg := v.Message().Interface().(type) {
+		case *timestamppb.Timestamp:
+			return msg.AsTime(), msg.CheckValid()
+		case *durationpb.Duration:
+			return msg.AsDuration(), msg.CheckValid()
+		default:
+			return msg, nil
+		}
+	}
+}
+
+func scalarConvert(fd protoreflect.FieldDescriptor) func(v protoreflect.Value) ent.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind, protoreflect.StringKind, protoreflect.BytesKind:
+		return func(v protoreflect.Value) ent.Value { return v.Interface() }
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return func(v protoreflect.Value) ent.Value { return int(v.Int()) }
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return func(v protoreflect.Value) ent.Value { return uint(v.Uint()) }
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return func(v protoreflect.Value) ent.Value { return v.Float() }
+	default:
+		return nil
+	}
+}
+
+// listConvert converts lists of strings and integers into the []string and []int of ent's Strings and Ints fields.
+func listConvert(fd protoreflect.FieldDescriptor) Convert {
+	conv := scalarConvert(fd)
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return func(v protoreflect.Value) (ent.Value, error) { return convertList[string](v.List(), conv), nil }
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return func(v protoreflect.Value) (ent.Value, error) { return convertList[int](v.List(), conv), nil }
+	default:
+		return nil
+	}
+}
+
+func convertList[T any](list protoreflect.List, conv func(v protoreflect.Value) ent.Value) []T {
+	out := make([]T, list.Len())
+	for i := range out {
+		out[i], _ = conv(list.Get(i)).(T)
+	}
+
+	return out
+}
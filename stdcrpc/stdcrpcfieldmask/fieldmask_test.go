@@ -0,0 +1,184 @@
+package stdcrpcfieldmask_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"entgo.io/ent"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcfieldmask"
+	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcfieldmask/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcfieldmask/testdata/model"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcfieldmask/testdata/model/user"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var mapping = stdcrpcfieldmask.New[*internalv1.User](
+	stdcrpcfieldmask.WithImmutable("id"),
+	stdcrpcfieldmask.WithConvert("status", func(v protoreflect.Value) (ent.Value, error) {
+		switch internalv1.Status(v.Enum()) {
+		case internalv1.Status_STATUS_ACTIVE:
+			return user.StatusActive, nil
+		default:
+			return nil, errors.New("must be a defined status")
+		}
+	}))
+
+func newMutation() *model.UserMutation {
+	return model.NewClient().User.Update().Mutation()
+}
+
+func update(paths ...string) (*internalv1.UpdateUserRequest, *fieldmaskpb.FieldMask) {
+	return internalv1.UpdateUserRequest_builder{
+		User: internalv1.User_builder{
+			Id:       proto.String("usr_1"),
+			Name:     proto.String("Alice"),
+			Age:      proto.Int64(42),
+			Birthday: timestamppb.New(time.Date(1984, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Address:  internalv1.Address_builder{City: proto.String("Amsterdam")}.Build(),
+			Tags:     []string{"a", "b"},
+			Status:   internalv1.Status_STATUS_ACTIVE.Enum(),
+		}.Build(),
+	}.Build(), &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+func violations(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var cerr *connect.Error
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, connect.CodeInvalidArgument, cerr.Code())
+	require.Len(t, cerr.Details(), 1)
+
+	detail, derr := cerr.Details()[0].Value()
+	require.NoError(t, derr)
+
+	out := map[string]string{}
+	for _, v := range detail.(*errdetails.BadRequest).GetFieldViolations() {
+		out[v.GetField()] = v.GetDescription()
+	}
+
+	return out
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	req, mask := update("name", "age", "birthday", "address.city", "tags", "status")
+	mut := newMutation()
+	require.NoError(t, mapping.Apply(mask, req.GetUser(), mut))
+
+	name, _ := mut.Name()
+	require.Equal(t, "Alice", name)
+	age, _ := mut.Age()
+	require.Equal(t, 42, age)
+	birthday, _ := mut.Birthday()
+	require.Equal(t, time.Date(1984, 1, 2, 0, 0, 0, 0, time.UTC), birthday)
+	city, _ := mut.AddressCity()
+	require.Equal(t, "Amsterdam", city)
+	tags, _ := mut.Tags()
+	require.Equal(t, []string{"a", "b"}, tags)
+	status, _ := mut.Status()
+	require.Equal(t, user.StatusActive, status)
+
+	// fields that are not in the mask are left alone.
+	_, ok := mut.Nickname()
+	require.False(t, ok)
+	require.Len(t, mut.Fields(), 6)
+}
+
+func TestApplyClear(t *testing.T) {
+	t.Parallel()
+
+	req, mask := update("nickname", "birthday", "address.city", "tags", "status")
+	req.GetUser().ClearBirthday()
+	req.GetUser().ClearAddress()
+	req.GetUser().SetTags(nil)
+	req.GetUser().ClearStatus()
+
+	mut := newMutation()
+	require.NoError(t, mapping.Apply(mask, req.GetUser(), mut))
+	require.Empty(t, mut.Fields())
+
+	for _, name := range []string{
+		user.FieldNickname, user.FieldBirthday, user.FieldAddressCity, user.FieldTags, user.FieldStatus,
+	} {
+		require.True(t, mut.FieldCleared(name), name)
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	t.Parallel()
+
+	req, mask := update("name", "id", "foo", "address.street", "name.first")
+	require.Equal(t, map[string]string{
+		"update_mask.paths[1]": `field "id" is immutable`,
+		"update_mask.paths[2]": `unknown field "foo" in stdcrpc.stdcrpcfieldmask.internal.v1.User`,
+		"update_mask.paths[3]": `unknown field "street" in stdcrpc.stdcrpcfieldmask.internal.v1.Address`,
+		"update_mask.paths[4]": `field "name" is not a message, "name.first" can't select a field of it`,
+	}, violations(t, mapping.Apply(mask, req.GetUser(), newMutation())))
+
+	_, mask = update()
+	require.Equal(t, map[string]string{
+		"update_mask": "must contain at least one path",
+	}, violations(t, mapping.Apply(mask, req.GetUser(), newMutation())))
+
+	req, mask = update("age", "name")
+	req.GetUser().ClearName()
+	require.Equal(t, map[string]string{
+		"update_mask.paths[1]": `field "name" is required and can't be cleared`,
+	}, violations(t, mapping.Apply(mask, req.GetUser(), newMutation())))
+
+	req, mask = update("status")
+	req.GetUser().SetStatus(internalv1.Status_STATUS_UNSPECIFIED)
+	require.Equal(t, map[string]string{
+		"update_mask.paths[0]": `invalid value for field "status": must be a defined status`,
+	}, violations(t, mapping.Apply(mask, req.GetUser(), newMutation())))
+}
+
+func TestApplyImmutableMessage(t *testing.T) {
+	t.Parallel()
+
+	other := stdcrpcfieldmask.New[*internalv1.User](stdcrpcfieldmask.WithImmutable("address"))
+
+	req, mask := update("address", "address.city", "name")
+	require.Equal(t, map[string]string{
+		"update_mask.paths[0]": `field "address" is immutable`,
+		"update_mask.paths[1]": `field "address.city" is part of immutable field "address"`,
+	}, violations(t, other.Apply(mask, req.GetUser(), newMutation())))
+}
+
+func TestApplyInternal(t *testing.T) {
+	t.Parallel()
+
+	// the enum has no conversion, and the ent field of the renamed field doesn't exist.
+	other := stdcrpcfieldmask.New[*internalv1.User](stdcrpcfieldmask.WithField("name", "full_name"))
+
+	req, mask := update("status")
+	err := other.Apply(mask, req.GetUser(), newMutation())
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	require.ErrorContains(t, err, `no conversion for field "status"`)
+
+	_, mask = update("name")
+	err = other.Apply(mask, req.GetUser(), newMutation())
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	require.ErrorContains(t, err, "full_name")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t,
+		`stdcrpcfieldmask: unknown field "bogus" in stdcrpc.stdcrpcfieldmask.internal.v1.User`,
+		func() { stdcrpcfieldmask.New[*internalv1.User](stdcrpcfieldmask.WithImmutable("bogus")) })
+
+	other := stdcrpcfieldmask.New[*internalv1.User](stdcrpcfieldmask.WithMaskField("mask"))
+	req, mask := update("foo")
+	require.Contains(t, violations(t, other.Apply(mask, req.GetUser(), newMutation())), "mask.paths[0]")
+}
@@ -0,0 +1,578 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: stdcrpc/stdcrpcfieldmask/internal/v1/fieldmask.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type User struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_Nickname    *string                `protobuf:"bytes,3,opt,name=nickname"`
+	xxx_hidden_Age         int64                  `protobuf:"varint,4,opt,name=age"`
+	xxx_hidden_Birthday    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=birthday"`
+	xxx_hidden_Address     *Address               `protobuf:"bytes,6,opt,name=address"`
+	xxx_hidden_Tags        []string               `protobuf:"bytes,7,rep,name=tags"`
+	xxx_hidden_Status      Status                 `protobuf:"varint,8,opt,name=status,enum=stdcrpc.stdcrpcfieldmask.internal.v1.Status"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetNickname() string {
+	if x != nil {
+		if x.xxx_hidden_Nickname != nil {
+			return *x.xxx_hidden_Nickname
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetAge() int64 {
+	if x != nil {
+		return x.xxx_hidden_Age
+	}
+	return 0
+}
+
+func (x *User) GetBirthday() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Birthday
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *Address {
+	if x != nil {
+		return x.xxx_hidden_Address
+	}
+	return nil
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 7) {
+			return x.xxx_hidden_Status
+		}
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *User) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *User) SetNickname(v string) {
+	x.xxx_hidden_Nickname = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *User) SetAge(v int64) {
+	x.xxx_hidden_Age = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *User) SetBirthday(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Birthday = v
+}
+
+func (x *User) SetAddress(v *Address) {
+	x.xxx_hidden_Address = v
+}
+
+func (x *User) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *User) SetStatus(v Status) {
+	x.xxx_hidden_Status = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *User) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *User) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *User) HasNickname() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *User) HasAge() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *User) HasBirthday() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Birthday != nil
+}
+
+func (x *User) HasAddress() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Address != nil
+}
+
+func (x *User) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *User) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *User) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *User) ClearNickname() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Nickname = nil
+}
+
+func (x *User) ClearAge() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Age = 0
+}
+
+func (x *User) ClearBirthday() {
+	x.xxx_hidden_Birthday = nil
+}
+
+func (x *User) ClearAddress() {
+	x.xxx_hidden_Address = nil
+}
+
+func (x *User) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Status = Status_STATUS_UNSPECIFIED
+}
+
+type User_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id       *string
+	Name     *string
+	Nickname *string
+	Age      *int64
+	Birthday *timestamppb.Timestamp
+	Address  *Address
+	Tags     []string
+	Status   *Status
+}
+
+func (b0 User_builder) Build() *User {
+	m0 := &User{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Nickname != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_Nickname = b.Nickname
+	}
+	if b.Age != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Age = *b.Age
+	}
+	x.xxx_hidden_Birthday = b.Birthday
+	x.xxx_hidden_Address = b.Address
+	x.xxx_hidden_Tags = b.Tags
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Status = *b.Status
+	}
+	return m0
+}
+
+type Address struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_City        *string                `protobuf:"bytes,1,opt,name=city"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		if x.xxx_hidden_City != nil {
+			return *x.xxx_hidden_City
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Address) SetCity(v string) {
+	x.xxx_hidden_City = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *Address) HasCity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Address) ClearCity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_City = nil
+}
+
+type Address_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	City *string
+}
+
+func (b0 Address_builder) Build() *Address {
+	m0 := &Address{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.City != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_City = b.City
+	}
+	return m0
+}
+
+type UpdateUserRequest struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_User       *User                  `protobuf:"bytes,1,opt,name=user"`
+	xxx_hidden_UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.xxx_hidden_User
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.xxx_hidden_UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) SetUser(v *User) {
+	x.xxx_hidden_User = v
+}
+
+func (x *UpdateUserRequest) SetUpdateMask(v *fieldmaskpb.FieldMask) {
+	x.xxx_hidden_UpdateMask = v
+}
+
+func (x *UpdateUserRequest) HasUser() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_User != nil
+}
+
+func (x *UpdateUserRequest) HasUpdateMask() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdateMask != nil
+}
+
+func (x *UpdateUserRequest) ClearUser() {
+	x.xxx_hidden_User = nil
+}
+
+func (x *UpdateUserRequest) ClearUpdateMask() {
+	x.xxx_hidden_UpdateMask = nil
+}
+
+type UpdateUserRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	User       *User
+	UpdateMask *fieldmaskpb.FieldMask
+}
+
+func (b0 UpdateUserRequest_builder) Build() *UpdateUserRequest {
+	m0 := &UpdateUserRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_User = b.User
+	x.xxx_hidden_UpdateMask = b.UpdateMask
+	return m0
+}
+
+var File_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto protoreflect.FileDescriptor
+
+const file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_rawDesc = "" +
+	"\n" +
+	"4stdcrpc/stdcrpcfieldmask/internal/v1/fieldmask.proto\x12$stdcrpc.stdcrpcfieldmask.internal.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x10\n" +
+	"\x03age\x18\x04 \x01(\x03R\x03age\x126\n" +
+	"\bbirthday\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bbirthday\x12G\n" +
+	"\aaddress\x18\x06 \x01(\v2-.stdcrpc.stdcrpcfieldmask.internal.v1.AddressR\aaddress\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12D\n" +
+	"\x06status\x18\b \x01(\x0e2,.stdcrpc.stdcrpcfieldmask.internal.v1.StatusR\x06status\"\x1d\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"\x90\x01\n" +
+	"\x11UpdateUserRequest\x12>\n" +
+	"\x04user\x18\x01 \x01(\v2*.stdcrpc.stdcrpcfieldmask.internal.v1.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask*3\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01B\xb5\x02\n" +
+	"(com.stdcrpc.stdcrpcfieldmask.internal.v1B\x0eFieldmaskProtoP\x01ZFgithub.com/advdv/stdgo/stdcrpc/stdcrpcfieldmask/internal/v1;internalv1\xa2\x02\x03SSI\xaa\x02$Stdcrpc.Stdcrpcfieldmask.Internal.V1\xca\x02$Stdcrpc\\Stdcrpcfieldmask\\Internal\\V1\xe2\x020Stdcrpc\\Stdcrpcfieldmask\\Internal\\V1\\GPBMetadata\xea\x02'Stdcrpc::Stdcrpcfieldmask::Internal::V1b\beditionsp\xe8\a"
+
+var file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_goTypes = []any{
+	(Status)(0),                   // 0: stdcrpc.stdcrpcfieldmask.internal.v1.Status
+	(*User)(nil),                  // 1: stdcrpc.stdcrpcfieldmask.internal.v1.User
+	(*Address)(nil),               // 2: stdcrpc.stdcrpcfieldmask.internal.v1.Address
+	(*UpdateUserRequest)(nil),     // 3: stdcrpc.stdcrpcfieldmask.internal.v1.UpdateUserRequest
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 5: google.protobuf.FieldMask
+}
+var file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_depIdxs = []int32{
+	4, // 0: stdcrpc.stdcrpcfieldmask.internal.v1.User.birthday:type_name -> google.protobuf.Timestamp
+	2, // 1: stdcrpc.stdcrpcfieldmask.internal.v1.User.address:type_name -> stdcrpc.stdcrpcfieldmask.internal.v1.Address
+	0, // 2: stdcrpc.stdcrpcfieldmask.internal.v1.User.status:type_name -> stdcrpc.stdcrpcfieldmask.internal.v1.Status
+	1, // 3: stdcrpc.stdcrpcfieldmask.internal.v1.UpdateUserRequest.user:type_name -> stdcrpc.stdcrpcfieldmask.internal.v1.User
+	5, // 4: stdcrpc.stdcrpcfieldmask.internal.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_init() }
+func file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_init() {
+	if File_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_rawDesc), len(file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_goTypes,
+		DependencyIndexes: file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_depIdxs,
+		EnumInfos:         file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_enumTypes,
+		MessageInfos:      file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_msgTypes,
+	}.Build()
+	File_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto = out.File
+	file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_goTypes = nil
+	file_stdcrpc_stdcrpcfieldmask_internal_v1_fieldmask_proto_depIdxs = nil
+}
@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.String("nickname").Optional().Nillable(),
+		field.Int("age"),
+		field.Time("birthday").Optional().Nillable(),
+		field.String("address_city").Optional(),
+		field.Strings("tags").Optional(),
+		field.Enum("status").Values("active").Optional(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return nil
+}
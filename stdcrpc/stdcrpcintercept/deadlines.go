@@ -0,0 +1,144 @@
+package stdcrpcintercept
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DeadlinesOption configures the deadlines interceptor.
+type DeadlinesOption func(*deadlines)
+
+// WithDefaultTimeout sets the timeout of calls without a deadline, for procedures that don't declare a default
+// timeout themselves.
+func WithDefaultTimeout(timeout time.Duration) DeadlinesOption {
+	return func(dl *deadlines) { dl.fallback.def = timeout }
+}
+
+// WithMaxTimeout sets the maximum timeout of calls, for procedures that don't declare a maximum themselves.
+func WithMaxTimeout(timeout time.Duration) DeadlinesOption {
+	return func(dl *deadlines) { dl.fallback.max = timeout }
+}
+
+// WithTimeoutExtensions reads the default and maximum timeout of procedures from method options of the
+// google.protobuf.Duration type. The extensions are owned by the application's protobuf files, e.g.:
+//
+//	extend google.protobuf.MethodOptions {
+//	  google.protobuf.Duration default_timeout = 50203;
+//	  google.protobuf.Duration max_timeout = 50204;
+//	}
+//
+// Either extension may be nil.
+func WithTimeoutExtensions(defaultExt, maxExt protoreflect.ExtensionType) DeadlinesOption {
+	return func(dl *deadlines) { dl.defaultExt, dl.maxExt = defaultExt, maxExt }
+}
+
+// timeouts of a procedure, zero when there is none.
+type timeouts struct{ def, max time.Duration }
+
+// deadlines implements [connect.Interceptor].
+type deadlines struct {
+	fallback   timeouts
+	defaultExt protoreflect.ExtensionType
+	maxExt     protoreflect.ExtensionType
+	procedures sync.Map
+}
+
+// NewDeadlines creates a Connect interceptor that bounds the deadline of handlers. The timeout that a client sends
+// (Connect-Timeout-Ms or grpc-timeout) is lowered to the maximum of the procedure, and calls without a timeout get
+// the default of the procedure, or the maximum if there is no default. Transactions and downstream calls of the
+// handler then inherit the deadline.
+func NewDeadlines(opts ...DeadlinesOption) connect.Interceptor {
+	dl := &deadlines{}
+	for _, opt := range opts {
+		opt(dl)
+	}
+
+	return dl
+}
+
+func (dl *deadlines) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ctx, cancel := dl.bound(ctx, req.Spec())
+		defer cancel()
+
+		return next(ctx, req)
+	}
+}
+
+func (dl *deadlines) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (dl *deadlines) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, cancel := dl.bound(ctx, conn.Spec())
+		defer cancel()
+
+		return next(ctx, conn)
+	}
+}
+
+// bound returns the context with the deadline of the call, it never extends the deadline of the client.
+func (dl *deadlines) bound(ctx context.Context, spec connect.Spec) (context.Context, context.CancelFunc) {
+	tos := dl.timeouts(spec)
+
+	if _, ok := ctx.Deadline(); !ok && tos.def > 0 {
+		if tos.max > 0 {
+			tos.def = min(tos.def, tos.max)
+		}
+
+		return context.WithTimeout(ctx, tos.def)
+	}
+
+	if tos.max > 0 {
+		return context.WithTimeout(ctx, tos.max)
+	}
+
+	return ctx, func() {}
+}
+
+// timeouts returns the timeouts of the procedure, the ones it declares take precedence over the fallbacks. The
+// result is cached per procedure.
+func (dl *deadlines) timeouts(spec connect.Spec) timeouts {
+	if tos, ok := dl.procedures.Load(spec.Procedure); ok {
+		return tos.(timeouts) //nolint:forcetypeassert
+	}
+
+	tos := dl.fallback
+	if method, err := stdcrpc.MethodDescriptor(spec); err == nil {
+		if def := durationOption(method, dl.defaultExt); def > 0 {
+			tos.def = def
+		}
+
+		if maxTimeout := durationOption(method, dl.maxExt); maxTimeout > 0 {
+			tos.max = maxTimeout
+		}
+	}
+
+	dl.procedures.Store(spec.Procedure, tos)
+
+	return tos
+}
+
+// durationOption returns the duration of the method option, or zero if it is not set.
+func durationOption(method protoreflect.MethodDescriptor, ext protoreflect.ExtensionType) time.Duration {
+	opts := method.Options()
+	if ext == nil || opts == nil || !proto.HasExtension(opts, ext) {
+		return 0
+	}
+
+	dur, _ := proto.GetExtension(opts, ext).(*durationpb.Duration)
+
+	return dur.AsDuration()
+}
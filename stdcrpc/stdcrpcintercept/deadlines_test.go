@@ -0,0 +1,152 @@
+package stdcrpcintercept_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept"
+	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1/internalv1connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// deadlineService responds with the time that is left until the deadline of the handler.
+type deadlineService struct{}
+
+func remaining(ctx context.Context) *internalv1.DeadlineResponse {
+	resp := &internalv1.DeadlineResponse{}
+	if deadline, ok := ctx.Deadline(); ok {
+		resp.SetRemaining(durationpb.New(time.Until(deadline)))
+	}
+
+	return resp
+}
+
+func (deadlineService) Deadline(
+	ctx context.Context, _ *connect.Request[internalv1.DeadlineRequest],
+) (*connect.Response[internalv1.DeadlineResponse], error) {
+	return connect.NewResponse(remaining(ctx)), nil
+}
+
+func (s deadlineService) DeadlineUnset(
+	ctx context.Context, req *connect.Request[internalv1.DeadlineRequest],
+) (*connect.Response[internalv1.DeadlineResponse], error) {
+	return s.Deadline(ctx, req)
+}
+
+func (deadlineService) DeadlineStream(
+	ctx context.Context, _ *connect.Request[internalv1.DeadlineRequest],
+	stream *connect.ServerStream[internalv1.DeadlineResponse],
+) error {
+	return stream.Send(remaining(ctx))
+}
+
+func setupDeadlines(
+	t *testing.T, clientOpts []connect.ClientOption, opts ...stdcrpcintercept.DeadlinesOption,
+) internalv1connect.DeadlineServiceClient {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewDeadlineServiceHandler(deadlineService{},
+		connect.WithInterceptors(stdcrpcintercept.NewDeadlines(opts...))))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return internalv1connect.NewDeadlineServiceClient(srv.Client(), srv.URL, clientOpts...)
+}
+
+type deadlineCall = func(
+	context.Context, *connect.Request[internalv1.DeadlineRequest],
+) (*connect.Response[internalv1.DeadlineResponse], error)
+
+// callRemaining calls the procedure with the timeout, zero for none, and returns the remaining time of the handler.
+func callRemaining(t *testing.T, call deadlineCall, timeout time.Duration) *durationpb.Duration {
+	t.Helper()
+
+	ctx := t.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		t.Cleanup(cancel)
+	}
+
+	resp, err := call(ctx, connect.NewRequest(&internalv1.DeadlineRequest{}))
+	require.NoError(t, err)
+
+	return resp.Msg.GetRemaining()
+}
+
+func requireBetween(t *testing.T, rem *durationpb.Duration, low, high time.Duration) {
+	t.Helper()
+	require.NotNil(t, rem, "the handler must have a deadline")
+	require.Greater(t, rem.AsDuration(), low)
+	require.LessOrEqual(t, rem.AsDuration(), high)
+}
+
+func TestDeadlines(t *testing.T) {
+	t.Parallel()
+
+	for name, clientOpts := range map[string][]connect.ClientOption{
+		"connect": nil,
+		"grpc":    {connect.WithGRPC()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := setupDeadlines(t, clientOpts,
+				stdcrpcintercept.WithTimeoutExtensions(internalv1.E_DefaultTimeout, internalv1.E_MaxTimeout))
+
+			// the default of the procedure applies without a client timeout.
+			requireBetween(t, callRemaining(t, client.Deadline, 0), 500*time.Millisecond, time.Second)
+
+			// client timeouts are lowered to the maximum of the procedure.
+			requireBetween(t, callRemaining(t, client.Deadline, time.Minute), 1500*time.Millisecond, 2*time.Second)
+
+			// shorter client timeouts are kept.
+			requireBetween(t, callRemaining(t, client.Deadline, 300*time.Millisecond), 0, 300*time.Millisecond)
+
+			// procedures without options are left alone.
+			require.Nil(t, callRemaining(t, client.DeadlineUnset, 0))
+			requireBetween(t, callRemaining(t, client.DeadlineUnset, time.Minute), 50*time.Second, time.Minute)
+		})
+	}
+}
+
+func TestDeadlinesFallback(t *testing.T) {
+	t.Parallel()
+
+	client := setupDeadlines(t, nil,
+		stdcrpcintercept.WithTimeoutExtensions(internalv1.E_DefaultTimeout, internalv1.E_MaxTimeout),
+		stdcrpcintercept.WithDefaultTimeout(3*time.Second),
+		stdcrpcintercept.WithMaxTimeout(5*time.Second))
+
+	requireBetween(t, callRemaining(t, client.DeadlineUnset, 0), 2500*time.Millisecond, 3*time.Second)
+	requireBetween(t, callRemaining(t, client.DeadlineUnset, time.Minute), 4500*time.Millisecond, 5*time.Second)
+
+	// the options of the procedure take precedence.
+	requireBetween(t, callRemaining(t, client.Deadline, time.Minute), 1500*time.Millisecond, 2*time.Second)
+
+	// the fallback default is lowered to the maximum of the procedure.
+	stream, err := client.DeadlineStream(t.Context(), connect.NewRequest(&internalv1.DeadlineRequest{}))
+	require.NoError(t, err)
+	require.True(t, stream.Receive())
+	requireBetween(t, stream.Msg().GetRemaining(), 1500*time.Millisecond, 2*time.Second)
+	require.NoError(t, stream.Close())
+}
+
+func TestDeadlinesMaxOnly(t *testing.T) {
+	t.Parallel()
+
+	client := setupDeadlines(t, nil, stdcrpcintercept.WithMaxTimeout(3*time.Second))
+
+	// without a default, calls without a timeout get the maximum.
+	requireBetween(t, callRemaining(t, client.DeadlineUnset, 0), 2500*time.Millisecond, 3*time.Second)
+}
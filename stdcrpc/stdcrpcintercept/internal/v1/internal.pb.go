@@ -10,6 +10,8 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -21,6 +23,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ListRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Names []string               `protobuf:"bytes,1,rep,name=names"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListRequest) GetNames() []string {
+	if x != nil {
+		return x.xxx_hidden_Names
+	}
+	return nil
+}
+
+func (x *ListRequest) SetNames(v []string) {
+	x.xxx_hidden_Names = v
+}
+
+type ListRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Names []string
+}
+
+func (b0 ListRequest_builder) Build() *ListRequest {
+	m0 := &ListRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Names = b.Names
+	return m0
+}
+
 type Greeting struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
@@ -32,7 +91,7 @@ type Greeting struct {
 
 func (x *Greeting) Reset() {
 	*x = Greeting{}
-	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[0]
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +103,7 @@ func (x *Greeting) String() string {
 func (*Greeting) ProtoMessage() {}
 
 func (x *Greeting) ProtoReflect() protoreflect.Message {
-	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[0]
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -99,26 +158,558 @@ func (b0 Greeting_builder) Build() *Greeting {
 	return m0
 }
 
+type GreetRequest struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name            *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Password        *string                `protobuf:"bytes,2,opt,name=password"`
+	xxx_hidden_Credentials     *Credentials           `protobuf:"bytes,3,opt,name=credentials"`
+	xxx_hidden_MoreCredentials *[]*Credentials        `protobuf:"bytes,4,rep,name=more_credentials,json=moreCredentials"`
+	xxx_hidden_Bio             *string                `protobuf:"bytes,5,opt,name=bio"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *GreetRequest) Reset() {
+	*x = GreetRequest{}
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GreetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GreetRequest) ProtoMessage() {}
+
+func (x *GreetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GreetRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GreetRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GreetRequest) GetCredentials() *Credentials {
+	if x != nil {
+		return x.xxx_hidden_Credentials
+	}
+	return nil
+}
+
+func (x *GreetRequest) GetMoreCredentials() []*Credentials {
+	if x != nil {
+		if x.xxx_hidden_MoreCredentials != nil {
+			return *x.xxx_hidden_MoreCredentials
+		}
+	}
+	return nil
+}
+
+func (x *GreetRequest) GetBio() string {
+	if x != nil {
+		if x.xxx_hidden_Bio != nil {
+			return *x.xxx_hidden_Bio
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GreetRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *GreetRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *GreetRequest) SetCredentials(v *Credentials) {
+	x.xxx_hidden_Credentials = v
+}
+
+func (x *GreetRequest) SetMoreCredentials(v []*Credentials) {
+	x.xxx_hidden_MoreCredentials = &v
+}
+
+func (x *GreetRequest) SetBio(v string) {
+	x.xxx_hidden_Bio = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *GreetRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GreetRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GreetRequest) HasCredentials() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Credentials != nil
+}
+
+func (x *GreetRequest) HasBio() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *GreetRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *GreetRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Password = nil
+}
+
+func (x *GreetRequest) ClearCredentials() {
+	x.xxx_hidden_Credentials = nil
+}
+
+func (x *GreetRequest) ClearBio() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Bio = nil
+}
+
+type GreetRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name            *string
+	Password        *string
+	Credentials     *Credentials
+	MoreCredentials []*Credentials
+	Bio             *string
+}
+
+func (b0 GreetRequest_builder) Build() *GreetRequest {
+	m0 := &GreetRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Password = b.Password
+	}
+	x.xxx_hidden_Credentials = b.Credentials
+	x.xxx_hidden_MoreCredentials = &b.MoreCredentials
+	if b.Bio != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Bio = b.Bio
+	}
+	return m0
+}
+
+type Credentials struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        *string                `protobuf:"bytes,1,opt,name=kind"`
+	xxx_hidden_Token       *string                `protobuf:"bytes,2,opt,name=token"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Credentials) GetKind() string {
+	if x != nil {
+		if x.xxx_hidden_Kind != nil {
+			return *x.xxx_hidden_Kind
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Credentials) GetToken() string {
+	if x != nil {
+		if x.xxx_hidden_Token != nil {
+			return *x.xxx_hidden_Token
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Credentials) SetKind(v string) {
+	x.xxx_hidden_Kind = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *Credentials) SetToken(v string) {
+	x.xxx_hidden_Token = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *Credentials) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Credentials) HasToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Credentials) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = nil
+}
+
+func (x *Credentials) ClearToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Token = nil
+}
+
+type Credentials_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind  *string
+	Token *string
+}
+
+func (b0 Credentials_builder) Build() *Credentials {
+	m0 := &Credentials{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kind = b.Kind
+	}
+	if b.Token != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Token = b.Token
+	}
+	return m0
+}
+
+type DeadlineRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadlineRequest) Reset() {
+	*x = DeadlineRequest{}
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadlineRequest) ProtoMessage() {}
+
+func (x *DeadlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DeadlineRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DeadlineRequest_builder) Build() *DeadlineRequest {
+	m0 := &DeadlineRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type DeadlineResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Remaining *durationpb.Duration   `protobuf:"bytes,1,opt,name=remaining"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeadlineResponse) Reset() {
+	*x = DeadlineResponse{}
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadlineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadlineResponse) ProtoMessage() {}
+
+func (x *DeadlineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeadlineResponse) GetRemaining() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Remaining
+	}
+	return nil
+}
+
+func (x *DeadlineResponse) SetRemaining(v *durationpb.Duration) {
+	x.xxx_hidden_Remaining = v
+}
+
+func (x *DeadlineResponse) HasRemaining() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Remaining != nil
+}
+
+func (x *DeadlineResponse) ClearRemaining() {
+	x.xxx_hidden_Remaining = nil
+}
+
+type DeadlineResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// the time until the deadline of the handler's context, absent without a deadline.
+	Remaining *durationpb.Duration
+}
+
+func (b0 DeadlineResponse_builder) Build() *DeadlineResponse {
+	m0 := &DeadlineResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Remaining = b.Remaining
+	return m0
+}
+
+var file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50200,
+		Name:          "stdcrpc.stdcrpcintercept.internal.v1.skip_response_validation",
+		Tag:           "varint,50200,opt,name=skip_response_validation",
+		Filename:      "stdcrpc/stdcrpcintercept/internal/v1/internal.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50201,
+		Name:          "stdcrpc.stdcrpcintercept.internal.v1.log_payloads",
+		Tag:           "varint,50201,opt,name=log_payloads",
+		Filename:      "stdcrpc/stdcrpcintercept/internal/v1/internal.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*durationpb.Duration)(nil),
+		Field:         50203,
+		Name:          "stdcrpc.stdcrpcintercept.internal.v1.default_timeout",
+		Tag:           "bytes,50203,opt,name=default_timeout",
+		Filename:      "stdcrpc/stdcrpcintercept/internal/v1/internal.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*durationpb.Duration)(nil),
+		Field:         50204,
+		Name:          "stdcrpc.stdcrpcintercept.internal.v1.max_timeout",
+		Tag:           "bytes,50204,opt,name=max_timeout",
+		Filename:      "stdcrpc/stdcrpcintercept/internal/v1/internal.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50202,
+		Name:          "stdcrpc.stdcrpcintercept.internal.v1.sensitive",
+		Tag:           "varint,50202,opt,name=sensitive",
+		Filename:      "stdcrpc/stdcrpcintercept/internal/v1/internal.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional bool skip_response_validation = 50200;
+	E_SkipResponseValidation = &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes[0]
+	// optional bool log_payloads = 50201;
+	E_LogPayloads = &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes[1]
+	// optional google.protobuf.Duration default_timeout = 50203;
+	E_DefaultTimeout = &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes[2]
+	// optional google.protobuf.Duration max_timeout = 50204;
+	E_MaxTimeout = &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes[3]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional bool sensitive = 50202;
+	E_Sensitive = &file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes[4]
+)
+
 var File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto protoreflect.FileDescriptor
 
 const file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_rawDesc = "" +
 	"\n" +
-	"3stdcrpc/stdcrpcintercept/internal/v1/internal.proto\x12$stdcrpc.stdcrpcintercept.internal.v1\x1a\x1bbuf/validate/validate.proto\"*\n" +
+	"3stdcrpc/stdcrpcintercept/internal/v1/internal.proto\x12$stdcrpc.stdcrpcintercept.internal.v1\x1a\x1bbuf/validate/validate.proto\x1a google/protobuf/descriptor.proto\x1a\x1egoogle/protobuf/duration.proto\"#\n" +
+	"\vListRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"*\n" +
 	"\bGreeting\x12\x1e\n" +
 	"\x04name\x18\x01 \x01(\tB\n" +
-	"\xbaH\a\xc8\x01\x01r\x02\x10\x03R\x04nameB\xb4\x02\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x10\x03R\x04name\"\x88\x02\n" +
+	"\fGreetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\bpassword\x18\x02 \x01(\tB\x03\x80\x01\x01R\bpassword\x12S\n" +
+	"\vcredentials\x18\x03 \x01(\v21.stdcrpc.stdcrpcintercept.internal.v1.CredentialsR\vcredentials\x12\\\n" +
+	"\x10more_credentials\x18\x04 \x03(\v21.stdcrpc.stdcrpcintercept.internal.v1.CredentialsR\x0fmoreCredentials\x12\x10\n" +
+	"\x03bio\x18\x05 \x01(\tR\x03bio\"=\n" +
+	"\vCredentials\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\x05token\x18\x02 \x01(\tB\x04\xd0\xc1\x18\x01R\x05token\"\x11\n" +
+	"\x0fDeadlineRequest\"K\n" +
+	"\x10DeadlineResponse\x127\n" +
+	"\tremaining\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tremaining2\xf8\x01\n" +
+	"\vTestService\x12m\n" +
+	"\x04List\x121.stdcrpc.stdcrpcintercept.internal.v1.ListRequest\x1a..stdcrpc.stdcrpcintercept.internal.v1.Greeting\"\x000\x01\x12z\n" +
+	"\rListUnchecked\x121.stdcrpc.stdcrpcintercept.internal.v1.ListRequest\x1a..stdcrpc.stdcrpcintercept.internal.v1.Greeting\"\x04\xc0\xc1\x18\x010\x012\xf5\x01\n" +
+	"\n" +
+	"LogService\x12q\n" +
+	"\x05Greet\x122.stdcrpc.stdcrpcintercept.internal.v1.GreetRequest\x1a..stdcrpc.stdcrpcintercept.internal.v1.Greeting\"\x04\xc8\xc1\x18\x01\x12t\n" +
+	"\fGreetQuietly\x122.stdcrpc.stdcrpcintercept.internal.v1.GreetRequest\x1a..stdcrpc.stdcrpcintercept.internal.v1.Greeting\"\x002\xaa\x03\n" +
+	"\x0fDeadlineService\x12\x87\x01\n" +
+	"\bDeadline\x125.stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest\x1a6.stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse\"\f\xda\xc1\x18\x02\b\x01\xe2\xc1\x18\x02\b\x02\x12\x80\x01\n" +
+	"\rDeadlineUnset\x125.stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest\x1a6.stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse\"\x00\x12\x89\x01\n" +
+	"\x0eDeadlineStream\x125.stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest\x1a6.stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse\"\x06\xe2\xc1\x18\x02\b\x020\x01:Z\n" +
+	"\x18skip_response_validation\x12\x1e.google.protobuf.MethodOptions\x18\x98\x88\x03 \x01(\bR\x16skipResponseValidation:C\n" +
+	"\flog_payloads\x12\x1e.google.protobuf.MethodOptions\x18\x99\x88\x03 \x01(\bR\vlogPayloads:d\n" +
+	"\x0fdefault_timeout\x12\x1e.google.protobuf.MethodOptions\x18\x9b\x88\x03 \x01(\v2\x19.google.protobuf.DurationR\x0edefaultTimeout:\\\n" +
+	"\vmax_timeout\x12\x1e.google.protobuf.MethodOptions\x18\x9c\x88\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"maxTimeout:=\n" +
+	"\tsensitive\x12\x1d.google.protobuf.FieldOptions\x18\x9a\x88\x03 \x01(\bR\tsensitiveB\xb4\x02\n" +
 	"(com.stdcrpc.stdcrpcintercept.internal.v1B\rInternalProtoP\x01ZFgithub.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1;internalv1\xa2\x02\x03SSI\xaa\x02$Stdcrpc.Stdcrpcintercept.Internal.V1\xca\x02$Stdcrpc\\Stdcrpcintercept\\Internal\\V1\xe2\x020Stdcrpc\\Stdcrpcintercept\\Internal\\V1\\GPBMetadata\xea\x02'Stdcrpc::Stdcrpcintercept::Internal::V1b\beditionsp\xe8\a"
 
-var file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_goTypes = []any{
-	(*Greeting)(nil), // 0: stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	(*ListRequest)(nil),                // 0: stdcrpc.stdcrpcintercept.internal.v1.ListRequest
+	(*Greeting)(nil),                   // 1: stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	(*GreetRequest)(nil),               // 2: stdcrpc.stdcrpcintercept.internal.v1.GreetRequest
+	(*Credentials)(nil),                // 3: stdcrpc.stdcrpcintercept.internal.v1.Credentials
+	(*DeadlineRequest)(nil),            // 4: stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest
+	(*DeadlineResponse)(nil),           // 5: stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse
+	(*durationpb.Duration)(nil),        // 6: google.protobuf.Duration
+	(*descriptorpb.MethodOptions)(nil), // 7: google.protobuf.MethodOptions
+	(*descriptorpb.FieldOptions)(nil),  // 8: google.protobuf.FieldOptions
 }
 var file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: stdcrpc.stdcrpcintercept.internal.v1.GreetRequest.credentials:type_name -> stdcrpc.stdcrpcintercept.internal.v1.Credentials
+	3,  // 1: stdcrpc.stdcrpcintercept.internal.v1.GreetRequest.more_credentials:type_name -> stdcrpc.stdcrpcintercept.internal.v1.Credentials
+	6,  // 2: stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse.remaining:type_name -> google.protobuf.Duration
+	7,  // 3: stdcrpc.stdcrpcintercept.internal.v1.skip_response_validation:extendee -> google.protobuf.MethodOptions
+	7,  // 4: stdcrpc.stdcrpcintercept.internal.v1.log_payloads:extendee -> google.protobuf.MethodOptions
+	7,  // 5: stdcrpc.stdcrpcintercept.internal.v1.default_timeout:extendee -> google.protobuf.MethodOptions
+	7,  // 6: stdcrpc.stdcrpcintercept.internal.v1.max_timeout:extendee -> google.protobuf.MethodOptions
+	8,  // 7: stdcrpc.stdcrpcintercept.internal.v1.sensitive:extendee -> google.protobuf.FieldOptions
+	6,  // 8: stdcrpc.stdcrpcintercept.internal.v1.default_timeout:type_name -> google.protobuf.Duration
+	6,  // 9: stdcrpc.stdcrpcintercept.internal.v1.max_timeout:type_name -> google.protobuf.Duration
+	0,  // 10: stdcrpc.stdcrpcintercept.internal.v1.TestService.List:input_type -> stdcrpc.stdcrpcintercept.internal.v1.ListRequest
+	0,  // 11: stdcrpc.stdcrpcintercept.internal.v1.TestService.ListUnchecked:input_type -> stdcrpc.stdcrpcintercept.internal.v1.ListRequest
+	2,  // 12: stdcrpc.stdcrpcintercept.internal.v1.LogService.Greet:input_type -> stdcrpc.stdcrpcintercept.internal.v1.GreetRequest
+	2,  // 13: stdcrpc.stdcrpcintercept.internal.v1.LogService.GreetQuietly:input_type -> stdcrpc.stdcrpcintercept.internal.v1.GreetRequest
+	4,  // 14: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.Deadline:input_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest
+	4,  // 15: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineUnset:input_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest
+	4,  // 16: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineStream:input_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineRequest
+	1,  // 17: stdcrpc.stdcrpcintercept.internal.v1.TestService.List:output_type -> stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	1,  // 18: stdcrpc.stdcrpcintercept.internal.v1.TestService.ListUnchecked:output_type -> stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	1,  // 19: stdcrpc.stdcrpcintercept.internal.v1.LogService.Greet:output_type -> stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	1,  // 20: stdcrpc.stdcrpcintercept.internal.v1.LogService.GreetQuietly:output_type -> stdcrpc.stdcrpcintercept.internal.v1.Greeting
+	5,  // 21: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.Deadline:output_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse
+	5,  // 22: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineUnset:output_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse
+	5,  // 23: stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineStream:output_type -> stdcrpc.stdcrpcintercept.internal.v1.DeadlineResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	8,  // [8:10] is the sub-list for extension type_name
+	3,  // [3:8] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_init() }
@@ -132,13 +723,14 @@ func file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_rawDesc), len(file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
-			NumExtensions: 0,
-			NumServices:   0,
+			NumMessages:   6,
+			NumExtensions: 5,
+			NumServices:   3,
 		},
 		GoTypes:           file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_goTypes,
 		DependencyIndexes: file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_depIdxs,
 		MessageInfos:      file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_msgTypes,
+		ExtensionInfos:    file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_extTypes,
 	}.Build()
 	File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto = out.File
 	file_stdcrpc_stdcrpcintercept_internal_v1_internal_proto_goTypes = nil
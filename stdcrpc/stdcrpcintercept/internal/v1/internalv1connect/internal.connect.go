@@ -0,0 +1,377 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: stdcrpc/stdcrpcintercept/internal/v1/internal.proto
+
+package internalv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TestServiceName is the fully-qualified name of the TestService service.
+	TestServiceName = "stdcrpc.stdcrpcintercept.internal.v1.TestService"
+	// LogServiceName is the fully-qualified name of the LogService service.
+	LogServiceName = "stdcrpc.stdcrpcintercept.internal.v1.LogService"
+	// DeadlineServiceName is the fully-qualified name of the DeadlineService service.
+	DeadlineServiceName = "stdcrpc.stdcrpcintercept.internal.v1.DeadlineService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TestServiceListProcedure is the fully-qualified name of the TestService's List RPC.
+	TestServiceListProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.TestService/List"
+	// TestServiceListUncheckedProcedure is the fully-qualified name of the TestService's ListUnchecked
+	// RPC.
+	TestServiceListUncheckedProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.TestService/ListUnchecked"
+	// LogServiceGreetProcedure is the fully-qualified name of the LogService's Greet RPC.
+	LogServiceGreetProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.LogService/Greet"
+	// LogServiceGreetQuietlyProcedure is the fully-qualified name of the LogService's GreetQuietly RPC.
+	LogServiceGreetQuietlyProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.LogService/GreetQuietly"
+	// DeadlineServiceDeadlineProcedure is the fully-qualified name of the DeadlineService's Deadline
+	// RPC.
+	DeadlineServiceDeadlineProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.DeadlineService/Deadline"
+	// DeadlineServiceDeadlineUnsetProcedure is the fully-qualified name of the DeadlineService's
+	// DeadlineUnset RPC.
+	DeadlineServiceDeadlineUnsetProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.DeadlineService/DeadlineUnset"
+	// DeadlineServiceDeadlineStreamProcedure is the fully-qualified name of the DeadlineService's
+	// DeadlineStream RPC.
+	DeadlineServiceDeadlineStreamProcedure = "/stdcrpc.stdcrpcintercept.internal.v1.DeadlineService/DeadlineStream"
+)
+
+// TestServiceClient is a client for the stdcrpc.stdcrpcintercept.internal.v1.TestService service.
+type TestServiceClient interface {
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.Greeting], error)
+	ListUnchecked(context.Context, *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.Greeting], error)
+}
+
+// NewTestServiceClient constructs a client for the stdcrpc.stdcrpcintercept.internal.v1.TestService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTestServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	testServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("TestService").Methods()
+	return &testServiceClient{
+		list: connect.NewClient[v1.ListRequest, v1.Greeting](
+			httpClient,
+			baseURL+TestServiceListProcedure,
+			connect.WithSchema(testServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		listUnchecked: connect.NewClient[v1.ListRequest, v1.Greeting](
+			httpClient,
+			baseURL+TestServiceListUncheckedProcedure,
+			connect.WithSchema(testServiceMethods.ByName("ListUnchecked")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// testServiceClient implements TestServiceClient.
+type testServiceClient struct {
+	list          *connect.Client[v1.ListRequest, v1.Greeting]
+	listUnchecked *connect.Client[v1.ListRequest, v1.Greeting]
+}
+
+// List calls stdcrpc.stdcrpcintercept.internal.v1.TestService.List.
+func (c *testServiceClient) List(ctx context.Context, req *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.Greeting], error) {
+	return c.list.CallServerStream(ctx, req)
+}
+
+// ListUnchecked calls stdcrpc.stdcrpcintercept.internal.v1.TestService.ListUnchecked.
+func (c *testServiceClient) ListUnchecked(ctx context.Context, req *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.Greeting], error) {
+	return c.listUnchecked.CallServerStream(ctx, req)
+}
+
+// TestServiceHandler is an implementation of the stdcrpc.stdcrpcintercept.internal.v1.TestService
+// service.
+type TestServiceHandler interface {
+	List(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.Greeting]) error
+	ListUnchecked(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.Greeting]) error
+}
+
+// NewTestServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTestServiceHandler(svc TestServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	testServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("TestService").Methods()
+	testServiceListHandler := connect.NewServerStreamHandler(
+		TestServiceListProcedure,
+		svc.List,
+		connect.WithSchema(testServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServiceListUncheckedHandler := connect.NewServerStreamHandler(
+		TestServiceListUncheckedProcedure,
+		svc.ListUnchecked,
+		connect.WithSchema(testServiceMethods.ByName("ListUnchecked")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/stdcrpc.stdcrpcintercept.internal.v1.TestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TestServiceListProcedure:
+			testServiceListHandler.ServeHTTP(w, r)
+		case TestServiceListUncheckedProcedure:
+			testServiceListUncheckedHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTestServiceHandler struct{}
+
+func (UnimplementedTestServiceHandler) List(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.Greeting]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.TestService.List is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) ListUnchecked(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.Greeting]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.TestService.ListUnchecked is not implemented"))
+}
+
+// LogServiceClient is a client for the stdcrpc.stdcrpcintercept.internal.v1.LogService service.
+type LogServiceClient interface {
+	Greet(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error)
+	GreetQuietly(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error)
+}
+
+// NewLogServiceClient constructs a client for the stdcrpc.stdcrpcintercept.internal.v1.LogService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewLogServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) LogServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	logServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("LogService").Methods()
+	return &logServiceClient{
+		greet: connect.NewClient[v1.GreetRequest, v1.Greeting](
+			httpClient,
+			baseURL+LogServiceGreetProcedure,
+			connect.WithSchema(logServiceMethods.ByName("Greet")),
+			connect.WithClientOptions(opts...),
+		),
+		greetQuietly: connect.NewClient[v1.GreetRequest, v1.Greeting](
+			httpClient,
+			baseURL+LogServiceGreetQuietlyProcedure,
+			connect.WithSchema(logServiceMethods.ByName("GreetQuietly")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// logServiceClient implements LogServiceClient.
+type logServiceClient struct {
+	greet        *connect.Client[v1.GreetRequest, v1.Greeting]
+	greetQuietly *connect.Client[v1.GreetRequest, v1.Greeting]
+}
+
+// Greet calls stdcrpc.stdcrpcintercept.internal.v1.LogService.Greet.
+func (c *logServiceClient) Greet(ctx context.Context, req *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error) {
+	return c.greet.CallUnary(ctx, req)
+}
+
+// GreetQuietly calls stdcrpc.stdcrpcintercept.internal.v1.LogService.GreetQuietly.
+func (c *logServiceClient) GreetQuietly(ctx context.Context, req *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error) {
+	return c.greetQuietly.CallUnary(ctx, req)
+}
+
+// LogServiceHandler is an implementation of the stdcrpc.stdcrpcintercept.internal.v1.LogService
+// service.
+type LogServiceHandler interface {
+	Greet(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error)
+	GreetQuietly(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error)
+}
+
+// NewLogServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewLogServiceHandler(svc LogServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	logServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("LogService").Methods()
+	logServiceGreetHandler := connect.NewUnaryHandler(
+		LogServiceGreetProcedure,
+		svc.Greet,
+		connect.WithSchema(logServiceMethods.ByName("Greet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	logServiceGreetQuietlyHandler := connect.NewUnaryHandler(
+		LogServiceGreetQuietlyProcedure,
+		svc.GreetQuietly,
+		connect.WithSchema(logServiceMethods.ByName("GreetQuietly")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/stdcrpc.stdcrpcintercept.internal.v1.LogService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case LogServiceGreetProcedure:
+			logServiceGreetHandler.ServeHTTP(w, r)
+		case LogServiceGreetQuietlyProcedure:
+			logServiceGreetQuietlyHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedLogServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedLogServiceHandler struct{}
+
+func (UnimplementedLogServiceHandler) Greet(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.LogService.Greet is not implemented"))
+}
+
+func (UnimplementedLogServiceHandler) GreetQuietly(context.Context, *connect.Request[v1.GreetRequest]) (*connect.Response[v1.Greeting], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.LogService.GreetQuietly is not implemented"))
+}
+
+// DeadlineServiceClient is a client for the stdcrpc.stdcrpcintercept.internal.v1.DeadlineService
+// service.
+type DeadlineServiceClient interface {
+	Deadline(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error)
+	DeadlineUnset(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error)
+	DeadlineStream(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.ServerStreamForClient[v1.DeadlineResponse], error)
+}
+
+// NewDeadlineServiceClient constructs a client for the
+// stdcrpc.stdcrpcintercept.internal.v1.DeadlineService service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDeadlineServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DeadlineServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	deadlineServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("DeadlineService").Methods()
+	return &deadlineServiceClient{
+		deadline: connect.NewClient[v1.DeadlineRequest, v1.DeadlineResponse](
+			httpClient,
+			baseURL+DeadlineServiceDeadlineProcedure,
+			connect.WithSchema(deadlineServiceMethods.ByName("Deadline")),
+			connect.WithClientOptions(opts...),
+		),
+		deadlineUnset: connect.NewClient[v1.DeadlineRequest, v1.DeadlineResponse](
+			httpClient,
+			baseURL+DeadlineServiceDeadlineUnsetProcedure,
+			connect.WithSchema(deadlineServiceMethods.ByName("DeadlineUnset")),
+			connect.WithClientOptions(opts...),
+		),
+		deadlineStream: connect.NewClient[v1.DeadlineRequest, v1.DeadlineResponse](
+			httpClient,
+			baseURL+DeadlineServiceDeadlineStreamProcedure,
+			connect.WithSchema(deadlineServiceMethods.ByName("DeadlineStream")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// deadlineServiceClient implements DeadlineServiceClient.
+type deadlineServiceClient struct {
+	deadline       *connect.Client[v1.DeadlineRequest, v1.DeadlineResponse]
+	deadlineUnset  *connect.Client[v1.DeadlineRequest, v1.DeadlineResponse]
+	deadlineStream *connect.Client[v1.DeadlineRequest, v1.DeadlineResponse]
+}
+
+// Deadline calls stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.Deadline.
+func (c *deadlineServiceClient) Deadline(ctx context.Context, req *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error) {
+	return c.deadline.CallUnary(ctx, req)
+}
+
+// DeadlineUnset calls stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineUnset.
+func (c *deadlineServiceClient) DeadlineUnset(ctx context.Context, req *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error) {
+	return c.deadlineUnset.CallUnary(ctx, req)
+}
+
+// DeadlineStream calls stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineStream.
+func (c *deadlineServiceClient) DeadlineStream(ctx context.Context, req *connect.Request[v1.DeadlineRequest]) (*connect.ServerStreamForClient[v1.DeadlineResponse], error) {
+	return c.deadlineStream.CallServerStream(ctx, req)
+}
+
+// DeadlineServiceHandler is an implementation of the
+// stdcrpc.stdcrpcintercept.internal.v1.DeadlineService service.
+type DeadlineServiceHandler interface {
+	Deadline(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error)
+	DeadlineUnset(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error)
+	DeadlineStream(context.Context, *connect.Request[v1.DeadlineRequest], *connect.ServerStream[v1.DeadlineResponse]) error
+}
+
+// NewDeadlineServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDeadlineServiceHandler(svc DeadlineServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	deadlineServiceMethods := v1.File_stdcrpc_stdcrpcintercept_internal_v1_internal_proto.Services().ByName("DeadlineService").Methods()
+	deadlineServiceDeadlineHandler := connect.NewUnaryHandler(
+		DeadlineServiceDeadlineProcedure,
+		svc.Deadline,
+		connect.WithSchema(deadlineServiceMethods.ByName("Deadline")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deadlineServiceDeadlineUnsetHandler := connect.NewUnaryHandler(
+		DeadlineServiceDeadlineUnsetProcedure,
+		svc.DeadlineUnset,
+		connect.WithSchema(deadlineServiceMethods.ByName("DeadlineUnset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deadlineServiceDeadlineStreamHandler := connect.NewServerStreamHandler(
+		DeadlineServiceDeadlineStreamProcedure,
+		svc.DeadlineStream,
+		connect.WithSchema(deadlineServiceMethods.ByName("DeadlineStream")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/stdcrpc.stdcrpcintercept.internal.v1.DeadlineService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DeadlineServiceDeadlineProcedure:
+			deadlineServiceDeadlineHandler.ServeHTTP(w, r)
+		case DeadlineServiceDeadlineUnsetProcedure:
+			deadlineServiceDeadlineUnsetHandler.ServeHTTP(w, r)
+		case DeadlineServiceDeadlineStreamProcedure:
+			deadlineServiceDeadlineStreamHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDeadlineServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDeadlineServiceHandler struct{}
+
+func (UnimplementedDeadlineServiceHandler) Deadline(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.Deadline is not implemented"))
+}
+
+func (UnimplementedDeadlineServiceHandler) DeadlineUnset(context.Context, *connect.Request[v1.DeadlineRequest]) (*connect.Response[v1.DeadlineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineUnset is not implemented"))
+}
+
+func (UnimplementedDeadlineServiceHandler) DeadlineStream(context.Context, *connect.Request[v1.DeadlineRequest], *connect.ServerStream[v1.DeadlineResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcintercept.internal.v1.DeadlineService.DeadlineStream is not implemented"))
+}
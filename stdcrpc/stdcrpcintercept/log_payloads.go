@@ -0,0 +1,286 @@
+package stdcrpcintercept
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// LogPayloadsOption configures the payload logging interceptor.
+type LogPayloadsOption func(*logPayloads)
+
+// WithPayloadLevel sets the level that payloads are logged at, by default debug.
+func WithPayloadLevel(lvl zapcore.Level) LogPayloadsOption {
+	return func(lp *logPayloads) { lp.level = lvl }
+}
+
+// WithMaxPayloadSize truncates rendered payloads to the number of bytes, by default 4 KiB. Zero or less disables
+// truncation.
+func WithMaxPayloadSize(size int) LogPayloadsOption {
+	return func(lp *logPayloads) { lp.maxSize = size }
+}
+
+// WithPayloadSampling only logs the fraction of the calls, e.g. 0.01 for one in a hundred. All messages of a
+// sampled stream are logged.
+func WithPayloadSampling(rate float64) LogPayloadsOption {
+	return func(lp *logPayloads) { lp.rate = rate }
+}
+
+// WithSensitiveExtension redacts the fields that have the boolean field option set to true, in addition to fields
+// with the standard debug_redact option. The extension is owned by the application's protobuf files, e.g.:
+//
+//	extend google.protobuf.FieldOptions { bool sensitive = 50202; }
+func WithSensitiveExtension(ext protoreflect.ExtensionType) LogPayloadsOption {
+	return func(lp *logPayloads) { lp.sensitiveExt = ext }
+}
+
+// WithLogPayloadsExtension only logs the payloads of procedures that have the boolean method option set to true,
+// instead of those of every procedure. E.g.:
+//
+//	extend google.protobuf.MethodOptions { bool log_payloads = 50201; }
+func WithLogPayloadsExtension(ext protoreflect.ExtensionType) LogPayloadsOption {
+	return func(lp *logPayloads) { lp.enableExt = ext }
+}
+
+// logPayloads implements [connect.Interceptor].
+type logPayloads struct {
+	logs         *zap.Logger
+	level        zapcore.Level
+	maxSize      int
+	rate         float64
+	sensitiveExt protoreflect.ExtensionType
+	enableExt    protoreflect.ExtensionType
+	enables      sync.Map
+}
+
+// NewLogPayloads creates a Connect interceptor that logs the request and response messages of calls, for handlers
+// and clients. Sensitive fields are redacted from the logged messages: fields with the debug_redact option, and
+// fields marked with the extension of [WithSensitiveExtension]. The paths of redacted fields are logged instead.
+func NewLogPayloads(logs *zap.Logger, opts ...LogPayloadsOption) connect.Interceptor {
+	lp := &logPayloads{logs: logs, level: zapcore.DebugLevel, maxSize: 4096, rate: 1}
+	for _, opt := range opts {
+		opt(lp)
+	}
+
+	return lp
+}
+
+func (lp *logPayloads) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !lp.enabled(req.Spec()) {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+
+		fields := append(lp.specFields(req.Spec()), lp.render("request", req.Any())...)
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		} else {
+			fields = append(fields, lp.render("response", resp.Any())...)
+		}
+
+		lp.logs.Log(lp.level, "rpc call", fields...)
+
+		return resp, err
+	}
+}
+
+func (lp *logPayloads) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		if !lp.enabled(spec) {
+			return conn
+		}
+
+		return &loggingClientConn{StreamingClientConn: conn, lp: lp}
+	}
+}
+
+func (lp *logPayloads) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !lp.enabled(conn.Spec()) {
+			return next(ctx, conn)
+		}
+
+		return next(ctx, &loggingHandlerConn{StreamingHandlerConn: conn, lp: lp})
+	}
+}
+
+// loggingClientConn logs every message that is sent and received by the client.
+type loggingClientConn struct {
+	connect.StreamingClientConn
+	lp *logPayloads
+}
+
+func (c *loggingClientConn) Send(msg any) error {
+	c.lp.logMessage(c.Spec(), "rpc message sent", msg)
+
+	return c.StreamingClientConn.Send(msg)
+}
+
+func (c *loggingClientConn) Receive(msg any) error {
+	if err := c.StreamingClientConn.Receive(msg); err != nil {
+		return err
+	}
+
+	c.lp.logMessage(c.Spec(), "rpc message received", msg)
+
+	return nil
+}
+
+// loggingHandlerConn logs every message that is sent and received by the handler.
+type loggingHandlerConn struct {
+	connect.StreamingHandlerConn
+	lp *logPayloads
+}
+
+func (c *loggingHandlerConn) Send(msg any) error {
+	c.lp.logMessage(c.Spec(), "rpc message sent", msg)
+
+	return c.StreamingHandlerConn.Send(msg)
+}
+
+func (c *loggingHandlerConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+
+	c.lp.logMessage(c.Spec(), "rpc message received", msg)
+
+	return nil
+}
+
+func (lp *logPayloads) logMessage(spec connect.Spec, logMsg string, msg any) {
+	lp.logs.Log(lp.level, logMsg, append(lp.specFields(spec), lp.render("message", msg)...)...)
+}
+
+func (lp *logPayloads) specFields(spec connect.Spec) []zap.Field {
+	return []zap.Field{zap.String("procedure", spec.Procedure), zap.Bool("client", spec.IsClient)}
+}
+
+// enabled decides whether the payloads of the call are logged.
+func (lp *logPayloads) enabled(spec connect.Spec) bool {
+	if !lp.logs.Core().Enabled(lp.level) {
+		return false
+	}
+
+	if lp.enableExt != nil && !hasMethodOption(&lp.enables, spec, lp.enableExt) {
+		return false
+	}
+
+	return lp.rate >= 1 || rand.Float64() < lp.rate //nolint:gosec
+}
+
+// render returns the log fields for the message: the redacted message in JSON, and the paths of the redacted
+// fields if there were any.
+func (lp *logPayloads) render(key string, msg any) []zap.Field {
+	pmsg, ok := msg.(proto.Message)
+	if !ok {
+		return []zap.Field{zap.String(key, fmt.Sprintf("%T", msg))}
+	}
+
+	pmsg = proto.Clone(pmsg)
+	redacted := lp.redact(pmsg.ProtoReflect(), "", nil)
+
+	data, err := protojson.Marshal(pmsg)
+	if err != nil {
+		return []zap.Field{zap.String(key, fmt.Sprintf("%T", msg)), zap.NamedError(key+"_error", err)}
+	}
+
+	// protojson output is deliberately unstable in its whitespace, compact it so payloads are easy to search for.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err == nil {
+		data = compacted.Bytes()
+	}
+
+	fields := make([]zap.Field, 0, 3) //nolint:mnd
+	if rendered := string(data); lp.maxSize > 0 && len(rendered) > lp.maxSize {
+		fields = append(fields,
+			zap.String(key, strings.ToValidUTF8(rendered[:lp.maxSize], "")),
+			zap.Int(key+"_size", len(rendered)),
+			zap.Bool(key+"_truncated", true))
+	} else {
+		fields = append(fields, zap.String(key, rendered))
+	}
+
+	if len(redacted) > 0 {
+		fields = append(fields, zap.Strings(key+"_redacted", redacted))
+	}
+
+	return fields
+}
+
+// redact clears the sensitive fields of the message and its sub-messages, and returns the paths of the cleared
+// fields. Elements of lists and maps share the path of the field.
+func (lp *logPayloads) redact(msg protoreflect.Message, prefix string, paths []string) []string {
+	var populated []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		populated = append(populated, fd)
+
+		return true
+	})
+
+	for _, fd := range populated {
+		path := prefix + string(fd.Name())
+		if lp.sensitive(fd) {
+			msg.Clear(fd)
+
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+
+			continue
+		}
+
+		switch val := msg.Get(fd); {
+		case fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind:
+			val.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				paths = lp.redact(v.Message(), path+".", paths)
+
+				return true
+			})
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			for i := range val.List().Len() {
+				paths = lp.redact(val.List().Get(i).Message(), path+".", paths)
+			}
+		case !fd.IsMap() && !fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			paths = lp.redact(val.Message(), path+".", paths)
+		}
+	}
+
+	return paths
+}
+
+// sensitive returns whether the field must be redacted.
+func (lp *logPayloads) sensitive(fd protoreflect.FieldDescriptor) bool {
+	opts, _ := fd.Options().(*descriptorpb.FieldOptions)
+	if opts == nil {
+		return false
+	}
+
+	if opts.GetDebugRedact() {
+		return true
+	}
+
+	if lp.sensitiveExt == nil || !proto.HasExtension(opts, lp.sensitiveExt) {
+		return false
+	}
+
+	sensitive, _ := proto.GetExtension(opts, lp.sensitiveExt).(bool)
+
+	return sensitive
+}
@@ -0,0 +1,187 @@
+package stdcrpcintercept_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept"
+	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1/internalv1connect"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/proto"
+)
+
+type logService struct{}
+
+func (logService) Greet(
+	_ context.Context, req *connect.Request[internalv1.GreetRequest],
+) (*connect.Response[internalv1.Greeting], error) {
+	if req.Msg.GetName() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no name"))
+	}
+
+	return connect.NewResponse(internalv1.Greeting_builder{Name: proto.String("hello " + req.Msg.GetName())}.Build()), nil
+}
+
+func (s logService) GreetQuietly(
+	ctx context.Context, req *connect.Request[internalv1.GreetRequest],
+) (*connect.Response[internalv1.Greeting], error) {
+	return s.Greet(ctx, req)
+}
+
+func setupLogging(
+	t *testing.T, lvl zapcore.Level, opts ...stdcrpcintercept.LogPayloadsOption,
+) (*observer.ObservedLogs, internalv1connect.LogServiceClient) {
+	t.Helper()
+
+	core, obs := observer.New(lvl)
+	cept := stdcrpcintercept.NewLogPayloads(zap.New(core), opts...)
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewLogServiceHandler(logService{}, connect.WithInterceptors(cept)))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return obs, internalv1connect.NewLogServiceClient(srv.Client(), srv.URL)
+}
+
+func greetRequest(name string) *connect.Request[internalv1.GreetRequest] {
+	return connect.NewRequest(internalv1.GreetRequest_builder{
+		Name:        proto.String(name),
+		Password:    proto.String("secret1"),
+		Credentials: internalv1.Credentials_builder{Kind: proto.String("pat"), Token: proto.String("secret2")}.Build(),
+		MoreCredentials: []*internalv1.Credentials{
+			internalv1.Credentials_builder{Kind: proto.String("jwt"), Token: proto.String("secret3")}.Build(),
+		},
+	}.Build())
+}
+
+func TestLogPayloads(t *testing.T) {
+	t.Parallel()
+
+	obs, client := setupLogging(t, zap.DebugLevel,
+		stdcrpcintercept.WithSensitiveExtension(internalv1.E_Sensitive),
+		stdcrpcintercept.WithLogPayloadsExtension(internalv1.E_LogPayloads))
+
+	_, err := client.Greet(t.Context(), greetRequest("alice"))
+	require.NoError(t, err)
+
+	_, err = client.Greet(t.Context(), greetRequest(""))
+	require.Error(t, err)
+
+	_, err = client.GreetQuietly(t.Context(), greetRequest("alice"))
+	require.NoError(t, err)
+
+	entries := obs.All()
+	require.Len(t, entries, 2, "only the procedure with the option is logged")
+	require.Equal(t, zap.DebugLevel, entries[0].Level)
+
+	fields := entries[0].ContextMap()
+	require.Equal(t, internalv1connect.LogServiceGreetProcedure, fields["procedure"])
+	require.JSONEq(t, `{"name":"alice","credentials":{"kind":"pat"},"moreCredentials":[{"kind":"jwt"}]}`,
+		fields["request"].(string))
+	require.ElementsMatch(t,
+		[]any{"password", "credentials.token", "more_credentials.token"}, fields["request_redacted"])
+	require.JSONEq(t, `{"name":"hello alice"}`, fields["response"].(string))
+
+	fields = entries[1].ContextMap()
+	require.Contains(t, fields["error"], "no name")
+	require.NotContains(t, fields, "response")
+
+	for _, entry := range entries {
+		for _, field := range entry.Context {
+			require.NotContains(t, field.String, "secret")
+		}
+	}
+}
+
+func TestLogPayloadsTruncate(t *testing.T) {
+	t.Parallel()
+
+	obs, client := setupLogging(t, zap.InfoLevel,
+		stdcrpcintercept.WithPayloadLevel(zap.InfoLevel), stdcrpcintercept.WithMaxPayloadSize(20))
+
+	req := greetRequest(strings.Repeat("é", 100))
+
+	_, err := client.Greet(t.Context(), req)
+	require.NoError(t, err)
+
+	fields := obs.All()[0].ContextMap()
+	rendered := fields["request"].(string)
+	require.LessOrEqual(t, len(rendered), 20)
+	require.True(t, utf8.ValidString(rendered), "multi-byte characters are not cut")
+	require.Equal(t, true, fields["request_truncated"])
+	require.Greater(t, fields["request_size"], int64(200))
+}
+
+func TestLogPayloadsDisabled(t *testing.T) {
+	t.Parallel()
+
+	// the level is not enabled.
+	obs, client := setupLogging(t, zap.InfoLevel)
+	_, err := client.Greet(t.Context(), greetRequest("alice"))
+	require.NoError(t, err)
+	require.Zero(t, obs.Len())
+
+	// none of the calls are sampled.
+	obs, client = setupLogging(t, zap.DebugLevel, stdcrpcintercept.WithPayloadSampling(0))
+	_, err = client.Greet(t.Context(), greetRequest("alice"))
+	require.NoError(t, err)
+	require.Zero(t, obs.Len())
+}
+
+func TestLogPayloadsStreaming(t *testing.T) {
+	t.Parallel()
+
+	core, obs := observer.New(zap.DebugLevel)
+	cept := stdcrpcintercept.NewLogPayloads(zap.New(core))
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(testService{}, connect.WithInterceptors(cept)))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	client := internalv1connect.NewTestServiceClient(srv.Client(), srv.URL, connect.WithInterceptors(cept))
+
+	stream, err := client.List(t.Context(),
+		connect.NewRequest(internalv1.ListRequest_builder{Names: []string{"foo", "bar"}}.Build()))
+	require.NoError(t, err)
+
+	names, err := receiveAll(t, stream)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo", "bar"}, names)
+
+	var handlerMsgs, clientMsgs []string
+	for _, entry := range obs.All() {
+		fields := entry.ContextMap()
+		if fields["client"] == true {
+			clientMsgs = append(clientMsgs, entry.Message+" "+fields["message"].(string))
+		} else {
+			handlerMsgs = append(handlerMsgs, entry.Message+" "+fields["message"].(string))
+		}
+	}
+
+	require.Equal(t, []string{
+		`rpc message received {"names":["foo","bar"]}`,
+		`rpc message sent {"name":"foo"}`,
+		`rpc message sent {"name":"bar"}`,
+	}, handlerMsgs)
+	require.Equal(t, []string{
+		`rpc message sent {"names":["foo","bar"]}`,
+		`rpc message received {"name":"foo"}`,
+		`rpc message received {"name":"bar"}`,
+	}, clientMsgs)
+}
@@ -5,34 +5,148 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// ValidateResponseOption configures the response validation interceptor.
+type ValidateResponseOption func(*validateResponse)
+
+// WithLogViolations logs invalid responses instead of failing the call with them. Streams continue, and unary
+// calls return the invalid response.
+func WithLogViolations(logs *zap.Logger) ValidateResponseOption {
+	return func(vr *validateResponse) { vr.logs = logs }
+}
+
+// WithSkipExtension skips validation for procedures that have the boolean method option set to true. The
+// extension is owned by the application's protobuf files, e.g.:
+//
+//	extend google.protobuf.MethodOptions { bool skip_response_validation = 50200; }
+func WithSkipExtension(ext protoreflect.ExtensionType) ValidateResponseOption {
+	return func(vr *validateResponse) { vr.skipExt = ext }
+}
+
+// validateResponse implements [connect.Interceptor].
+type validateResponse struct {
+	val     protovalidate.Validator
+	logs    *zap.Logger
+	skipExt protoreflect.ExtensionType
+	skips   sync.Map
+}
+
 // NewValidateResponse creates a Connect interceptor that validates responses. Useful for
-// test environments.
-func NewValidateResponse(val protovalidate.Validator) connect.UnaryInterceptorFunc {
-	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
-		return connect.UnaryFunc(func(
-			ctx context.Context,
-			req connect.AnyRequest,
-		) (connect.AnyResponse, error) {
-			if req.Spec().IsClient {
-				return next(ctx, req)
-			}
-
-			resp, err := next(ctx, req)
-			if err != nil {
-				return resp, err
-			}
-
-			return resp, validate(val, resp.Any())
-		})
-	}
-
-	return connect.UnaryInterceptorFunc(interceptor)
+// test environments. Unary responses and every message sent on a stream are validated. By
+// default an invalid response fails the call (or aborts the stream) with [connect.CodeInternal].
+func NewValidateResponse(val protovalidate.Validator, opts ...ValidateResponseOption) connect.Interceptor {
+	vr := &validateResponse{val: val}
+	for _, opt := range opts {
+		opt(vr)
+	}
+
+	return vr
+}
+
+func (vr *validateResponse) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient || vr.skip(req.Spec()) {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		return resp, vr.check(req.Spec(), resp.Any())
+	}
+}
+
+func (vr *validateResponse) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (vr *validateResponse) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if vr.skip(conn.Spec()) {
+			return next(ctx, conn)
+		}
+
+		vconn := &validatingConn{StreamingHandlerConn: conn, vr: vr}
+		if err := next(ctx, vconn); err != nil {
+			return err
+		}
+
+		// the handler may have ignored the error that Send returned, the stream must still fail.
+		return vconn.err
+	}
+}
+
+// validatingConn validates every message before it is sent.
+type validatingConn struct {
+	connect.StreamingHandlerConn
+	vr  *validateResponse
+	err error
+}
+
+func (c *validatingConn) Send(msg any) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if err := c.vr.check(c.Spec(), msg); err != nil {
+		c.err = err
+
+		return err
+	}
+
+	return c.StreamingHandlerConn.Send(msg)
+}
+
+// check validates the message, and either returns the violation or logs it.
+func (vr *validateResponse) check(spec connect.Spec, msg any) error {
+	err := validate(vr.val, msg)
+	if err == nil || vr.logs == nil {
+		return err
+	}
+
+	vr.logs.Error("invalid response", zap.String("procedure", spec.Procedure), zap.Error(err))
+
+	return nil
+}
+
+// skip returns whether the procedure opted out of response validation.
+func (vr *validateResponse) skip(spec connect.Spec) bool {
+	if vr.skipExt == nil {
+		return false
+	}
+
+	return hasMethodOption(&vr.skips, spec, vr.skipExt)
+}
+
+// hasMethodOption returns whether the procedure has the boolean method option set to true. The result is cached
+// per procedure.
+func hasMethodOption(cache *sync.Map, spec connect.Spec, ext protoreflect.ExtensionType) bool {
+	if set, ok := cache.Load(spec.Procedure); ok {
+		return set.(bool) //nolint:forcetypeassert
+	}
+
+	set := false
+	if method, err := stdcrpc.MethodDescriptor(spec); err == nil {
+		opts := method.Options()
+		if opts != nil && proto.HasExtension(opts, ext) {
+			set, _ = proto.GetExtension(opts, ext).(bool)
+		}
+	}
+
+	cache.Store(spec.Procedure, set)
+
+	return set
 }
 
 // this is copied from: https://github.com/connectrpc/validate-go/blob/main/validate.go#L148
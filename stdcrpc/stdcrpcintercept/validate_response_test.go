@@ -2,13 +2,19 @@ package stdcrpcintercept_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
 	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept"
 	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcintercept/internal/v1/internalv1connect"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -26,3 +32,95 @@ func TestValidateResponse(t *testing.T) {
 	require.NotNil(t, resp)
 	require.ErrorContains(t, err, "response: validation error")
 }
+
+type testService struct{ ignoreSendErr bool }
+
+func (s testService) List(
+	_ context.Context, req *connect.Request[internalv1.ListRequest], stream *connect.ServerStream[internalv1.Greeting],
+) error {
+	for _, name := range req.Msg.GetNames() {
+		if err := stream.Send(internalv1.Greeting_builder{Name: proto.String(name)}.Build()); err != nil &&
+			!s.ignoreSendErr {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s testService) ListUnchecked(
+	ctx context.Context, req *connect.Request[internalv1.ListRequest], stream *connect.ServerStream[internalv1.Greeting],
+) error {
+	return s.List(ctx, req, stream)
+}
+
+func setupStreaming(
+	t *testing.T, svc testService, opts ...stdcrpcintercept.ValidateResponseOption,
+) internalv1connect.TestServiceClient {
+	t.Helper()
+
+	val, err := protovalidate.New()
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(svc,
+		connect.WithInterceptors(stdcrpcintercept.NewValidateResponse(val, opts...))))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return internalv1connect.NewTestServiceClient(srv.Client(), srv.URL)
+}
+
+func receiveAll(t *testing.T, stream *connect.ServerStreamForClient[internalv1.Greeting]) ([]string, error) {
+	t.Helper()
+
+	var names []string
+	for stream.Receive() {
+		names = append(names, stream.Msg().GetName())
+	}
+
+	return names, stream.Err()
+}
+
+func TestValidateResponseStreaming(t *testing.T) {
+	listReq := connect.NewRequest(internalv1.ListRequest_builder{Names: []string{"foo", "x", "bar"}}.Build())
+
+	for _, svc := range []testService{{}, {ignoreSendErr: true}} {
+		client := setupStreaming(t, svc, stdcrpcintercept.WithSkipExtension(internalv1.E_SkipResponseValidation))
+
+		stream, err := client.List(t.Context(), listReq)
+		require.NoError(t, err)
+
+		names, err := receiveAll(t, stream)
+		require.Equal(t, []string{"foo"}, names, "the stream is aborted at the invalid message")
+		require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+		require.ErrorContains(t, err, "response: validation error")
+
+		stream, err = client.ListUnchecked(t.Context(), listReq)
+		require.NoError(t, err)
+
+		names, err = receiveAll(t, stream)
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo", "x", "bar"}, names, "the procedure opted out")
+	}
+}
+
+func TestValidateResponseLogViolations(t *testing.T) {
+	core, obs := observer.New(zap.InfoLevel)
+	client := setupStreaming(t, testService{}, stdcrpcintercept.WithLogViolations(zap.New(core)))
+
+	stream, err := client.List(t.Context(),
+		connect.NewRequest(internalv1.ListRequest_builder{Names: []string{"foo", "x", "bar"}}.Build()))
+	require.NoError(t, err)
+
+	names, err := receiveAll(t, stream)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo", "x", "bar"}, names)
+
+	entries := obs.FilterMessage("invalid response").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, internalv1connect.TestServiceListProcedure, entries[0].ContextMap()["procedure"])
+}
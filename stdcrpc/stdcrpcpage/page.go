@@ -0,0 +1,238 @@
+// Package stdcrpcpage implements keyset pagination for list procedures. The position after the last item of a page
+// is encoded in an opaque page token that is signed, so clients can't forge or alter it. Items are ordered on their
+// TypeID, or on a timestamp with the TypeID as a tie-breaker, so the order is stable while items are added.
+package stdcrpcpage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdent/stdenttypeid"
+)
+
+var (
+	// ErrInvalidPageToken is returned for page tokens that were altered, or that were issued for another list.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrExpiredPageToken is returned for page tokens that are older than the configured time-to-live.
+	ErrExpiredPageToken = errors.New("page token expired")
+	// ErrInvalidPageSize is returned for negative page sizes.
+	ErrInvalidPageSize = errors.New("page size must not be negative")
+)
+
+// Ordering is the stable order of the items in a list.
+type Ordering struct {
+	timeColumn string
+	idColumn   string
+	desc       bool
+}
+
+// ByID orders on the TypeID column. TypeIDs are generated in time order, so this is the order of creation.
+func ByID(idColumn string) Ordering { return Ordering{idColumn: idColumn} }
+
+// ByTimeID orders on the timestamp column, and on the TypeID column for items with the same timestamp.
+func ByTimeID(timeColumn, idColumn string) Ordering {
+	return Ordering{timeColumn: timeColumn, idColumn: idColumn}
+}
+
+// Descending returns the ordering in descending order, e.g. for newest first.
+func (o Ordering) Descending() Ordering { o.desc = true; return o }
+
+func (o Ordering) direction() string {
+	if o.desc {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+func (o Ordering) columns() []string {
+	if o.timeColumn == "" {
+		return []string{o.idColumn}
+	}
+
+	return []string{o.timeColumn, o.idColumn}
+}
+
+// Cursor is the position of an item in the ordering.
+type Cursor struct {
+	// Time of the item, only for [ByTimeID] orderings.
+	Time time.Time
+	// ID of the item.
+	ID stdenttypeid.ID
+}
+
+// Option configures the [Paginator].
+type Option func(*Paginator)
+
+// WithPageSize sets the page size that is used when a request doesn't ask for one, and the maximum. Larger sizes
+// are lowered to the maximum.
+func WithPageSize(defaultSize, maxSize int) Option {
+	return func(p *Paginator) { p.defaultSize, p.maxSize = defaultSize, maxSize }
+}
+
+// WithTokenTTL sets how long page tokens are accepted after they were issued.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(p *Paginator) { p.ttl = ttl }
+}
+
+// Paginator reads the page of a request and issues the token of the next page.
+type Paginator struct {
+	key         []byte
+	defaultSize int
+	maxSize     int
+	ttl         time.Duration
+}
+
+// New inits the paginator that signs page tokens with the key, by default pages have 50 items (1000 at most) and
+// tokens are accepted for a day.
+func New(key []byte, opts ...Option) *Paginator {
+	if len(key) < sha256.Size {
+		panic("stdcrpcpage: signing key must be at least 32 bytes")
+	}
+
+	p := &Paginator{key: key, defaultSize: 50, maxSize: 1000, ttl: 24 * time.Hour}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Page is the page that a list request asks for.
+type Page struct {
+	// Size is the number of items on the page.
+	Size int
+	// After is the position after which the page starts, nil for the first page.
+	After *Cursor
+
+	order Ordering
+	scope string
+}
+
+// Limit is the number of items that the query must be limited to: one more than the page size, to find out
+// whether there is a next page.
+func (pg Page) Limit() int { return pg.Size + 1 }
+
+// Read returns the page for the token and size of a list request. The scope binds tokens to the list they were
+// issued for, e.g. the procedure and its filters, a token that was issued for another scope or ordering is
+// rejected. Errors are Connect errors with [connect.CodeInvalidArgument].
+func (p *Paginator) Read(order Ordering, scope, token string, size int32) (Page, error) {
+	pg := Page{Size: int(size), order: order, scope: scope}
+
+	switch {
+	case size < 0:
+		return pg, connect.NewError(connect.CodeInvalidArgument, ErrInvalidPageSize)
+	case size == 0:
+		pg.Size = p.defaultSize
+	case int(size) > p.maxSize:
+		pg.Size = p.maxSize
+	}
+
+	if token == "" {
+		return pg, nil
+	}
+
+	cur, err := p.decode(pg, token)
+	if err != nil {
+		return pg, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	pg.After = cur
+
+	return pg, nil
+}
+
+// Next trims the items of the query to the page, and returns the token of the next page. The token is empty when
+// this is the last page. The items must be queried in the order of the page and limited to [Page.Limit].
+func Next[T any](p *Paginator, pg Page, items []T, cursor func(T) Cursor) ([]T, string, error) {
+	if len(items) <= pg.Size {
+		return items, "", nil
+	}
+
+	items = items[:pg.Size]
+
+	token, err := p.encode(pg, cursor(items[len(items)-1]))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, token, nil
+}
+
+// tokenPayload is the signed content of a page token.
+type tokenPayload struct {
+	Scope   []byte `json:"s"`
+	Time    int64  `json:"t,omitempty"`
+	ID      string `json:"i"`
+	Expires int64  `json:"e"`
+}
+
+// scopeHash binds tokens to the scope and ordering without putting the (possibly long) scope in the token.
+func scopeHash(pg Page) []byte {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		pg.scope, pg.order.timeColumn, pg.order.idColumn, pg.order.direction(),
+	}, "\x00")))
+
+	return sum[:16]
+}
+
+func (p *Paginator) encode(pg Page, cur Cursor) (string, error) {
+	payload := tokenPayload{Scope: scopeHash(pg), ID: string(cur.ID), Expires: time.Now().Add(p.ttl).UnixMilli()}
+	if pg.order.timeColumn != "" {
+		payload.Time = cur.Time.UnixNano()
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(p.sign(data)), nil
+}
+
+func (p *Paginator) decode(pg Page, token string) (*Cursor, error) {
+	encData, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encData)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil || !hmac.Equal(sig, p.sign(data)) {
+		return nil, ErrInvalidPageToken
+	}
+
+	var payload tokenPayload
+	if err := json.Unmarshal(data, &payload); err != nil || !hmac.Equal(payload.Scope, scopeHash(pg)) {
+		return nil, ErrInvalidPageToken
+	}
+
+	if time.Now().UnixMilli() > payload.Expires {
+		return nil, ErrExpiredPageToken
+	}
+
+	cur := &Cursor{ID: stdenttypeid.ID(payload.ID)}
+	if pg.order.timeColumn != "" {
+		cur.Time = time.Unix(0, payload.Time).UTC()
+	}
+
+	return cur, nil
+}
+
+func (p *Paginator) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
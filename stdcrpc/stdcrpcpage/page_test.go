@@ -0,0 +1,139 @@
+package stdcrpcpage_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcpage"
+	"github.com/advdv/stdgo/stdent/stdenttypeid"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = bytes.Repeat([]byte{1}, 32)
+
+type item struct {
+	id        stdenttypeid.ID
+	createdAt time.Time
+}
+
+func cursorOf(it item) stdcrpcpage.Cursor { return stdcrpcpage.Cursor{Time: it.createdAt, ID: it.id} }
+
+func items(n int) []item {
+	out := make([]item, n)
+	for i := range out {
+		out[i] = item{
+			id:        stdenttypeid.ID("upld_01h455vb4pex5vvjndtv4bxrp" + string(rune('a'+i))),
+			createdAt: time.Date(2026, 1, 1, 0, 0, i, 123, time.UTC),
+		}
+	}
+
+	return out
+}
+
+func TestPageSize(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey, stdcrpcpage.WithPageSize(10, 100))
+	order := stdcrpcpage.ByID("id")
+
+	for size, exp := range map[int32]int{0: 10, 5: 5, 100: 100, 101: 100} {
+		pg, err := pgr.Read(order, "list", "", size)
+		require.NoError(t, err)
+		require.Equal(t, exp, pg.Size)
+		require.Equal(t, exp+1, pg.Limit())
+		require.Nil(t, pg.After)
+	}
+
+	_, err := pgr.Read(order, "list", "", -1)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorIs(t, err, stdcrpcpage.ErrInvalidPageSize)
+}
+
+func TestNextAndRead(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey)
+	order := stdcrpcpage.ByTimeID("created_at", "id").Descending()
+
+	pg, err := pgr.Read(order, "list", "", 2)
+	require.NoError(t, err)
+
+	page, token, err := stdcrpcpage.Next(pgr, pg, items(3), cursorOf)
+	require.NoError(t, err)
+	require.Equal(t, items(2), page)
+	require.NotEmpty(t, token)
+
+	pg, err = pgr.Read(order, "list", token, 2)
+	require.NoError(t, err)
+	require.Equal(t, &stdcrpcpage.Cursor{Time: items(2)[1].createdAt, ID: items(2)[1].id}, pg.After)
+
+	// the last page has no next page.
+	page, token, err = stdcrpcpage.Next(pgr, pg, items(2), cursorOf)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Empty(t, token)
+}
+
+func TestInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey)
+	order := stdcrpcpage.ByID("id")
+
+	pg, err := pgr.Read(order, "list?owner=a", "", 1)
+	require.NoError(t, err)
+
+	_, token, err := stdcrpcpage.Next(pgr, pg, items(2), cursorOf)
+	require.NoError(t, err)
+
+	data, sig, _ := strings.Cut(token, ".")
+
+	for name, tc := range map[string]struct {
+		pgr   *stdcrpcpage.Paginator
+		order stdcrpcpage.Ordering
+		scope string
+		token string
+	}{
+		"garbage":         {pgr, order, "list?owner=a", "foo"},
+		"altered payload": {pgr, order, "list?owner=a", data[:len(data)-2] + "x" + data[len(data)-1:] + "." + sig},
+		"altered sig":     {pgr, order, "list?owner=a", data + "." + sig[:len(sig)-1]},
+		"other key":       {stdcrpcpage.New(bytes.Repeat([]byte{2}, 32)), order, "list?owner=a", token},
+		"other scope":     {pgr, order, "list?owner=b", token},
+		"other ordering":  {pgr, order.Descending(), "list?owner=a", token},
+	} {
+		_, err := tc.pgr.Read(tc.order, tc.scope, tc.token, 1)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+		require.ErrorIs(t, err, stdcrpcpage.ErrInvalidPageToken, name)
+	}
+
+	_, err = pgr.Read(order, "list?owner=a", token, 1)
+	require.NoError(t, err)
+}
+
+func TestExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey, stdcrpcpage.WithTokenTTL(time.Millisecond))
+	order := stdcrpcpage.ByID("id")
+
+	pg, err := pgr.Read(order, "list", "", 1)
+	require.NoError(t, err)
+
+	_, token, err := stdcrpcpage.Next(pgr, pg, items(2), cursorOf)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = pgr.Read(order, "list", token, 1)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorIs(t, err, stdcrpcpage.ErrExpiredPageToken)
+}
+
+func TestShortKey(t *testing.T) {
+	t.Parallel()
+
+	require.Panics(t, func() { stdcrpcpage.New([]byte("short")) })
+}
@@ -0,0 +1,103 @@
+package stdcrpcpage
+
+import (
+	"strconv"
+	"strings"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/jackc/pgx/v5"
+)
+
+// cursorArgs returns the values of the cursor, in the order of the columns.
+func (pg Page) cursorArgs() []any {
+	if pg.order.timeColumn == "" {
+		return []any{pg.After.ID}
+	}
+
+	return []any{pg.After.Time, pg.After.ID}
+}
+
+// EntPredicate returns the predicate that skips the items up to the cursor, it can be passed to the Where method
+// of ent queries. Together with the order and limit:
+//
+//	items, err := client.Upload.Query().
+//		Where(pg.EntPredicate()).Order(pg.EntOrder()).Limit(pg.Limit()).All(ctx)
+func (pg Page) EntPredicate() func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		if pg.After == nil {
+			return
+		}
+
+		cols := pg.order.columns()
+		for i, col := range cols {
+			cols[i] = s.C(col)
+		}
+
+		if pg.order.desc {
+			s.Where(entsql.CompositeLT(cols, pg.cursorArgs()...))
+		} else {
+			s.Where(entsql.CompositeGT(cols, pg.cursorArgs()...))
+		}
+	}
+}
+
+// EntOrder returns the order of the page, it can be passed to the Order method of ent queries.
+func (pg Page) EntOrder() func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		for _, col := range pg.order.columns() {
+			if pg.order.desc {
+				s.OrderBy(entsql.Desc(s.C(col)))
+			} else {
+				s.OrderBy(entsql.Asc(s.C(col)))
+			}
+		}
+	}
+}
+
+// SQLWhere returns the condition that skips the items up to the cursor for raw SQL queries, e.g. with pgx in a
+// [stdtx] transaction, and the arguments for it. The placeholders are numbered from argNum. On the first page the
+// condition is TRUE. The TypeID is parsed with the Postgres typeid_parse function, like [stdenttypeid.ID] does:
+//
+//	cond, args := pg.SQLWhere(2)
+//	rows, err := tx.Query(ctx, "SELECT id, created_at FROM uploads WHERE owner = $1 AND "+cond+" "+
+//		pg.SQLOrderByLimit(), append([]any{owner}, args...)...)
+func (pg Page) SQLWhere(argNum int) (string, []any) {
+	if pg.After == nil {
+		return "TRUE", nil
+	}
+
+	var cols, params []string
+	for i, col := range pg.order.columns() {
+		cols = append(cols, quoteColumn(col))
+
+		param := "$" + strconv.Itoa(argNum+i)
+		if col == pg.order.idColumn {
+			param = "public.typeid_parse(" + param + ")"
+		}
+
+		params = append(params, param)
+	}
+
+	op := " > "
+	if pg.order.desc {
+		op = " < "
+	}
+
+	args := pg.cursorArgs()
+	args[len(args)-1] = string(pg.After.ID)
+
+	return "(" + strings.Join(cols, ", ") + ")" + op + "(" + strings.Join(params, ", ") + ")", args
+}
+
+// SQLOrderByLimit returns the ORDER BY and LIMIT clauses of the page for raw SQL queries.
+func (pg Page) SQLOrderByLimit() string {
+	var terms []string
+	for _, col := range pg.order.columns() {
+		terms = append(terms, quoteColumn(col)+" "+pg.order.direction())
+	}
+
+	return "ORDER BY " + strings.Join(terms, ", ") + " LIMIT " + strconv.Itoa(pg.Limit())
+}
+
+// quoteColumn quotes the (possibly table-qualified) column name.
+func quoteColumn(col string) string { return pgx.Identifier(strings.Split(col, ".")).Sanitize() }
@@ -0,0 +1,71 @@
+package stdcrpcpage_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcpage"
+	"github.com/stretchr/testify/require"
+)
+
+func entQuery(pg stdcrpcpage.Page) (string, []any) {
+	sel := entsql.Dialect(dialect.Postgres).Select("id").From(entsql.Table("uploads"))
+	pg.EntPredicate()(sel)
+	pg.EntOrder()(sel)
+
+	return sel.Limit(pg.Limit()).Query()
+}
+
+func TestEntQuery(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey)
+
+	pg, err := pgr.Read(stdcrpcpage.ByID("id"), "list", "", 2)
+	require.NoError(t, err)
+
+	query, args := entQuery(pg)
+	require.Equal(t, `SELECT "id" FROM "uploads" ORDER BY "uploads"."id" ASC LIMIT 3`, query)
+	require.Empty(t, args)
+
+	order := stdcrpcpage.ByTimeID("created_at", "id").Descending()
+	pg, err = pgr.Read(order, "list", "", 2)
+	require.NoError(t, err)
+
+	_, token, err := stdcrpcpage.Next(pgr, pg, items(3), cursorOf)
+	require.NoError(t, err)
+
+	pg, err = pgr.Read(order, "list", token, 2)
+	require.NoError(t, err)
+
+	query, args = entQuery(pg)
+	require.Equal(t, `SELECT "id" FROM "uploads" WHERE ("uploads"."created_at", "uploads"."id") < ($1, `+
+		`public.typeid_parse($2)) ORDER BY "uploads"."created_at" DESC, "uploads"."id" DESC LIMIT 3`, query)
+	require.Equal(t, []any{items(2)[1].createdAt, items(2)[1].id}, args)
+}
+
+func TestSQLQuery(t *testing.T) {
+	t.Parallel()
+
+	pgr := stdcrpcpage.New(testKey)
+	order := stdcrpcpage.ByTimeID("uploads.created_at", "id")
+
+	pg, err := pgr.Read(order, "list", "", 2)
+	require.NoError(t, err)
+
+	cond, args := pg.SQLWhere(2)
+	require.Equal(t, "TRUE", cond)
+	require.Empty(t, args)
+	require.Equal(t, `ORDER BY "uploads"."created_at" ASC, "id" ASC LIMIT 3`, pg.SQLOrderByLimit())
+
+	_, token, err := stdcrpcpage.Next(pgr, pg, items(3), cursorOf)
+	require.NoError(t, err)
+
+	pg, err = pgr.Read(order, "list", token, 2)
+	require.NoError(t, err)
+
+	cond, args = pg.SQLWhere(2)
+	require.Equal(t, `("uploads"."created_at", "id") > ($2, public.typeid_parse($3))`, cond)
+	require.Equal(t, []any{items(2)[1].createdAt, string(items(2)[1].id)}, args)
+}
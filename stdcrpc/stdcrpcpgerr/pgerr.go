@@ -0,0 +1,186 @@
+// Package stdcrpcpgerr maps Postgres errors that handlers return to Connect errors with a meaningful code. It works
+// with every stack that wraps the *pgconn.PgError it got from pgx, so both pgx and ent (over pgx's stdlib driver).
+//
+// Clients only get a generic message and, for constraint violations, the constraint name. Everything else the
+// database reported (the message, detail, table and column) is logged, it may reveal the schema or other tenants'
+// data.
+package stdcrpcpgerr
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// ErrorInfoDomain is the domain of the google.rpc.ErrorInfo detail that is added to mapped errors.
+const ErrorInfoDomain = "postgres"
+
+// MapFunc turns a Postgres error into the Connect error for the client.
+type MapFunc func(pgErr *pgconn.PgError) *connect.Error
+
+// Mapping holds a [MapFunc] per SQLSTATE code, or per SQLSTATE class (the first two characters of the code) for
+// a more general mapping. Exact codes take precedence over classes.
+type Mapping map[string]MapFunc
+
+// Code maps to an error with the code and message. The reason ends up in a google.rpc.ErrorInfo detail, together
+// with the name of the violated constraint (if any), so clients can tell errors with the same code apart.
+func Code(code connect.Code, message, reason string) MapFunc {
+	return func(pgErr *pgconn.PgError) *connect.Error {
+		cerr := connect.NewError(code, errors.New(message))
+
+		info := &errdetails.ErrorInfo{Reason: reason, Domain: ErrorInfoDomain}
+		if pgErr.ConstraintName != "" {
+			info.Metadata = map[string]string{"constraint": pgErr.ConstraintName}
+		}
+
+		if detail, err := connect.NewErrorDetail(info); err == nil {
+			cerr.AddDetail(detail)
+		}
+
+		return cerr
+	}
+}
+
+// DefaultMapping returns the mapping that is used when [New] is called without options. Errors that are not in the
+// mapping become [connect.CodeInternal].
+func DefaultMapping() Mapping {
+	return Mapping{
+		// class 23: integrity constraint violations.
+		"23505": Code(connect.CodeAlreadyExists, "resource already exists", "UNIQUE_VIOLATION"),
+		"23503": Code(connect.CodeFailedPrecondition,
+			"referenced resource does not exist, or resource is still referenced", "FOREIGN_KEY_VIOLATION"),
+		"23514": Code(connect.CodeInvalidArgument, "value violates a check constraint", "CHECK_VIOLATION"),
+		"23502": Code(connect.CodeInvalidArgument, "required value is missing", "NOT_NULL_VIOLATION"),
+		"23P01": Code(connect.CodeFailedPrecondition, "resource conflicts with another", "EXCLUSION_VIOLATION"),
+
+		// class 22: data exceptions, the input could not be stored.
+		"22": Code(connect.CodeInvalidArgument, "invalid value", "DATA_EXCEPTION"),
+
+		// timeouts and cancellation.
+		"57014": Code(connect.CodeDeadlineExceeded, "database query was canceled", "QUERY_CANCELED"),
+		"25P04": Code(connect.CodeDeadlineExceeded, "database transaction timed out", "TRANSACTION_TIMEOUT"),
+		"25P03": Code(connect.CodeDeadlineExceeded, "database transaction timed out",
+			"IDLE_IN_TRANSACTION_SESSION_TIMEOUT"),
+
+		// class 40: the transaction was rolled back, what remains once the transactor ran out of retries.
+		"40001": Code(connect.CodeAborted, "concurrent modification, try again", "SERIALIZATION_FAILURE"),
+		"40P01": Code(connect.CodeAborted, "concurrent modification, try again", "DEADLOCK_DETECTED"),
+		"55P03": Code(connect.CodeAborted, "resource is locked, try again", "LOCK_NOT_AVAILABLE"),
+
+		"42501": Code(connect.CodePermissionDenied, "database permission denied", "INSUFFICIENT_PRIVILEGE"),
+
+		// the database is (temporarily) unable to serve the request.
+		"08":    Code(connect.CodeUnavailable, "database is unavailable", "CONNECTION_EXCEPTION"),
+		"53":    Code(connect.CodeUnavailable, "database is unavailable", "INSUFFICIENT_RESOURCES"),
+		"57P01": Code(connect.CodeUnavailable, "database is unavailable", "ADMIN_SHUTDOWN"),
+		"57P03": Code(connect.CodeUnavailable, "database is unavailable", "CANNOT_CONNECT_NOW"),
+	}
+}
+
+// Option configures the [Mapper].
+type Option func(*Mapper)
+
+// WithMapping adds to, or overwrites, the default mapping. A nil MapFunc removes the code from the mapping.
+func WithMapping(mapping Mapping) Option {
+	return func(m *Mapper) {
+		for code, fn := range mapping {
+			if fn == nil {
+				delete(m.mapping, code)
+
+				continue
+			}
+
+			m.mapping[code] = fn
+		}
+	}
+}
+
+// Mapper maps Postgres errors to Connect errors.
+type Mapper struct {
+	mapping Mapping
+}
+
+// New inits the mapper with the [DefaultMapping] and options applied.
+func New(opts ...Option) *Mapper {
+	m := &Mapper{mapping: DefaultMapping()}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Map returns the Connect error for the error if it wraps a Postgres error, and logs what the database reported.
+// Other errors are returned as-is, and so are Connect errors with a code the handler chose deliberately.
+func (m *Mapper) Map(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if cerr := new(connect.Error); errors.As(err, &cerr) && cerr.Code() != connect.CodeUnknown {
+		return err
+	}
+
+	mapped := m.lookup(pgErr)
+
+	stdctx.MaybeLog(ctx).Info("mapped database error",
+		zap.String("code", mapped.Code().String()),
+		zap.String("sqlstate", pgErr.Code),
+		zap.String("constraint", pgErr.ConstraintName),
+		zap.String("table", pgErr.TableName),
+		zap.String("column", pgErr.ColumnName),
+		zap.String("detail", pgErr.Detail),
+		zap.Error(err))
+
+	return mapped
+}
+
+func (m *Mapper) lookup(pgErr *pgconn.PgError) *connect.Error {
+	if fn, ok := m.mapping[pgErr.Code]; ok {
+		return fn(pgErr)
+	}
+
+	if len(pgErr.Code) >= 2 {
+		if fn, ok := m.mapping[pgErr.Code[:2]]; ok {
+			return fn(pgErr)
+		}
+	}
+
+	return connect.NewError(connect.CodeInternal, errors.New("internal database error"))
+}
+
+// Interceptor maps the Postgres errors that handlers return, see [Mapper.Map].
+func (m *Mapper) Interceptor() connect.Interceptor { return interceptor{m} }
+
+type interceptor struct{ m *Mapper }
+
+func (i interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil && !req.Spec().IsClient {
+			return resp, i.m.Map(ctx, err)
+		}
+
+		return resp, err
+	}
+}
+
+func (interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := next(ctx, conn); err != nil {
+			return i.m.Map(ctx, err)
+		}
+
+		return nil
+	}
+}
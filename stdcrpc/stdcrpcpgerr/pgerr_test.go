@@ -0,0 +1,104 @@
+package stdcrpcpgerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcpgerr"
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMap(t *testing.T) {
+	mapper := stdcrpcpgerr.New()
+
+	for _, tt := range []struct {
+		err     error
+		expCode connect.Code
+		expInfo *errdetails.ErrorInfo
+	}{
+		{
+			fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}),
+			connect.CodeAlreadyExists,
+			&errdetails.ErrorInfo{
+				Reason: "UNIQUE_VIOLATION", Domain: "postgres", Metadata: map[string]string{"constraint": "users_email_key"},
+			},
+		},
+		{&pgconn.PgError{Code: "23503"}, connect.CodeFailedPrecondition, nil},
+		{&pgconn.PgError{Code: "23514", ConstraintName: "positive"}, connect.CodeInvalidArgument, nil},
+		{&pgconn.PgError{Code: "22P02"}, connect.CodeInvalidArgument, nil},
+		{&pgconn.PgError{Code: "57014"}, connect.CodeDeadlineExceeded, nil},
+		{&pgconn.PgError{Code: "25P04"}, connect.CodeDeadlineExceeded, nil},
+		{
+			retrypolicy.ExceededError{LastError: &pgconn.PgError{Code: "40001"}},
+			connect.CodeAborted,
+			&errdetails.ErrorInfo{Reason: "SERIALIZATION_FAILURE", Domain: "postgres"},
+		},
+		{&pgconn.PgError{Code: "08006"}, connect.CodeUnavailable, nil},
+		{&pgconn.PgError{Code: "XX000"}, connect.CodeInternal, nil},
+		{connect.NewError(connect.CodeUnknown, &pgconn.PgError{Code: "23505"}), connect.CodeAlreadyExists, nil},
+		{connect.NewError(connect.CodeNotFound, &pgconn.PgError{Code: "23505"}), connect.CodeNotFound, nil},
+		{errors.New("other"), connect.CodeUnknown, nil},
+	} {
+		err := mapper.Map(t.Context(), tt.err)
+		require.Equal(t, tt.expCode, connect.CodeOf(err), "%v", tt.err)
+
+		if tt.expInfo != nil {
+			var cerr *connect.Error
+			require.ErrorAs(t, err, &cerr)
+			require.Len(t, cerr.Details(), 1)
+
+			info, err := cerr.Details()[0].Value()
+			require.NoError(t, err)
+			require.Equal(t, tt.expInfo.GetReason(), info.(*errdetails.ErrorInfo).GetReason())
+			require.Equal(t, tt.expInfo.GetMetadata(), info.(*errdetails.ErrorInfo).GetMetadata())
+		}
+	}
+}
+
+func TestMapHidesInternalDetail(t *testing.T) {
+	core, obs := observer.New(zap.InfoLevel)
+	ctx := stdctx.WithLogger(t.Context(), zap.New(core))
+
+	err := stdcrpcpgerr.New().Map(ctx, &pgconn.PgError{
+		Code: "23505", Message: "duplicate key value", Detail: "Key (email)=(foo@example.com) already exists.",
+		TableName: "users", ConstraintName: "users_email_key",
+	})
+	require.NotContains(t, err.Error(), "foo@example.com")
+	require.NotContains(t, err.Error(), "users")
+
+	entries := obs.FilterMessage("mapped database error").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, "Key (email)=(foo@example.com) already exists.", entries[0].ContextMap()["detail"])
+	require.Equal(t, "users", entries[0].ContextMap()["table"])
+}
+
+func TestWithMapping(t *testing.T) {
+	mapper := stdcrpcpgerr.New(stdcrpcpgerr.WithMapping(stdcrpcpgerr.Mapping{
+		"23503": stdcrpcpgerr.Code(connect.CodeNotFound, "not found", "NOT_FOUND"),
+		"22":    nil,
+	}))
+
+	require.Equal(t, connect.CodeNotFound,
+		connect.CodeOf(mapper.Map(t.Context(), &pgconn.PgError{Code: "23503"})))
+	require.Equal(t, connect.CodeInternal,
+		connect.CodeOf(mapper.Map(t.Context(), &pgconn.PgError{Code: "22P02"})))
+}
+
+func TestInterceptor(t *testing.T) {
+	cept := stdcrpcpgerr.New().Interceptor()
+
+	_, err := cept.WrapUnary(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, &pgconn.PgError{Code: "23505"}
+	})(t.Context(), connect.NewRequest(wrapperspb.String("hello")))
+	require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+}
@@ -0,0 +1,153 @@
+package stdcrpcsnap
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Difference is a single difference between the expected and the actual JSON.
+type Difference struct {
+	// Path of the value in gjson syntax, so it can be passed to [PinResponseValue]. It is empty for the root.
+	Path string
+	// Expected is the JSON of the expected value, or empty if the value is missing in the expected JSON.
+	Expected string
+	// Actual is the JSON of the actual value, or empty if the value is missing in the actual JSON.
+	Actual string
+}
+
+// String formats the difference as a single line.
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "(root)"
+	}
+
+	switch {
+	case d.Expected == "":
+		return fmt.Sprintf("%s: unexpected %s", path, d.Actual)
+	case d.Actual == "":
+		return fmt.Sprintf("%s: missing, expected %s", path, d.Expected)
+	default:
+		return fmt.Sprintf("%s: expected %s, got %s", path, d.Expected, d.Actual)
+	}
+}
+
+// Diff returns the differences between two JSON documents, with objects compared key by key and arrays
+// element by element. Numbers are compared by value, like [require.JSONEq] does.
+func Diff(expJSON, actJSON []byte) ([]Difference, error) {
+	var exp, act any
+	if err := json.Unmarshal(expJSON, &exp); err != nil {
+		return nil, fmt.Errorf("unmarshal expected JSON: %w", err)
+	}
+
+	if err := json.Unmarshal(actJSON, &act); err != nil {
+		return nil, fmt.Errorf("unmarshal actual JSON: %w", err)
+	}
+
+	return diffValue(nil, "", exp, act), nil
+}
+
+func diffValue(diffs []Difference, path string, exp, act any) []Difference {
+	switch expv := exp.(type) {
+	case map[string]any:
+		actv, ok := act.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(expv)+len(actv))
+		for k := range expv {
+			keys = append(keys, k)
+		}
+
+		for k := range actv {
+			if _, ok := expv[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			diffs = diffMember(diffs, joinPath(path, escapePathKey(k)), expv, actv, k)
+		}
+
+		return diffs
+	case []any:
+		actv, ok := act.([]any)
+		if !ok {
+			break
+		}
+
+		for i := range max(len(expv), len(actv)) {
+			elemPath := joinPath(path, strconv.Itoa(i))
+
+			switch {
+			case i >= len(actv):
+				diffs = append(diffs, Difference{Path: elemPath, Expected: encodeValue(expv[i])})
+			case i >= len(expv):
+				diffs = append(diffs, Difference{Path: elemPath, Actual: encodeValue(actv[i])})
+			default:
+				diffs = diffValue(diffs, elemPath, expv[i], actv[i])
+			}
+		}
+
+		return diffs
+	}
+
+	if !reflect.DeepEqual(exp, act) {
+		diffs = append(diffs, Difference{Path: path, Expected: encodeValue(exp), Actual: encodeValue(act)})
+	}
+
+	return diffs
+}
+
+func diffMember(diffs []Difference, path string, exp, act map[string]any, key string) []Difference {
+	expv, inExp := exp[key]
+	actv, inAct := act[key]
+
+	switch {
+	case !inAct:
+		return append(diffs, Difference{Path: path, Expected: encodeValue(expv)})
+	case !inExp:
+		return append(diffs, Difference{Path: path, Actual: encodeValue(actv)})
+	default:
+		return diffValue(diffs, path, expv, actv)
+	}
+}
+
+func joinPath(path, elem string) string {
+	if path == "" {
+		return elem
+	}
+
+	return path + "." + elem
+}
+
+// escapePathKey escapes the characters that have a special meaning in gjson paths.
+func escapePathKey(k string) string {
+	var b strings.Builder
+
+	for _, r := range k {
+		if strings.ContainsRune(`.*?|#@\`, r) {
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func encodeValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
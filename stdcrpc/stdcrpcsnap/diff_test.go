@@ -0,0 +1,38 @@
+package stdcrpcsnap_test
+
+import (
+	"testing"
+
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcsnap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	for _, tt := range []struct {
+		exp, act string
+		diffs    []string
+	}{
+		{`{"a":1,"b":[1,2]}`, `{"b":[1,2.0],"a":1}`, nil},
+		{`{"a":1}`, `{"a":"1"}`, []string{`a: expected 1, got "1"`}},
+		{`{"a":1}`, `{"b":1}`, []string{`a: missing, expected 1`, `b: unexpected 1`}},
+		{
+			`{"items":[{"id":"x"},{"id":"y"}]}`, `{"items":[{"id":"x"},{"id":"z"},{}]}`,
+			[]string{`items.1.id: expected "y", got "z"`, `items.2: unexpected {}`},
+		},
+		{`{"a.b":{"c":null}}`, `{"a.b":{"c":[]}}`, []string{`a\.b.c: expected null, got []`}},
+		{`[]`, `{}`, []string{`(root): expected [], got {}`}},
+	} {
+		diffs, err := stdcrpcsnap.Diff([]byte(tt.exp), []byte(tt.act))
+		require.NoError(t, err)
+
+		var lines []string
+		for _, diff := range diffs {
+			lines = append(lines, diff.String())
+		}
+
+		require.Equal(t, tt.diffs, lines, "%s <> %s", tt.exp, tt.act)
+	}
+
+	_, err := stdcrpcsnap.Diff([]byte(`{`), []byte(`{}`))
+	require.ErrorContains(t, err, "unmarshal expected JSON")
+}
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: stdcrpc/stdcrpcsnap/internal/v1/snap.proto
+
+package internalv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/advdv/stdgo/stdcrpc/stdcrpcsnap/internal/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TestServiceName is the fully-qualified name of the TestService service.
+	TestServiceName = "stdcrpc.stdcrpcsnap.internal.v1.TestService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TestServiceListProcedure is the fully-qualified name of the TestService's List RPC.
+	TestServiceListProcedure = "/stdcrpc.stdcrpcsnap.internal.v1.TestService/List"
+	// TestServiceEchoProcedure is the fully-qualified name of the TestService's Echo RPC.
+	TestServiceEchoProcedure = "/stdcrpc.stdcrpcsnap.internal.v1.TestService/Echo"
+)
+
+// TestServiceClient is a client for the stdcrpc.stdcrpcsnap.internal.v1.TestService service.
+type TestServiceClient interface {
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.ListResponse], error)
+	Echo(context.Context) *connect.BidiStreamForClient[v1.EchoRequest, v1.EchoResponse]
+}
+
+// NewTestServiceClient constructs a client for the stdcrpc.stdcrpcsnap.internal.v1.TestService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTestServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	testServiceMethods := v1.File_stdcrpc_stdcrpcsnap_internal_v1_snap_proto.Services().ByName("TestService").Methods()
+	return &testServiceClient{
+		list: connect.NewClient[v1.ListRequest, v1.ListResponse](
+			httpClient,
+			baseURL+TestServiceListProcedure,
+			connect.WithSchema(testServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		echo: connect.NewClient[v1.EchoRequest, v1.EchoResponse](
+			httpClient,
+			baseURL+TestServiceEchoProcedure,
+			connect.WithSchema(testServiceMethods.ByName("Echo")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// testServiceClient implements TestServiceClient.
+type testServiceClient struct {
+	list *connect.Client[v1.ListRequest, v1.ListResponse]
+	echo *connect.Client[v1.EchoRequest, v1.EchoResponse]
+}
+
+// List calls stdcrpc.stdcrpcsnap.internal.v1.TestService.List.
+func (c *testServiceClient) List(ctx context.Context, req *connect.Request[v1.ListRequest]) (*connect.ServerStreamForClient[v1.ListResponse], error) {
+	return c.list.CallServerStream(ctx, req)
+}
+
+// Echo calls stdcrpc.stdcrpcsnap.internal.v1.TestService.Echo.
+func (c *testServiceClient) Echo(ctx context.Context) *connect.BidiStreamForClient[v1.EchoRequest, v1.EchoResponse] {
+	return c.echo.CallBidiStream(ctx)
+}
+
+// TestServiceHandler is an implementation of the stdcrpc.stdcrpcsnap.internal.v1.TestService
+// service.
+type TestServiceHandler interface {
+	List(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.ListResponse]) error
+	Echo(context.Context, *connect.BidiStream[v1.EchoRequest, v1.EchoResponse]) error
+}
+
+// NewTestServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTestServiceHandler(svc TestServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	testServiceMethods := v1.File_stdcrpc_stdcrpcsnap_internal_v1_snap_proto.Services().ByName("TestService").Methods()
+	testServiceListHandler := connect.NewServerStreamHandler(
+		TestServiceListProcedure,
+		svc.List,
+		connect.WithSchema(testServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	testServiceEchoHandler := connect.NewBidiStreamHandler(
+		TestServiceEchoProcedure,
+		svc.Echo,
+		connect.WithSchema(testServiceMethods.ByName("Echo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/stdcrpc.stdcrpcsnap.internal.v1.TestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TestServiceListProcedure:
+			testServiceListHandler.ServeHTTP(w, r)
+		case TestServiceEchoProcedure:
+			testServiceEchoHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTestServiceHandler struct{}
+
+func (UnimplementedTestServiceHandler) List(context.Context, *connect.Request[v1.ListRequest], *connect.ServerStream[v1.ListResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcsnap.internal.v1.TestService.List is not implemented"))
+}
+
+func (UnimplementedTestServiceHandler) Echo(context.Context, *connect.BidiStream[v1.EchoRequest, v1.EchoResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("stdcrpc.stdcrpcsnap.internal.v1.TestService.Echo is not implemented"))
+}
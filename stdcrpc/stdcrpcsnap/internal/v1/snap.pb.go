@@ -0,0 +1,453 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: stdcrpc/stdcrpcsnap/internal/v1/snap.proto
+
+package internalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int32                  `protobuf:"varint,1,opt,name=count"`
+	xxx_hidden_Fail        bool                   `protobuf:"varint,2,opt,name=fail"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListRequest) GetCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *ListRequest) GetFail() bool {
+	if x != nil {
+		return x.xxx_hidden_Fail
+	}
+	return false
+}
+
+func (x *ListRequest) SetCount(v int32) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ListRequest) SetFail(v bool) {
+	x.xxx_hidden_Fail = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListRequest) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListRequest) HasFail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListRequest) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Count = 0
+}
+
+func (x *ListRequest) ClearFail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Fail = false
+}
+
+type ListRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Count *int32
+	Fail  *bool
+}
+
+func (b0 ListRequest_builder) Build() *ListRequest {
+	m0 := &ListRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Count = *b.Count
+	}
+	if b.Fail != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Fail = *b.Fail
+	}
+	return m0
+}
+
+type ListResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Index       int32                  `protobuf:"varint,2,opt,name=index"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListResponse) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ListResponse) GetIndex() int32 {
+	if x != nil {
+		return x.xxx_hidden_Index
+	}
+	return 0
+}
+
+func (x *ListResponse) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ListResponse) SetIndex(v int32) {
+	x.xxx_hidden_Index = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListResponse) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListResponse) HasIndex() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListResponse) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *ListResponse) ClearIndex() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Index = 0
+}
+
+type ListResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id    *string
+	Index *int32
+}
+
+func (b0 ListResponse_builder) Build() *ListResponse {
+	m0 := &ListResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Index != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Index = *b.Index
+	}
+	return m0
+}
+
+type EchoRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Text        *string                `protobuf:"bytes,1,opt,name=text"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EchoRequest) Reset() {
+	*x = EchoRequest{}
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EchoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoRequest) ProtoMessage() {}
+
+func (x *EchoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EchoRequest) GetText() string {
+	if x != nil {
+		if x.xxx_hidden_Text != nil {
+			return *x.xxx_hidden_Text
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EchoRequest) SetText(v string) {
+	x.xxx_hidden_Text = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *EchoRequest) HasText() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *EchoRequest) ClearText() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Text = nil
+}
+
+type EchoRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Text *string
+}
+
+func (b0 EchoRequest_builder) Build() *EchoRequest {
+	m0 := &EchoRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Text != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Text = b.Text
+	}
+	return m0
+}
+
+type EchoResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Text        *string                `protobuf:"bytes,1,opt,name=text"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EchoResponse) Reset() {
+	*x = EchoResponse{}
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EchoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoResponse) ProtoMessage() {}
+
+func (x *EchoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EchoResponse) GetText() string {
+	if x != nil {
+		if x.xxx_hidden_Text != nil {
+			return *x.xxx_hidden_Text
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EchoResponse) SetText(v string) {
+	x.xxx_hidden_Text = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *EchoResponse) HasText() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *EchoResponse) ClearText() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Text = nil
+}
+
+type EchoResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Text *string
+}
+
+func (b0 EchoResponse_builder) Build() *EchoResponse {
+	m0 := &EchoResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Text != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Text = b.Text
+	}
+	return m0
+}
+
+var File_stdcrpc_stdcrpcsnap_internal_v1_snap_proto protoreflect.FileDescriptor
+
+const file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_rawDesc = "" +
+	"\n" +
+	"*stdcrpc/stdcrpcsnap/internal/v1/snap.proto\x12\x1fstdcrpc.stdcrpcsnap.internal.v1\"7\n" +
+	"\vListRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x12\n" +
+	"\x04fail\x18\x02 \x01(\bR\x04fail\"4\n" +
+	"\fListResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"!\n" +
+	"\vEchoRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\"\n" +
+	"\fEchoResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text2\xe1\x01\n" +
+	"\vTestService\x12g\n" +
+	"\x04List\x12,.stdcrpc.stdcrpcsnap.internal.v1.ListRequest\x1a-.stdcrpc.stdcrpcsnap.internal.v1.ListResponse\"\x000\x01\x12i\n" +
+	"\x04Echo\x12,.stdcrpc.stdcrpcsnap.internal.v1.EchoRequest\x1a-.stdcrpc.stdcrpcsnap.internal.v1.EchoResponse\"\x00(\x010\x01B\x92\x02\n" +
+	"#com.stdcrpc.stdcrpcsnap.internal.v1B\tSnapProtoP\x01ZAgithub.com/advdv/stdgo/stdcrpc/stdcrpcsnap/internal/v1;internalv1\xa2\x02\x03SSI\xaa\x02\x1fStdcrpc.Stdcrpcsnap.Internal.V1\xca\x02\x1fStdcrpc\\Stdcrpcsnap\\Internal\\V1\xe2\x02+Stdcrpc\\Stdcrpcsnap\\Internal\\V1\\GPBMetadata\xea\x02\"Stdcrpc::Stdcrpcsnap::Internal::V1b\beditionsp\xe8\a"
+
+var file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_goTypes = []any{
+	(*ListRequest)(nil),  // 0: stdcrpc.stdcrpcsnap.internal.v1.ListRequest
+	(*ListResponse)(nil), // 1: stdcrpc.stdcrpcsnap.internal.v1.ListResponse
+	(*EchoRequest)(nil),  // 2: stdcrpc.stdcrpcsnap.internal.v1.EchoRequest
+	(*EchoResponse)(nil), // 3: stdcrpc.stdcrpcsnap.internal.v1.EchoResponse
+}
+var file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_depIdxs = []int32{
+	0, // 0: stdcrpc.stdcrpcsnap.internal.v1.TestService.List:input_type -> stdcrpc.stdcrpcsnap.internal.v1.ListRequest
+	2, // 1: stdcrpc.stdcrpcsnap.internal.v1.TestService.Echo:input_type -> stdcrpc.stdcrpcsnap.internal.v1.EchoRequest
+	1, // 2: stdcrpc.stdcrpcsnap.internal.v1.TestService.List:output_type -> stdcrpc.stdcrpcsnap.internal.v1.ListResponse
+	3, // 3: stdcrpc.stdcrpcsnap.internal.v1.TestService.Echo:output_type -> stdcrpc.stdcrpcsnap.internal.v1.EchoResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_init() }
+func file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_init() {
+	if File_stdcrpc_stdcrpcsnap_internal_v1_snap_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_rawDesc), len(file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_goTypes,
+		DependencyIndexes: file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_depIdxs,
+		MessageInfos:      file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_msgTypes,
+	}.Build()
+	File_stdcrpc_stdcrpcsnap_internal_v1_snap_proto = out.File
+	file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_goTypes = nil
+	file_stdcrpc_stdcrpcsnap_internal_v1_snap_proto_depIdxs = nil
+}
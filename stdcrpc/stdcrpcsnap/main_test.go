@@ -0,0 +1,10 @@
+package stdcrpcsnap_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcsnap"
+)
+
+func TestMain(m *testing.M) { os.Exit(stdcrpcsnap.Main(m)) }
@@ -14,6 +14,9 @@ type Overwrite struct {
 	Path   string
 	Value  any
 	Assert OverwriteAssertFunc
+
+	// volatile is set for overwrites that mask volatile values instead, see [MaskVolatile].
+	volatile []VolatileKind
 }
 
 // OverwriteAssertFunc allows asserting overwitten values.
@@ -25,6 +28,12 @@ func ApplyOverwrite(tb require.TestingT, actMsg []byte, actOverwrites ...Overwri
 	var err error
 
 	for _, actOverwrite := range actOverwrites {
+		if actOverwrite.volatile != nil {
+			actMsg = maskVolatile(tb, actMsg, actOverwrite.volatile)
+
+			continue
+		}
+
 		actVal := gjson.GetBytes(actMsg, actOverwrite.Path)
 		// if actOverwrite.Assert != nil {
 		// 	if len(actVal.Paths(string(actMsg))) == 0 {
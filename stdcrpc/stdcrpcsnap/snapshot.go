@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"connectrpc.com/connect"
@@ -24,10 +25,10 @@ import (
 // value even though it wouldn't match the snapshot.
 func PinResponseValue(atPath string, toValue any, assertActual ...OverwriteAssertFunc) Overwrite {
 	if len(assertActual) > 0 {
-		return Overwrite{atPath, toValue, assertActual[0]}
+		return Overwrite{Path: atPath, Value: toValue, Assert: assertActual[0]}
 	}
 
-	return Overwrite{atPath, toValue, nil}
+	return Overwrite{Path: atPath, Value: toValue}
 }
 
 // MessageSnapshotEq compares the protobuf message against a snapshot. If the snapshot file doesn't exist it
@@ -64,31 +65,96 @@ func ResponseSnapshotEq[O any](
 	SnapshotEq(tb, actMsg, actOverwrites...)
 }
 
-// SnapshotEq compares the protobuf message against a snapshot. If the snapshot file doesn't exist it
-// is created instead. It allows overwriting parts of the message with static values while asserting
-// the replaced values precicely. This allows for part of the message to be dynanmic but still tightly
+// SnapshotEq compares the protobuf message against a snapshot in testdata/snapshots, named after the test. If the
+// snapshot file doesn't exist it is created instead. It allows overwriting parts of the message with static values
+// while asserting the replaced values precicely. This allows for part of the message to be dynanmic but still tightly
 // asserted.
+//
+// A mismatch is reported as a list of differences per path. The overwrites are applied to the snapshot as well, so
+// pinned values never show up as a difference. In update mode (see [Updating]) a snapshot that doesn't match is
+// rewritten instead.
 func SnapshotEq(tb testing.TB, actMsg []byte, actOverwrites ...Overwrite) {
 	actMsg = ApplyOverwrite(tb, actMsg, actOverwrites...)
 
-	expFilePath := filepath.Join("testdata", tb.Name()+".json")
+	expFilePath := filepath.Join(snapshotDir, tb.Name()+".json")
+	markUsed(expFilePath)
 
 	expMsg, err := os.ReadFile(expFilePath)
+	if os.IsNotExist(err) {
+		expMsg, err = readLegacySnapshot(tb, expFilePath)
+	}
+
 	if os.IsNotExist(err) {
 		// in case the expected message json is not found, we assume it is a new test case so we write
 		// the actual data into the file.
-		require.NoError(tb, os.MkdirAll(filepath.Dir(expFilePath), 0o777), "mkdir: %s", expFilePath)
-		require.NoError(tb, os.WriteFile(expFilePath, actMsg, 0o600), "write file: %s", expFilePath)
-		expMsg = actMsg
-
+		writeSnapshot(tb, expFilePath, actMsg)
 		tb.Logf("created snapshot for test %s since it didn't exist: %s", tb.Name(), expFilePath)
-	} else {
-		require.NoError(tb, err, "read file: %s", err)
+
+		return
+	}
+
+	require.NoError(tb, err, "read file: %s", err)
+
+	// the snapshot was written with the overwrites applied, but apply them again (without asserting)
+	// so changing a pinned value doesn't fail every snapshot that has it.
+	expMsg = ApplyOverwrite(tb, expMsg, withoutAsserts(actOverwrites)...)
+
+	diffs, err := Diff(expMsg, actMsg)
+	require.NoError(tb, err, "diff snapshot: %s", expFilePath)
+
+	if len(diffs) == 0 {
+		return
+	}
+
+	if Updating() {
+		writeSnapshot(tb, expFilePath, actMsg)
+		tb.Logf("updated snapshot for test %s: %s", tb.Name(), expFilePath)
+
+		return
+	}
+
+	var lines strings.Builder
+	for _, diff := range diffs {
+		lines.WriteString("\t" + diff.String() + "\n")
+	}
+
+	require.Failf(tb, "snapshot mismatch",
+		"%s (run with -stdcrpcsnap.update or %s=1 to update):\n%s\nactual JSON: %s",
+		expFilePath, UpdateEnvVar, lines.String(), stdlo.Must1(formatJSONData(actMsg)))
+}
+
+// readLegacySnapshot reads the snapshot from where it was written before snapshots got their own directory. In
+// update mode it is moved to the path of the snapshot.
+func readLegacySnapshot(tb testing.TB, path string) ([]byte, error) {
+	legacyPath := filepath.Join(legacySnapshotDir, tb.Name()+".json")
+
+	data, err := os.ReadFile(legacyPath)
+	if err != nil || !Updating() {
+		return data, err //nolint:wrapcheck
+	}
+
+	writeSnapshot(tb, path, data)
+	require.NoError(tb, os.Remove(legacyPath), "remove legacy snapshot: %s", legacyPath)
+	tb.Logf("moved snapshot for test %s: %s -> %s", tb.Name(), legacyPath, path)
+
+	return data, nil
+}
+
+// writeSnapshot writes the message as indented JSON, so snapshots are stable and readable in reviews.
+func writeSnapshot(tb testing.TB, path string, msg []byte) {
+	formatted, err := formatJSONData(msg)
+	require.NoError(tb, err, "format snapshot: %s", path)
+	require.NoError(tb, os.MkdirAll(filepath.Dir(path), 0o777), "mkdir: %s", path)
+	require.NoError(tb, os.WriteFile(path, []byte(formatted+"\n"), 0o600), "write file: %s", path)
+}
+
+func withoutAsserts(overwrites []Overwrite) []Overwrite {
+	stripped := make([]Overwrite, len(overwrites))
+	for i, ovr := range overwrites {
+		stripped[i] = Overwrite{Path: ovr.Path, Value: ovr.Value, volatile: ovr.volatile}
 	}
 
-	// finally, actually compare the json.
-	require.JSONEqf(tb, string(expMsg), string(actMsg), "snapshot mismatch, actual JSON: %s",
-		stdlo.Must1(formatJSONData(actMsg)))
+	return stripped
 }
 
 func formatJSONData(data []byte) (string, error) {
@@ -1,6 +1,9 @@
 package stdcrpcsnap_test
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -37,3 +40,76 @@ func TestSnapshotEqNoWildcards(t *testing.T) {
 		stdcrpcsnap.PinResponseValue("items.0.foo.created_at", "2025-03-28T09:03:02.742002+01:00",
 			stdcrpcsnap.AssertRFC3339Nano()))
 }
+
+// failTB records the failure message instead of failing the test.
+type failTB struct {
+	*testing.T
+	msg string
+}
+
+func (t *failTB) Errorf(format string, args ...any) { t.msg = fmt.Sprintf(format, args...) }
+
+func (t *failTB) FailNow() {}
+
+func TestSnapshotEqMismatch(t *testing.T) {
+	t.Setenv(stdcrpcsnap.UpdateEnvVar, "")
+	if stdcrpcsnap.Updating() {
+		t.Skip("mismatches are not reported in update mode")
+	}
+
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join("testdata", "snapshots"), 0o777))
+	require.NoError(t, os.WriteFile(filepath.Join("testdata", "snapshots", t.Name()+".json"),
+		[]byte(`{"id":"a","at":"old","items":[{"n":1}]}`), 0o600))
+
+	ftb := &failTB{T: t}
+	stdcrpcsnap.SnapshotEq(ftb, []byte(`{"id":"b","at":"new","items":[{"n":2}]}`),
+		stdcrpcsnap.PinResponseValue("at", "pinned"))
+
+	require.Contains(t, ftb.msg, `id: expected "a", got "b"`)
+	require.Contains(t, ftb.msg, `items.0.n: expected 1, got 2`)
+	require.NotContains(t, ftb.msg, "at: ", "pinned values are not reported")
+}
+
+func TestSnapshotEqUpdate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(stdcrpcsnap.UpdateEnvVar, "true")
+	require.True(t, stdcrpcsnap.Updating())
+
+	path := filepath.Join("testdata", "snapshots", t.Name()+".json")
+	require.NoError(t, os.MkdirAll(filepath.Join("testdata", "snapshots"), 0o777))
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":"a"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join("testdata", "snapshots", "TestRemoved.json"), []byte(`{}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join("testdata", "fixture.json"), []byte(`{}`), 0o600))
+
+	stdcrpcsnap.SnapshotEq(t, []byte(`{"id":"b"}`))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"b"}`, string(data))
+
+	orphans, err := stdcrpcsnap.Orphans()
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join("testdata", "snapshots", "TestRemoved.json")}, orphans,
+		"other files in testdata are not snapshots")
+}
+
+func TestSnapshotEqLegacyPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(stdcrpcsnap.UpdateEnvVar, "")
+
+	legacy := filepath.Join("testdata", t.Name()+".json")
+	require.NoError(t, os.MkdirAll("testdata", 0o777))
+	require.NoError(t, os.WriteFile(legacy, []byte(`{"id":"a"}`), 0o600))
+
+	stdcrpcsnap.SnapshotEq(t, []byte(`{"id":"a"}`))
+	require.FileExists(t, legacy, "snapshots are only moved in update mode")
+
+	t.Setenv(stdcrpcsnap.UpdateEnvVar, "true")
+	stdcrpcsnap.SnapshotEq(t, []byte(`{"id":"a"}`))
+	require.NoFileExists(t, legacy)
+
+	data, err := os.ReadFile(filepath.Join("testdata", "snapshots", t.Name()+".json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"a"}`, string(data))
+}
@@ -0,0 +1,146 @@
+package stdcrpcsnap
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// transportHeaders are left out of stream snapshots, they describe the protocol and not the procedure's response.
+var transportHeaders = []string{
+	"Accept-Encoding", "Connect-Accept-Encoding", "Connect-Content-Encoding", "Content-Encoding", "Content-Length",
+	"Content-Type", "Date", "Grpc-Accept-Encoding", "Grpc-Encoding", "Grpc-Message", "Grpc-Status",
+	"Grpc-Status-Details-Bin", "Trailer", "Transfer-Encoding", "Vary",
+}
+
+// streamSnapshot is the JSON that is compared for streaming calls.
+type streamSnapshot struct {
+	Header   http.Header       `json:"header,omitempty"`
+	Messages []json.RawMessage `json:"messages"`
+	Trailer  http.Header       `json:"trailer,omitempty"`
+	Error    *errorSnapshot    `json:"error,omitempty"`
+}
+
+// errorSnapshot is the JSON for a Connect error, with the details as readable JSON.
+type errorSnapshot struct {
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Details []detailSnapshot `json:"details,omitempty"`
+}
+
+type detailSnapshot struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ServerStreamSnapshotEq receives every message of the server stream and asserts that the ordered messages, the
+// response headers and trailers, and the error (if the call failed) equal the snapshot. Paths of the overwrites
+// are relative to the snapshot: "messages.#.id", "header.X-Foo.0" or "error.details.0.value".
+func ServerStreamSnapshotEq[O any](
+	tb testing.TB, stream *connect.ServerStreamForClient[O], actOverwrites ...Overwrite,
+) {
+	var snap streamSnapshot
+	for stream.Receive() {
+		snap.Messages = append(snap.Messages, marshalStreamMessage(tb, stream.Msg()))
+	}
+
+	snap.Error = newErrorSnapshot(tb, stream.Err())
+	snap.Header, snap.Trailer = withoutTransportHeaders(stream.ResponseHeader()),
+		withoutTransportHeaders(stream.ResponseTrailer())
+	require.NoError(tb, stream.Close())
+
+	streamSnapshotEq(tb, snap, actOverwrites...)
+}
+
+// BidiStreamSnapshotEq is [ServerStreamSnapshotEq] for bidirectional streams. It receives the responses until the
+// server ends the stream, so the requests should be sent (and the request side closed) before calling it.
+func BidiStreamSnapshotEq[I, O any](
+	tb testing.TB, stream *connect.BidiStreamForClient[I, O], actOverwrites ...Overwrite,
+) {
+	var (
+		snap streamSnapshot
+		err  error
+	)
+
+	for {
+		var msg *O
+		if msg, err = stream.Receive(); err != nil {
+			break
+		}
+
+		snap.Messages = append(snap.Messages, marshalStreamMessage(tb, msg))
+	}
+
+	snap.Error = newErrorSnapshot(tb, err)
+	snap.Header, snap.Trailer = withoutTransportHeaders(stream.ResponseHeader()),
+		withoutTransportHeaders(stream.ResponseTrailer())
+	require.NoError(tb, stream.CloseResponse())
+
+	streamSnapshotEq(tb, snap, actOverwrites...)
+}
+
+func streamSnapshotEq(tb testing.TB, snap streamSnapshot, actOverwrites ...Overwrite) {
+	if snap.Messages == nil {
+		snap.Messages = []json.RawMessage{}
+	}
+
+	actMsg, err := json.Marshal(snap)
+	require.NoError(tb, err)
+
+	SnapshotEq(tb, actMsg, actOverwrites...)
+}
+
+func marshalStreamMessage(tb testing.TB, msg any) json.RawMessage {
+	pmsg, ok := msg.(proto.Message)
+	require.True(tb, ok, "stream message of type %T is not a protobuf message", msg)
+
+	data, err := protojson.Marshal(pmsg)
+	require.NoError(tb, err)
+
+	return data
+}
+
+// newErrorSnapshot returns the snapshot of the error that ended the stream, if any. Errors that are not a
+// Connect error fail the test.
+func newErrorSnapshot(tb testing.TB, err error) *errorSnapshot {
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var connErr *connect.Error
+	require.ErrorAs(tb, err, &connErr, "stream ended with an error that is not a Connect error")
+
+	snap := &errorSnapshot{Code: connErr.Code().String(), Message: connErr.Message()}
+
+	for _, detail := range connErr.Details() {
+		value, err := detail.Value()
+		require.NoError(tb, err, "decode error detail of type %s", detail.Type())
+
+		data, err := protojson.Marshal(value)
+		require.NoError(tb, err)
+
+		snap.Details = append(snap.Details, detailSnapshot{Type: detail.Type(), Value: data})
+	}
+
+	return snap
+}
+
+func withoutTransportHeaders(hdr http.Header) http.Header {
+	hdr = hdr.Clone()
+	for _, name := range transportHeaders {
+		hdr.Del(name)
+	}
+
+	if len(hdr) == 0 {
+		return nil
+	}
+
+	return hdr
+}
@@ -0,0 +1,119 @@
+package stdcrpcsnap_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcsnap"
+	internalv1 "github.com/advdv/stdgo/stdcrpc/stdcrpcsnap/internal/v1"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcsnap/internal/v1/internalv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+type testService struct{}
+
+func (testService) List(
+	_ context.Context, req *connect.Request[internalv1.ListRequest], stream *connect.ServerStream[internalv1.ListResponse],
+) error {
+	stream.ResponseHeader().Set("X-Total", strconv.Itoa(int(req.Msg.GetCount())))
+
+	for i := range req.Msg.GetCount() {
+		if err := stream.Send(internalv1.ListResponse_builder{
+			Id: proto.String(uuid.NewString()), Index: proto.Int32(i),
+		}.Build()); err != nil {
+			return err
+		}
+	}
+
+	if req.Msg.GetFail() {
+		cerr := connect.NewError(connect.CodeFailedPrecondition, errors.New("list failed"))
+		detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{Reason: "LIST_FAILED", Domain: "example.com"})
+		if err != nil {
+			return err
+		}
+
+		cerr.AddDetail(detail)
+
+		return cerr
+	}
+
+	stream.ResponseTrailer().Set("X-Done", "true")
+
+	return nil
+}
+
+func (testService) Echo(
+	_ context.Context, stream *connect.BidiStream[internalv1.EchoRequest, internalv1.EchoResponse],
+) error {
+	for {
+		req, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := stream.Send(internalv1.EchoResponse_builder{Text: proto.String(req.GetText())}.Build()); err != nil {
+			return err
+		}
+	}
+}
+
+func setupStreaming(tb testing.TB) internalv1connect.TestServiceClient {
+	tb.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(internalv1connect.NewTestServiceHandler(testService{}))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	tb.Cleanup(srv.Close)
+
+	return internalv1connect.NewTestServiceClient(srv.Client(), srv.URL)
+}
+
+func TestServerStreamSnapshotEq(t *testing.T) {
+	client := setupStreaming(t)
+
+	t.Run("ok", func(t *testing.T) {
+		stream, err := client.List(t.Context(), connect.NewRequest(internalv1.ListRequest_builder{
+			Count: proto.Int32(3),
+		}.Build()))
+		require.NoError(t, err)
+
+		stdcrpcsnap.ServerStreamSnapshotEq(t, stream, stdcrpcsnap.MaskVolatile())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		stream, err := client.List(t.Context(), connect.NewRequest(internalv1.ListRequest_builder{
+			Count: proto.Int32(1), Fail: proto.Bool(true),
+		}.Build()))
+		require.NoError(t, err)
+
+		stdcrpcsnap.ServerStreamSnapshotEq(t, stream,
+			stdcrpcsnap.PinResponseValue("messages.#.id", "pinned", stdcrpcsnap.AssertUUID()))
+	})
+}
+
+func TestBidiStreamSnapshotEq(t *testing.T) {
+	client := setupStreaming(t)
+
+	stream := client.Echo(t.Context())
+	for _, text := range []string{"foo", "bar"} {
+		require.NoError(t, stream.Send(internalv1.EchoRequest_builder{Text: proto.String(text)}.Build()))
+	}
+
+	require.NoError(t, stream.CloseRequest())
+
+	stdcrpcsnap.BidiStreamSnapshotEq(t, stream)
+}
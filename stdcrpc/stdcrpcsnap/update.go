@@ -0,0 +1,123 @@
+package stdcrpcsnap
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// UpdateEnvVar is the environment variable that enables the update mode when set to a true value, as an
+// alternative to the -stdcrpcsnap.update test flag.
+const UpdateEnvVar = "STDCRPCSNAP_UPDATE"
+
+var updateFlag = flag.Bool("stdcrpcsnap.update", false,
+	"rewrite snapshots that don't match the actual response, and remove orphaned snapshots")
+
+// snapshotDir is the directory, relative to the package under test, that holds the snapshots. It is dedicated to
+// snapshots, so other files in testdata are never considered orphaned.
+var snapshotDir = filepath.Join("testdata", "snapshots")
+
+// legacySnapshotDir is where snapshots were written before they got their own directory. They are still read from
+// there, and moved to [snapshotDir] in update mode.
+const legacySnapshotDir = "testdata"
+
+// Updating returns whether snapshots are rewritten instead of compared, see [UpdateEnvVar].
+func Updating() bool {
+	if *updateFlag {
+		return true
+	}
+
+	update, _ := strconv.ParseBool(os.Getenv(UpdateEnvVar))
+
+	return update
+}
+
+// used keeps the snapshot files that were compared against, to detect orphaned snapshots.
+var used sync.Map
+
+func markUsed(path string) { used.Store(filepath.Clean(path), true) }
+
+// Main runs the tests and then checks for orphaned snapshots: files in testdata/snapshots that no test compared
+// against, usually because the test was renamed or removed. In update mode they are removed, otherwise they are
+// reported and the returned exit code is non-zero. Use it in TestMain:
+//
+//	func TestMain(m *testing.M) { os.Exit(stdcrpcsnap.Main(m)) }
+//
+// The check only runs when every test ran and passed, so not with -run, -skip or -short since a test that is
+// filtered or skipped doesn't compare against its snapshot.
+func Main(m *testing.M) int {
+	code := m.Run()
+	if code != 0 || testing.Short() || flagSet("test.run") || flagSet("test.skip") {
+		return code
+	}
+
+	orphans, err := Orphans()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stdcrpcsnap: %v\n", err)
+
+		return 1
+	}
+
+	if len(orphans) == 0 {
+		return code
+	}
+
+	if Updating() {
+		for _, path := range orphans {
+			if err := os.Remove(path); err != nil {
+				fmt.Fprintf(os.Stderr, "stdcrpcsnap: remove orphaned snapshot: %v\n", err)
+
+				return 1
+			}
+
+			fmt.Fprintf(os.Stderr, "stdcrpcsnap: removed orphaned snapshot: %s\n", path)
+		}
+
+		return code
+	}
+
+	fmt.Fprintf(os.Stderr, "stdcrpcsnap: orphaned snapshots, no test compared against them (run with "+
+		"-stdcrpcsnap.update or %s=1 to remove them):\n", UpdateEnvVar)
+
+	for _, path := range orphans {
+		fmt.Fprintf(os.Stderr, "\t%s\n", path)
+	}
+
+	return 1
+}
+
+// Orphans returns the snapshot files that no test compared against during this test run. Only the directory that
+// the snapshots are written to is considered, see [Main].
+func Orphans() ([]string, error) {
+	var orphans []string
+
+	if err := filepath.WalkDir(snapshotDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+
+		if _, ok := used.Load(filepath.Clean(path)); !ok {
+			orphans = append(orphans, path)
+		}
+
+		return nil
+	}); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("walk snapshots: %w", err)
+	}
+
+	slices.Sort(orphans)
+
+	return orphans, nil
+}
+
+func flagSet(name string) bool {
+	f := flag.Lookup(name)
+
+	return f != nil && f.Value.String() != ""
+}
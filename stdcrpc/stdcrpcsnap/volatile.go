@@ -0,0 +1,175 @@
+package stdcrpcsnap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/advdv/stdgo/stdent/stdenttypeid"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// VolatileKind is a shape of value that is different for every test run.
+type VolatileKind string
+
+const (
+	// VolatileUUID matches UUIDs in their canonical form.
+	VolatileUUID VolatileKind = "uuid"
+	// VolatileTypeID matches typeids in their printable form, e.g. "upld_01h455vb4pex5vvjndtv4bxrp4".
+	VolatileTypeID VolatileKind = "typeid"
+	// VolatileTimestamp matches RFC 3339 timestamps.
+	VolatileTimestamp VolatileKind = "timestamp"
+	// VolatileJWT matches JSON Web Tokens in their compact form.
+	VolatileJWT VolatileKind = "jwt"
+)
+
+// VolatileMapKey is the top-level key under which the masked snapshot lists its placeholders, with the kind of
+// value each one replaced.
+const VolatileMapKey = "_volatile"
+
+// MaskVolatile replaces every string value of the given kinds (or all kinds if none are given) with a placeholder
+// such as "<uuid:1>", so the snapshot is stable without pinning each path by hand. The same value gets the same
+// placeholder everywhere in the message, so relationships between values are still asserted. The placeholders are
+// listed in the snapshot under [VolatileMapKey] for review.
+//
+// It is applied in order with the other overwrites, so values that were pinned before it are masked too.
+func MaskVolatile(kinds ...VolatileKind) Overwrite {
+	if len(kinds) == 0 {
+		kinds = []VolatileKind{VolatileUUID, VolatileTypeID, VolatileTimestamp, VolatileJWT}
+	}
+
+	return Overwrite{volatile: kinds}
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// volatileKindOf returns the kind of volatile value s is, or an empty kind if it is not volatile. The label further
+// qualifies the placeholder, e.g. with the prefix of a typeid.
+func volatileKindOf(s string, kinds []VolatileKind) (kind VolatileKind, label string) {
+	for _, kind := range kinds {
+		switch kind {
+		case VolatileUUID:
+			if uuidPattern.MatchString(s) {
+				return kind, string(kind)
+			}
+		case VolatileTypeID:
+			if id, err := stdenttypeid.Parse(s); err == nil {
+				return kind, string(kind) + ":" + id.Prefix()
+			}
+		case VolatileTimestamp:
+			if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return kind, string(kind)
+			}
+		case VolatileJWT:
+			if isJWT(s) {
+				return kind, string(kind)
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// isJWT returns true for three base64url segments of which the first is a JOSE header.
+func isJWT(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	return json.Unmarshal(data, &header) == nil && header.Alg != ""
+}
+
+// maskVolatile replaces the volatile values in the message with placeholders, numbered in document order.
+func maskVolatile(tb require.TestingT, msg []byte, kinds []VolatileKind) []byte {
+	var (
+		placeholders = map[string]string{}
+		kindOf       = map[string]VolatileKind{}
+		counts       = map[string]int{}
+		order        []string
+		err          error
+	)
+
+	for _, val := range volatileStrings(gjson.ParseBytes(msg), "", nil) {
+		placeholder, ok := placeholders[val.str]
+		if !ok {
+			kind, label := volatileKindOf(val.str, kinds)
+			if kind == "" {
+				continue
+			}
+
+			counts[label]++
+			placeholder = "<" + label + ":" + strconv.Itoa(counts[label]) + ">"
+			placeholders[val.str], kindOf[placeholder] = placeholder, kind
+			order = append(order, placeholder)
+		}
+
+		msg, err = sjson.SetBytes(msg, val.path, placeholder)
+		require.NoError(tb, err, "set bytes for path: %s", val.path)
+	}
+
+	// a snapshot is masked again before it is compared, its placeholders don't match and the map is kept as-is.
+	if len(order) == 0 || !gjson.ParseBytes(msg).IsObject() {
+		return msg
+	}
+
+	var volatileMap strings.Builder
+
+	volatileMap.WriteString("{")
+
+	for i, placeholder := range order {
+		if i > 0 {
+			volatileMap.WriteString(",")
+		}
+
+		fmt.Fprintf(&volatileMap, "%q:%q", placeholder, kindOf[placeholder])
+	}
+
+	volatileMap.WriteString("}")
+
+	msg, err = sjson.SetRawBytes(msg, VolatileMapKey, []byte(volatileMap.String()))
+	require.NoError(tb, err, "set volatile map")
+
+	return msg
+}
+
+type pathString struct{ path, str string }
+
+// volatileStrings returns the string values in the JSON with their gjson path, in document order.
+func volatileStrings(res gjson.Result, path string, vals []pathString) []pathString {
+	switch {
+	case res.IsObject():
+		res.ForEach(func(key, val gjson.Result) bool {
+			if path == "" && key.Str == VolatileMapKey {
+				return true
+			}
+
+			vals = volatileStrings(val, joinPath(path, escapePathKey(key.Str)), vals)
+
+			return true
+		})
+	case res.IsArray():
+		for i, val := range res.Array() {
+			vals = volatileStrings(val, joinPath(path, strconv.Itoa(i)), vals)
+		}
+	case res.Type == gjson.String && path != "":
+		vals = append(vals, pathString{path: path, str: res.Str})
+	}
+
+	return vals
+}
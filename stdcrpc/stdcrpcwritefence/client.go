@@ -0,0 +1,108 @@
+package stdcrpcwritefence
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// ClientInterceptor returns a client-side Connect interceptor that
+// gives clients without a cookie jar the same read-your-writes
+// routing as browsers. It captures the fence token from the
+// response header that [Middleware] pins after a write, and echoes
+// it back in the request header of every following call until the
+// token's TTL has passed — after which the server would reject it
+// anyway, so sending it would only cost bytes.
+//
+// The token is held per interceptor, so every call made through a
+// client that shares the interceptor is promoted after any write
+// through it. That matches the typical service-to-service caller
+// that acts as a single principal; callers that act for many users
+// should use one interceptor per user session instead.
+//
+// Options are the ones of [Middleware]: [WithHeaderName] and
+// [WithTTL] must match the server for the echo to line up; the
+// cookie options are ignored.
+//
+// Unary responses are the only source of tokens, because
+// [Interceptor] only fences unary procedures. Streaming calls do
+// carry the captured token, so a stream opened after a write reads
+// its own writes too.
+func ClientInterceptor(opts ...Option) connect.Interceptor {
+	cfg := newConfig(opts...)
+
+	return &clientInterceptor{headerName: cfg.headerName, ttl: cfg.ttl}
+}
+
+// clientInterceptor implements [connect.Interceptor].
+type clientInterceptor struct {
+	headerName string
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (ci *clientInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ci.echo(req.Header())
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if token := resp.Header().Get(ci.headerName); token != "" {
+			ci.capture(token)
+		}
+
+		return resp, nil
+	}
+}
+
+func (ci *clientInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		ci.echo(conn.RequestHeader())
+
+		return conn
+	}
+}
+
+func (ci *clientInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// capture stores the token that the server pinned, restarting the
+// read-your-writes window.
+func (ci *clientInterceptor) capture(token string) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	ci.token, ci.expires = token, time.Now().Add(ci.ttl)
+}
+
+// echo sets the captured token on the request header, unless there
+// is none or its window has passed. A header that the caller set
+// explicitly is left alone.
+func (ci *clientInterceptor) echo(header http.Header) {
+	ci.mu.Lock()
+	token, expires := ci.token, ci.expires
+	ci.mu.Unlock()
+
+	if token == "" || time.Now().After(expires) {
+		return
+	}
+
+	if header.Get(ci.headerName) == "" {
+		header.Set(ci.headerName, token)
+	}
+}
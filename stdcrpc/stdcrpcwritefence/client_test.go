@@ -0,0 +1,128 @@
+package stdcrpcwritefence_test
+
+// Black-box tests for [stdcrpcwritefence.ClientInterceptor]. A real
+// Connect client without a cookie jar talks to a real Connect
+// handler behind the middleware, so the only way a read can be
+// promoted is through the header the interceptor echoes back.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcwritefence"
+	"github.com/advdv/stdgo/stdent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	writeProcedure = "/test.v1.TestService/Write"
+	readProcedure  = "/test.v1.TestService/Read"
+)
+
+// fenceClients is a write and a read client that share one
+// [stdcrpcwritefence.ClientInterceptor], plus what the read handler
+// observed on its last call.
+type fenceClients struct {
+	write, read *connect.Client[emptypb.Empty, emptypb.Empty]
+	promoted    atomic.Bool
+	sentHeader  atomic.Bool
+}
+
+func newFenceClients(t *testing.T, opts ...stdcrpcwritefence.Option) *fenceClients {
+	t.Helper()
+
+	fc := &fenceClients{}
+	empty := func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(writeProcedure, connect.NewUnaryHandler(writeProcedure, empty,
+		connect.WithIdempotency(connect.IdempotencyIdempotent),
+		connect.WithInterceptors(stdcrpcwritefence.Interceptor())))
+	mux.Handle(readProcedure, connect.NewUnaryHandler(readProcedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			fc.promoted.Store(stdent.HasReadPromotion(ctx))
+			fc.sentHeader.Store(req.Header().Get(stdcrpcwritefence.DefaultHeaderName) != "")
+
+			return empty(ctx, req)
+		},
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithInterceptors(stdcrpcwritefence.Interceptor())))
+
+	srv := httptest.NewServer(promotedMiddleware(opts...)(mux))
+	t.Cleanup(srv.Close)
+
+	// the test server's client has no cookie jar, like most
+	// service-to-service and mobile clients.
+	require.Nil(t, srv.Client().Jar)
+
+	cept := connect.WithInterceptors(stdcrpcwritefence.ClientInterceptor(opts...))
+	fc.write = connect.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+writeProcedure, cept)
+	fc.read = connect.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+readProcedure, cept)
+
+	return fc
+}
+
+func (fc *fenceClients) call(t *testing.T, cln *connect.Client[emptypb.Empty, emptypb.Empty]) {
+	t.Helper()
+
+	_, err := cln.CallUnary(t.Context(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+}
+
+func TestClientInterceptor_round_trip_promotes_routing(t *testing.T) {
+	t.Parallel()
+
+	fc := newFenceClients(t)
+
+	// 1) Before any write there is nothing to echo.
+	fc.call(t, fc.read)
+	assert.False(t, fc.sentHeader.Load())
+	assert.False(t, fc.promoted.Load(), "a read before any write MUST NOT be promoted")
+
+	// 2) The write pins the header, the interceptor captures it and
+	// the follow-up read through the sibling client is promoted.
+	fc.call(t, fc.write)
+	fc.call(t, fc.read)
+	assert.True(t, fc.sentHeader.Load())
+	assert.True(t, fc.promoted.Load(), "a read after a write MUST be promoted")
+}
+
+func TestClientInterceptor_stops_echoing_after_ttl(t *testing.T) {
+	t.Parallel()
+
+	// Past the TTL the server would reject the token anyway, so the
+	// interceptor stops sending it.
+	fc := newFenceClients(t, stdcrpcwritefence.WithTTL(time.Second))
+
+	fc.call(t, fc.write)
+	time.Sleep(1100 * time.Millisecond)
+
+	fc.call(t, fc.read)
+	assert.False(t, fc.sentHeader.Load(), "an expired token MUST NOT be echoed")
+	assert.False(t, fc.promoted.Load())
+}
+
+func TestClientInterceptor_explicit_header_wins(t *testing.T) {
+	t.Parallel()
+
+	// A header the caller set explicitly is not overwritten by the
+	// captured token.
+	fc := newFenceClients(t)
+	fc.call(t, fc.write)
+
+	req := connect.NewRequest(&emptypb.Empty{})
+	req.Header().Set(stdcrpcwritefence.DefaultHeaderName, "not-a-valid-securecookie")
+
+	_, err := fc.read.CallUnary(t.Context(), req)
+	require.NoError(t, err)
+	assert.False(t, fc.promoted.Load(), "the explicit (invalid) header MUST be sent as is")
+}
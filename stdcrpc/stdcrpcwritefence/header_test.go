@@ -0,0 +1,120 @@
+package stdcrpcwritefence_test
+
+// Black-box tests for the header transport of
+// [stdcrpcwritefence.Middleware]: the same signed token the cookie
+// carries is pinned on the response header and accepted on the
+// request header, for clients without a cookie jar.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/advdv/stdgo/stdcrpc/stdcrpcwritefence"
+	"github.com/gorilla/securecookie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issueHeaderToken runs a write through the middleware with opts and
+// returns the fence token pinned on the response header name.
+func issueHeaderToken(t *testing.T, name string, opts ...stdcrpcwritefence.Option) string {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	promotedMiddleware(opts...)(markingHandler(nil)).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/w", nil))
+
+	token := rr.Result().Header.Get(name)
+	require.NotEmpty(t, token, "a write must pin the fence header")
+
+	return token
+}
+
+func TestMiddleware_marked_intent_sets_header(t *testing.T) {
+	t.Parallel()
+
+	// The header carries the very same token as the cookie, so a
+	// client may replay it over either transport.
+	rr := httptest.NewRecorder()
+	promotedMiddleware()(markingHandler(nil)).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/w", nil))
+
+	res := rr.Result()
+	issued := findSetCookie(t, res, stdcrpcwritefence.DefaultCookieName)
+	require.NotNil(t, issued)
+	assert.Equal(t, issued.Value, res.Header.Get(stdcrpcwritefence.DefaultHeaderName))
+}
+
+func TestMiddleware_no_marked_intent_no_header(t *testing.T) {
+	t.Parallel()
+
+	// Reads must not pin a header any more than they pin a cookie.
+	rr := httptest.NewRecorder()
+	promotedMiddleware()(&readPromotionProbe{}).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/r", nil))
+
+	assert.Empty(t, rr.Result().Header.Get(stdcrpcwritefence.DefaultHeaderName))
+}
+
+func TestMiddleware_valid_header_promotes_read(t *testing.T) {
+	t.Parallel()
+
+	// A request without a cookie but with a valid fence header MUST
+	// be promoted exactly like a cookie-carrying browser request.
+	token := issueHeaderToken(t, stdcrpcwritefence.DefaultHeaderName)
+
+	probe := &readPromotionProbe{}
+	req := httptest.NewRequest(http.MethodGet, "/r", nil)
+	req.Header.Set(stdcrpcwritefence.DefaultHeaderName, token)
+
+	promotedMiddleware()(probe).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, probe.got.Load(),
+		"a valid, unexpired fence header MUST promote the follow-up read")
+}
+
+func TestMiddleware_tampered_header_no_promotion(t *testing.T) {
+	t.Parallel()
+
+	// Tokens signed with another key, and garbage, MUST fail open to
+	// "no promotion" on the header transport too.
+	sc := securecookie.New([]byte("ffffffffffffffffffffffffffffffff"), nil)
+
+	forged, err := sc.Encode(stdcrpcwritefence.DefaultCookieName, "1")
+	require.NoError(t, err)
+
+	for _, token := range []string{forged, "not-a-valid-securecookie"} {
+		probe := &readPromotionProbe{}
+		req := httptest.NewRequest(http.MethodGet, "/r", nil)
+		req.Header.Set(stdcrpcwritefence.DefaultHeaderName, token)
+
+		promotedMiddleware()(probe).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.False(t, probe.got.Load(), "bad fence headers MUST NOT promote")
+	}
+}
+
+func TestMiddleware_header_name_honored(t *testing.T) {
+	t.Parallel()
+
+	// WithHeaderName moves the token to the configured header on
+	// both the response and the request side.
+	const name = "Myapp-Write-Fence"
+
+	token := issueHeaderToken(t, name, stdcrpcwritefence.WithHeaderName(name))
+
+	for header, promoted := range map[string]bool{
+		name:                                true,
+		stdcrpcwritefence.DefaultHeaderName: false,
+	} {
+		probe := &readPromotionProbe{}
+		req := httptest.NewRequest(http.MethodGet, "/r", nil)
+		req.Header.Set(header, token)
+
+		promotedMiddleware(stdcrpcwritefence.WithHeaderName(name))(probe).
+			ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, promoted, probe.got.Load(), header)
+	}
+}
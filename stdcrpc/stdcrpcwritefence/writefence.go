@@ -37,11 +37,19 @@
 // client from forging or extending the window, the embedded
 // timestamp prevents replay past the configured TTL.
 //
-// Failure mode for cookie verification is fail-open: any error
-// (cookie missing, malformed, tampered, expired) is treated as "no
-// promotion". The middleware never short-circuits the request and
-// never writes to the response except to add the Set-Cookie header
-// when a write was observed.
+// Non-browser clients (service-to-service callers, mobile apps)
+// usually don't keep a cookie jar, so the same signed token also
+// travels in a header: the middleware issues it in the response
+// header next to the cookie, and accepts it in the request header
+// as an alternative to the cookie. [ClientInterceptor] captures the
+// token on the client side and echoes it back automatically. Both
+// transports share the single securecookie key.
+//
+// Failure mode for token verification is fail-open: any error
+// (cookie or header missing, malformed, tampered, expired) is
+// treated as "no promotion". The middleware never short-circuits
+// the request and never writes to the response except to add the
+// Set-Cookie and fence headers when a write was observed.
 package stdcrpcwritefence
 
 import (
@@ -61,6 +69,10 @@ const (
 	// internal" convention some browsers / proxies treat specially.
 	DefaultCookieName = "_wfence"
 
+	// DefaultHeaderName is the request and response header that
+	// carries the fence token when [WithHeaderName] is not supplied.
+	DefaultHeaderName = "Write-Fence"
+
 	// DefaultTTL is the read-your-writes window applied to every
 	// pinned response when [WithTTL] is not supplied. Three seconds
 	// is a typical Aurora / RDS reader-lag budget; tune per
@@ -121,6 +133,7 @@ func MarkFenceIntent(ctx context.Context) {
 
 type config struct {
 	cookieName string
+	headerName string
 	path       string
 	domain     string
 	sameSite   http.SameSite
@@ -130,7 +143,8 @@ type config struct {
 	promote    func(context.Context) context.Context
 }
 
-// Option configures a [Middleware].
+// Option configures a [Middleware] or a [ClientInterceptor]. The
+// cookie options only apply to the middleware.
 type Option func(*config)
 
 // WithCookieName overrides the cookie name used by the middleware.
@@ -138,6 +152,11 @@ type Option func(*config)
 // share the same domain.
 func WithCookieName(name string) Option { return func(c *config) { c.cookieName = name } }
 
+// WithHeaderName overrides the header that carries the fence token
+// for clients without a cookie jar. Pass the same option to
+// [ClientInterceptor] so both sides agree on the header.
+func WithHeaderName(name string) Option { return func(c *config) { c.headerName = name } }
+
 // WithPath overrides the cookie's Path attribute. Defaults to "/".
 func WithPath(p string) Option { return func(c *config) { c.path = p } }
 
@@ -163,7 +182,8 @@ func WithInsecure() Option { return func(c *config) { c.secure = false } }
 func WithTTL(d time.Duration) Option { return func(c *config) { c.ttl = d } }
 
 // WithReadPromotion sets the ctx promoter the middleware invokes on
-// a successful cookie verification. The promoter returns a derived
+// a successful token verification, whether the token arrived in
+// the cookie or in the fence header. The promoter returns a derived
 // ctx that downstream code (typically `stdent.TransactR` /
 // `stdent.TransactR0`) consults to route the read to the rw
 // transactor.
@@ -196,10 +216,11 @@ func WithReadPromotion(promote func(context.Context) context.Context) Option {
 //
 // The returned middleware:
 //
-//  1. Reads the configured cookie from the request; on a verified,
-//     unexpired cookie it hands ctx to the promoter supplied via
-//     [WithReadPromotion] (if any) so a subsequent transact call
-//     can open against the rw transactor instead of the ro one.
+//  1. Reads the configured cookie, or failing that the fence
+//     header, from the request; on a verified, unexpired token it
+//     hands ctx to the promoter supplied via [WithReadPromotion]
+//     (if any) so a subsequent transact call can open against the
+//     rw transactor instead of the ro one.
 //
 //  2. Installs a fresh fence-intent flag on ctx so any caller down
 //     the stack (canonically [Interceptor], optionally
@@ -208,25 +229,15 @@ func WithReadPromotion(promote func(context.Context) context.Context) Option {
 //  3. Wraps the [http.ResponseWriter] so that the first call to
 //     WriteHeader or Write (whichever lands first) — or, failing
 //     either, the moment the handler returns — checks the flag
-//     and, if set, adds a freshly-signed cookie to the response
-//     headers BEFORE the status line is flushed to the wire.
+//     and, if set, adds a freshly-signed cookie and fence header to
+//     the response headers BEFORE the status line is flushed to the
+//     wire.
 func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler {
 	if len(hashKey) < MinHashKeyLen {
 		panic("stdcrpcwritefence: hashKey must be at least 32 bytes")
 	}
 
-	cfg := config{
-		cookieName: DefaultCookieName,
-		path:       "/",
-		sameSite:   http.SameSiteLaxMode,
-		secure:     true,
-		httpOnly:   true,
-		ttl:        DefaultTTL,
-	}
-
-	for _, o := range opts {
-		o(&cfg)
-	}
+	cfg := newConfig(opts...)
 
 	codec := securecookie.New(hashKey, nil)
 	// MaxAge is in whole seconds in securecookie; ensure at least 1
@@ -239,17 +250,15 @@ func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			// Read side — verify the cookie and, on success, hand
+			// Read side — verify the cookie, or the fence header
+			// for clients without a cookie jar, and on success hand
 			// ctx to the caller-supplied promoter (if any). Any
 			// failure (missing, malformed, signature mismatch,
 			// expired) is silently treated as "no promotion": the
 			// middleware never writes to the response on the read
 			// path.
-			if c, err := r.Cookie(cfg.cookieName); err == nil {
-				var v string
-				if err := codec.Decode(cfg.cookieName, c.Value, &v); err == nil && cfg.promote != nil {
-					ctx = cfg.promote(ctx)
-				}
+			if verified(codec, cfg, r) && cfg.promote != nil {
+				ctx = cfg.promote(ctx)
 			}
 
 			// Write side — install the fence-intent flag that
@@ -259,7 +268,7 @@ func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler
 
 			var once sync.Once
 
-			setCookie := func() {
+			pinFence := func() {
 				once.Do(func() {
 					if !intent.Load() {
 						return
@@ -270,6 +279,10 @@ func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler
 						return
 					}
 
+					// The header is the transport for clients without a
+					// cookie jar, see [ClientInterceptor].
+					w.Header().Set(cfg.headerName, encoded)
+
 					// gosec G124 flags cookies whose Secure attribute may be
 					// false. WithInsecure() is an explicit opt-in for local
 					// dev only — production callers leave Secure on (the
@@ -287,20 +300,20 @@ func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler
 				})
 			}
 
-			// Hook WriteHeader and Write so the cookie lands BEFORE
+			// Hook WriteHeader and Write so the token lands BEFORE
 			// the status line / body is flushed. httpsnoop preserves
 			// the Flusher / Hijacker / Pusher interfaces so chi /
 			// WebSocket upgrades / SSE handlers continue to work.
 			wrapped := httpsnoop.Wrap(w, httpsnoop.Hooks{
 				WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
 					return func(code int) {
-						setCookie()
+						pinFence()
 						next(code)
 					}
 				},
 				Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
 					return func(p []byte) (int, error) {
-						setCookie()
+						pinFence()
 
 						return next(p)
 					}
@@ -313,9 +326,48 @@ func Middleware(hashKey []byte, opts ...Option) func(http.Handler) http.Handler
 			// net/http's implicit 200 OK is emitted by the server
 			// after the handler returns, but the headers it flushes
 			// at that point still come from w.Header(). Calling
-			// setCookie here is a no-op if the WriteHeader/Write hook
+			// pinFence here is a no-op if the WriteHeader/Write hook
 			// already ran, so it's safe in all cases.
-			setCookie()
+			pinFence()
 		})
 	}
 }
+
+// newConfig applies opts on top of the defaults. Shared by
+// [Middleware] and [ClientInterceptor] so both sides resolve the
+// same header name and TTL from the same options.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		cookieName: DefaultCookieName,
+		headerName: DefaultHeaderName,
+		path:       "/",
+		sameSite:   http.SameSiteLaxMode,
+		secure:     true,
+		httpOnly:   true,
+		ttl:        DefaultTTL,
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return cfg
+}
+
+// verified reports whether the request carries a valid, unexpired
+// fence token: in the cookie first, in the fence header otherwise.
+// Both transports carry the same token, signed under the cookie
+// name, so a token verifies regardless of how it travelled.
+func verified(codec *securecookie.SecureCookie, cfg config, r *http.Request) bool {
+	var v string
+
+	if c, err := r.Cookie(cfg.cookieName); err == nil {
+		if err := codec.Decode(cfg.cookieName, c.Value, &v); err == nil {
+			return true
+		}
+	}
+
+	token := r.Header.Get(cfg.headerName)
+
+	return token != "" && codec.Decode(cfg.cookieName, token, &v) == nil
+}
@@ -0,0 +1,35 @@
+package stdctx
+
+import (
+	"context"
+	"net/netip"
+)
+
+// clientIP holds the resolved address of the client, and the proxies the request went through.
+type clientIP struct {
+	ip      netip.Addr
+	proxies []netip.Addr
+}
+
+// WithClientIP adds the resolved client IP to the context, together with the chain of proxies between the client
+// and the server. The chain is ordered from the proxy closest to the client to the proxy that connected to us.
+func WithClientIP(ctx context.Context, ip netip.Addr, proxies []netip.Addr) context.Context {
+	return context.WithValue(ctx, ctxKey("client_ip"), clientIP{ip: ip, proxies: proxies})
+}
+
+// ClientIP returns the resolved client IP from the context.
+func ClientIP(ctx context.Context) (netip.Addr, bool) {
+	v, ok := ctx.Value(ctxKey("client_ip")).(clientIP)
+	if !ok {
+		return netip.Addr{}, false
+	}
+
+	return v.ip, true
+}
+
+// ProxyChain returns the trusted proxies the request went through, or nil if it didn't go through any.
+func ProxyChain(ctx context.Context) []netip.Addr {
+	v, _ := ctx.Value(ctxKey("client_ip")).(clientIP)
+
+	return v.proxies
+}
@@ -0,0 +1,15 @@
+package stdctx
+
+import "context"
+
+// WithCSPNonce adds the Content-Security-Policy nonce of the current response to the context.
+func WithCSPNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, ctxKey("csp_nonce"), nonce)
+}
+
+// CSPNonce returns the Content-Security-Policy nonce of the current response. Inline scripts and styles that are
+// rendered with this nonce are allowed by a policy that includes it.
+func CSPNonce(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(ctxKey("csp_nonce")).(string)
+	return v, ok
+}
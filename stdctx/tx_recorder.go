@@ -0,0 +1,26 @@
+package stdctx
+
+import "context"
+
+// TxExecFunc executes a statement in a transaction and returns the number of affected rows.
+type TxExecFunc func(ctx context.Context, sql string, args ...any) (int64, error)
+
+// TxRecorder records the response of the current request in the transaction that produced it, so both commit
+// atomically. An error should fail the transaction.
+type TxRecorder func(ctx context.Context, exec TxExecFunc, resp any) error
+
+// WithTxRecorder adds a recorder to the context that transaction helpers call after the transaction's function
+// succeeded, but before it commits.
+func WithTxRecorder(ctx context.Context, rec TxRecorder) context.Context {
+	return context.WithValue(ctx, ctxKey("tx_recorder"), rec)
+}
+
+// RecordInTx calls the recorder from the context, if there is any.
+func RecordInTx(ctx context.Context, exec TxExecFunc, resp any) error {
+	rec, ok := ctx.Value(ctxKey("tx_recorder")).(TxRecorder)
+	if !ok {
+		return nil
+	}
+
+	return rec(ctx, exec, resp)
+}
@@ -81,6 +81,56 @@ type ID string //nolint:recvcheck // sql.Scanner needs pointer; ent calls Value/
 // String returns the printable form of the id.
 func (id ID) String() string { return string(id) }
 
+// Prefix returns the type prefix of the id, e.g. `upld` for
+// `upld_01h455vb4pex5vvjndtv4bxrp4`. It is empty for the zero value.
+func (id ID) Prefix() string {
+	prefix, _, _ := cutSuffix(string(id))
+
+	return prefix
+}
+
+// Parse validates the printable form of a typeid, as a wire API
+// receives it from clients: a prefix of lowercase letters and
+// underscores, an underscore, and the 26-character base32 suffix
+// [encodeUUIDBase32] produces. The composite literal form is not
+// accepted; it is a database representation, not a wire one.
+func Parse(s string) (ID, error) {
+	const suffixLen = 26
+
+	prefix, suffix, ok := cutSuffix(s)
+	if !ok {
+		return "", errors.Newf("stdenttypeid: %q is missing the prefix separator", s)
+	}
+
+	if prefix == "" || strings.HasPrefix(prefix, "_") || strings.HasSuffix(prefix, "_") ||
+		strings.Trim(prefix, "abcdefghijklmnopqrstuvwxyz_") != "" {
+		return "", errors.Newf("stdenttypeid: invalid prefix %q, must be lowercase letters and underscores", prefix)
+	}
+
+	if len(suffix) != suffixLen {
+		return "", errors.Newf("stdenttypeid: suffix of %q must be %d characters", s, suffixLen)
+	}
+
+	// the 130-bit encoding left-pads the 128-bit uuid, so the first
+	// character only ever takes one of the first 8 values.
+	if suffix[0] > '7' || strings.Trim(suffix, base32Alphabet) != "" {
+		return "", errors.Newf("stdenttypeid: suffix of %q is not valid base32", s)
+	}
+
+	return ID(s), nil
+}
+
+// cutSuffix splits the printable form at the last underscore, since
+// prefixes may contain underscores themselves.
+func cutSuffix(s string) (prefix, suffix string, ok bool) {
+	idx := strings.LastIndex(s, "_")
+	if idx < 0 {
+		return "", s, false
+	}
+
+	return s[:idx], s[idx+1:], true
+}
+
 // Value implements [database/sql/driver.Valuer]. The driver sees
 // the printable string; [ID.FormatParam] ensures Postgres receives
 // it via `public.typeid_parse($N)`.
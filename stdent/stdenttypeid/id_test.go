@@ -154,3 +154,33 @@ func TestID_Scan_InvalidUUID(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "parse uuid")
 }
+
+func TestID_Prefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "upld", stdenttypeid.ID(knownSuffix).Prefix())
+	assert.Equal(t, "org_member", stdenttypeid.ID("org_member_01h455vb4pex5vvjndtv4bxrp4").Prefix())
+	assert.Empty(t, stdenttypeid.ID("").Prefix())
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	id, err := stdenttypeid.Parse(knownSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, stdenttypeid.ID(knownSuffix), id)
+
+	for in, expErr := range map[string]string{
+		"01h455vb4pex5vvjndtv4bxrp4":                  "missing the prefix separator",
+		"_01h455vb4pex5vvjndtv4bxrp4":                 "invalid prefix",
+		"Upld_01h455vb4pex5vvjndtv4bxrp4":             "invalid prefix",
+		"upld__01h455vb4pex5vvjndtv4bxrp4":            "invalid prefix",
+		"upld_01h455vb4pex5vvjndtv4bxrp":              "must be 26 characters",
+		"upld_81h455vb4pex5vvjndtv4bxrp4":             "not valid base32",
+		"upld_01h455vb4pex5vvjndtv4bxrpu":             "not valid base32",
+		"(upld,01890a5d-ac96-774b-bdca-add6c8bee2c4)": "missing the prefix separator",
+	} {
+		_, err := stdenttypeid.Parse(in)
+		require.ErrorContains(t, err, expErr, in)
+	}
+}
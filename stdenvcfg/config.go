@@ -2,8 +2,11 @@
 package stdenvcfg
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/iancoleman/strcase"
@@ -13,33 +16,146 @@ import (
 // Environment allows providing environment variables pre-set. Useful for testing.
 type Environment map[string]string
 
-// envConfigurer returns a function that parses environment variables into a configuration struct T. If the
-// prefix is provided it will set a prefix for the underlying environment parser.
-func envConfigurer[T any](prefix ...string) func(o env.Options, vars Environment) (T, error) {
-	return func(envo env.Options, vars Environment) (T, error) {
-		var cfg T
+// Check validates one configuration struct against the environment. Every configuration provided through [Provide]
+// or [ProvideNamed] contributes a check so that all configuration can be validated at once, during boot.
+type Check struct {
+	// Name identifies the configuration being checked.
+	Name string
+	// Vars describes the environment variables the configuration is parsed from.
+	Vars []Var
+	// Run parses and validates the configuration from the environment.
+	Run func(vars Environment) error
+}
+
+// Validated is provided alongside the environment by [ProvideLayeredEnvironment] and its shorthands. Depending on it
+// guarantees that all configuration provided to the application passed its validation, so no constructor that takes
+// configuration will run while any configuration is invalid. An [Environment] that is provided in another way, for
+// example with fx.Supply, may be accompanied by [ProvideValidation] for the same guarantee. Without it each
+// configuration is validated when it is parsed, so violations are reported one configuration at a time.
+type Validated struct{ all bool }
+
+// ProvideValidation provides [Validated] for an [Environment] that is not provided by [ProvideLayeredEnvironment].
+func ProvideValidation() fx.Option {
+	return fx.Provide(newValidated)
+}
 
-		// we always use an explicit environment.
-		envo.Environment = vars
+// newValidated runs all checks and reports every violation across all configuration structs in one error.
+func newValidated(params struct {
+	fx.In
+	Vars   Environment
+	Checks []Check `group:"config_checks"`
+},
+) (Validated, error) {
+	var errs []error
 
-		if len(prefix) > 0 {
-			envo.Prefix = prefix[0]
+	// value groups have no defined order, sort them for a stable report.
+	slices.SortFunc(params.Checks, func(a, b Check) int { return strings.Compare(a.Name, b.Name) })
+
+	for i, check := range params.Checks {
+		if i > 0 && params.Checks[i-1].Name == check.Name {
+			continue // same configuration provided more than once, for example as reloadable.
+		}
+
+		if err := check.Run(params.Vars); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", check.Name, err))
 		}
+	}
+
+	if len(errs) > 0 {
+		return Validated{}, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
 
-		err := env.ParseWithOptions(&cfg, envo)
-		if err != nil {
-			return cfg, fmt.Errorf("failed to parse environment: %w", err)
+	return Validated{all: true}, nil
+}
+
+// envChecker returns a function that creates a check for configuration struct T.
+func envChecker[T any](prefix ...string) func(o env.Options) Check {
+	return func(envo env.Options) Check {
+		return Check{
+			Name: checkName[T](prefix...),
+			Vars: declaredVars(reflect.TypeFor[T](), effectivePrefix(envo, prefix...)),
+			Run: func(vars Environment) error {
+				cfg, err := parse[T](envo, vars, prefix...)
+				if err != nil {
+					return err
+				}
+
+				return Validate(cfg, effectivePrefix(envo, prefix...))
+			},
 		}
+	}
+}
+
+// checkName identifies configuration T, parsed with the optional prefix, in error messages.
+func checkName[T any](prefix ...string) string {
+	name := reflect.TypeFor[T]().String()
+	if len(prefix) > 0 {
+		name += " (" + prefix[0] + ")"
+	}
+
+	return name
+}
 
-		return cfg, nil
+// effectivePrefix returns the prefix that the environment parser will use.
+func effectivePrefix(envo env.Options, prefix ...string) string {
+	if len(prefix) > 0 {
+		return prefix[0]
 	}
+
+	return envo.Prefix
 }
 
-// Provide configuration T as an fx dependency that parses the environment with an optional prefix.
+// envConfigurer returns a function that parses environment variables into a configuration struct T. If the
+// prefix is provided it will set a prefix for the underlying environment parser.
+func envConfigurer[T any](prefix ...string) func(o env.Options, vars Environment, vld Validated) (T, error) {
+	return func(envo env.Options, vars Environment, vld Validated) (T, error) {
+		return parseValidated[T](envo, vars, vld, prefix...)
+	}
+}
+
+// parseValidated parses the environment into configuration T, and validates it unless all configuration was already
+// validated during boot.
+func parseValidated[T any](envo env.Options, vars Environment, vld Validated, prefix ...string) (T, error) {
+	cfg, err := parse[T](envo, vars, prefix...)
+	if err != nil || vld.all {
+		return cfg, err
+	}
+
+	if err := Validate(cfg, effectivePrefix(envo, prefix...)); err != nil {
+		return cfg, fmt.Errorf("invalid configuration:\n%s: %w", checkName[T](prefix...), err)
+	}
+
+	return cfg, nil
+}
+
+// parse the environment variables into a configuration struct T.
+func parse[T any](envo env.Options, vars Environment, prefix ...string) (T, error) {
+	var cfg T
+
+	// we always use an explicit environment.
+	envo.Environment = vars
+
+	if len(prefix) > 0 {
+		envo.Prefix = prefix[0]
+	}
+
+	err := env.ParseWithOptions(&cfg, envo)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to parse environment: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Provide configuration T as an fx dependency that parses the environment with an optional prefix. The configuration
+// is validated as described by [Validate] before any of it is provided.
 func Provide[T any](prefix ...string) fx.Option {
-	return fx.Provide(fx.Annotate(
-		envConfigurer[T](prefix...),
-		fx.ParamTags(`optional:"true"`)))
+	return fx.Options(
+		fx.Provide(fx.Annotate(
+			envConfigurer[T](prefix...),
+			fx.ParamTags(`optional:"true"`, ``, `optional:"true"`))),
+		provideCheck[T](prefix...),
+	)
 }
 
 // ProvideNamed configuration T as an fx dependency that parses the environment with an optional prefix.
@@ -48,19 +164,31 @@ func ProvideNamed[T any](name string, prefix ...string) fx.Option {
 		prefix[0] += strcase.ToScreamingSnake(name) + "_"
 	}
 
+	return fx.Options(
+		fx.Provide(fx.Annotate(
+			envConfigurer[T](prefix...),
+			fx.ParamTags(`optional:"true"`, ``, `optional:"true"`),
+			fx.ResultTags(`name:"`+name+`"`),
+		)),
+		provideCheck[T](prefix...),
+	)
+}
+
+// provideCheck contributes the check for configuration T to the checks that are run during boot.
+func provideCheck[T any](prefix ...string) fx.Option {
 	return fx.Provide(fx.Annotate(
-		envConfigurer[T](prefix...),
+		envChecker[T](prefix...),
 		fx.ParamTags(`optional:"true"`),
-		fx.ResultTags(`name:"`+name+`"`),
+		fx.ResultTags(`group:"config_checks"`),
 	))
 }
 
 // ProvideExplicitEnvironment provides env options with environment options pre-set. Useful for testing.
 func ProvideExplicitEnvironment(vars map[string]string) fx.Option {
-	return fx.Supply(Environment(vars))
+	return ProvideLayeredEnvironment(Layer{Name: "explicit", Load: func() (map[string]string, error) { return vars, nil }})
 }
 
 // ProvideOSEnvironment provides an Environment from the os.Environ.
 func ProvideOSEnvironment() fx.Option {
-	return fx.Supply(Environment(env.ToMap(os.Environ())))
+	return ProvideLayeredEnvironment(OSLayer())
 }
@@ -0,0 +1,121 @@
+package stdenvcfg
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/fx"
+)
+
+// redacted replaces the value of sensitive variables when printing settings.
+const redacted = "[REDACTED]"
+
+// SecretSourcePrefix marks a source as a secret store. Values from such a source are always redacted when printing
+// settings, even if the field is not tagged as sensitive.
+const SecretSourcePrefix = "secret:"
+
+// Var describes an environment variable that is declared by a configuration struct.
+type Var struct {
+	// Name of the (prefixed) environment variable.
+	Name string
+	// Default is the value declared with the "envDefault" tag, if any.
+	Default string
+	// Sensitive is true if the field is tagged with `sensitive:"true"`, its value will never be printed.
+	Sensitive bool
+}
+
+// PrintSettings returns an fx option that prints every setting declared by the provided configuration, together with
+// the effective value and the layer it came from. Values of sensitive variables, and values resolved from a secret
+// source, are redacted.
+func PrintSettings(w io.Writer) fx.Option {
+	return fx.Invoke(func(params struct {
+		fx.In
+		Vars    Environment
+		Sources Sources `optional:"true"`
+		Checks  []Check `group:"config_checks"`
+	},
+	) error {
+		return WriteSettings(w, params.Vars, params.Sources, params.Checks...)
+	})
+}
+
+// WriteSettings writes a table of all variables declared by the checks, with their effective value and source.
+func WriteSettings(w io.Writer, vars Environment, sources Sources, checks ...Check) error {
+	declared := map[string]Var{}
+	for _, check := range checks {
+		for _, v := range check.Vars {
+			declared[v.Name] = v
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(declared))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVALUE\tSOURCE")
+
+	for _, name := range names {
+		decl := declared[name]
+
+		val, source := vars[name], sources[name]
+		if _, ok := vars[name]; !ok {
+			val, source = decl.Default, "unset"
+			if decl.Default != "" {
+				source = "envDefault"
+			}
+		} else if source == "" {
+			source = "unknown"
+		}
+
+		if (decl.Sensitive || strings.HasPrefix(source, SecretSourcePrefix)) && val != "" {
+			val = redacted
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, val, source)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	return nil
+}
+
+// declaredVars returns the environment variables that configuration type typ is parsed from.
+func declaredVars(typ reflect.Type, prefix string) (vars []Var) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := envName(field)
+		if name == "" {
+			if field.Type.Kind() == reflect.Struct {
+				vars = append(vars, declaredVars(field.Type, prefix+field.Tag.Get("envPrefix"))...)
+			}
+
+			continue
+		}
+
+		vars = append(vars, Var{
+			Name:      prefix + name,
+			Default:   field.Tag.Get("envDefault"),
+			Sensitive: strings.EqualFold(field.Tag.Get("sensitive"), "true"),
+		})
+	}
+
+	return vars
+}
@@ -0,0 +1,151 @@
+package stdenvcfg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/joho/godotenv"
+	"go.uber.org/fx"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources records, for each environment variable, the name of the layer that supplied its effective value.
+type Sources map[string]string
+
+// Layer is one source of environment variables. Layers are merged in order so later layers override earlier ones.
+type Layer struct {
+	// Name identifies the layer when reporting where a value came from.
+	Name string
+	// Load reads the variables of the layer.
+	Load func() (map[string]string, error)
+}
+
+// DefaultsLayer provides fixed default values, typically used as the first layer.
+func DefaultsLayer(defaults map[string]string) Layer {
+	return Layer{Name: "defaults", Load: func() (map[string]string, error) { return defaults, nil }}
+}
+
+// FileLayer reads variables from a dotenv (.env), YAML (.yaml, .yml) or JSON (.json) file. The format is determined
+// by the file's extension, any other extension is read as dotenv. Nested objects in YAML and JSON files are flattened
+// by joining the keys with an underscore, lists are joined with a comma. It is an error if the file does not exist.
+func FileLayer(path string) Layer {
+	return Layer{Name: "file:" + path, Load: func() (map[string]string, error) { return readFile(path) }}
+}
+
+// OptionalFileLayer is like [FileLayer] but provides no variables if the file does not exist. This is useful for
+// environment-specific override files.
+func OptionalFileLayer(path string) Layer {
+	return Layer{Name: "file:" + path, Load: func() (map[string]string, error) {
+		vars, err := readFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return vars, err
+	}}
+}
+
+// OSLayer provides the variables from the os.Environ.
+func OSLayer() Layer {
+	return Layer{Name: "os", Load: func() (map[string]string, error) { return env.ToMap(os.Environ()), nil }}
+}
+
+// ProvideLayeredEnvironment provides an Environment by merging the layers in order, later layers override the
+// variables of earlier ones. It also provides the [Sources] that record which layer supplied each variable, and a
+// [Loader] that re-reads the layers for reloadable configuration.
+func ProvideLayeredEnvironment(layers ...Layer) fx.Option {
+	return fx.Options(
+		fx.Supply(Loader(func(context.Context) (Environment, Sources, error) { return mergeLayers(layers...) })),
+		fx.Provide(func(load Loader) (Environment, Sources, error) { return load(context.Background()) }),
+		ProvideValidation(),
+	)
+}
+
+// mergeLayers loads and merges all layers while recording the source of every variable.
+func mergeLayers(layers ...Layer) (Environment, Sources, error) {
+	vars, sources := Environment{}, Sources{}
+
+	for _, layer := range layers {
+		lvars, err := layer.Load()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load layer %s: %w", layer.Name, err)
+		}
+
+		maps.Copy(vars, lvars)
+
+		for key := range lvars {
+			sources[key] = layer.Name
+		}
+	}
+
+	return vars, sources, nil
+}
+
+// readFile reads variables from a dotenv, YAML or JSON file.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var tree map[string]any
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&tree)
+	default:
+		vars, err := godotenv.UnmarshalBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dotenv: %w", err)
+		}
+
+		return vars, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	vars := map[string]string{}
+	flatten(vars, "", tree)
+
+	return vars, nil
+}
+
+// flatten turns a tree of decoded YAML or JSON values into flat variables.
+func flatten(vars map[string]string, prefix string, val any) {
+	switch val := val.(type) {
+	case map[string]any:
+		for key, sub := range val {
+			if prefix != "" {
+				key = prefix + "_" + key
+			}
+
+			flatten(vars, key, sub)
+		}
+	case []any:
+		elems := make([]string, 0, len(val))
+		for _, elem := range val {
+			elems = append(elems, fmt.Sprint(elem))
+		}
+
+		vars[prefix] = strings.Join(elems, ",")
+	case nil:
+		vars[prefix] = ""
+	default:
+		vars[prefix] = fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,90 @@
+package stdenvcfg_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type Conf4 struct {
+	Mode    string   `env:"MODE"`
+	Workers int      `env:"WORKERS"`
+	Origins []string `env:"ORIGINS"`
+	Token   string   `env:"TOKEN"   sensitive:"true"`
+	Region  string   `env:"REGION"  envDefault:"eu-central-1"`
+	Bucket  string   `env:"BUCKET"`
+}
+
+func TestLayeredEnvironment(t *testing.T) {
+	t.Setenv("APP_TOKEN", "from-os")
+
+	var deps struct {
+		fx.In
+		Cfg     Conf4
+		Sources stdenvcfg.Sources
+	}
+
+	fxtest.New(t,
+		fx.Populate(&deps),
+		stdenvcfg.Provide[Conf4]("APP_"),
+		stdenvcfg.ProvideLayeredEnvironment(
+			stdenvcfg.DefaultsLayer(map[string]string{"APP_MODE": "default", "APP_BUCKET": "b1"}),
+			stdenvcfg.FileLayer("testdata/base.yaml"),
+			stdenvcfg.OptionalFileLayer("testdata/prod.json"),
+			stdenvcfg.OptionalFileLayer("testdata/staging.json"),
+			stdenvcfg.FileLayer("testdata/local.env"),
+			stdenvcfg.OSLayer(),
+		),
+	)
+
+	assert.Equal(t, Conf4{
+		Mode:    "safe",
+		Workers: 8,
+		Origins: []string{"https://a.example.com", "https://b.example.com"},
+		Token:   "from-os",
+		Region:  "eu-central-1",
+		Bucket:  "b1",
+	}, deps.Cfg)
+
+	assert.Equal(t, "defaults", deps.Sources["APP_BUCKET"])
+	assert.Equal(t, "file:testdata/base.yaml", deps.Sources["APP_ORIGINS"])
+	assert.Equal(t, "file:testdata/prod.json", deps.Sources["APP_WORKERS"])
+	assert.Equal(t, "file:testdata/local.env", deps.Sources["APP_MODE"])
+	assert.Equal(t, "os", deps.Sources["APP_TOKEN"])
+}
+
+func TestLayeredEnvironmentMissingFile(t *testing.T) {
+	app := fx.New(
+		fx.Invoke(func(stdenvcfg.Environment) {}),
+		stdenvcfg.ProvideLayeredEnvironment(stdenvcfg.FileLayer("testdata/missing.yaml")))
+	require.ErrorContains(t, app.Err(), "failed to load layer file:testdata/missing.yaml")
+}
+
+func TestPrintSettings(t *testing.T) {
+	var buf bytes.Buffer
+
+	fxtest.New(t,
+		stdenvcfg.Provide[Conf4]("APP_"),
+		stdenvcfg.ProvideLayeredEnvironment(
+			stdenvcfg.DefaultsLayer(map[string]string{"APP_MODE": "fast", "APP_TOKEN": "secret1"}),
+			stdenvcfg.Layer{Name: stdenvcfg.SecretSourcePrefix + "vault", Load: func() (map[string]string, error) {
+				return map[string]string{"APP_BUCKET": "secret2"}, nil
+			}},
+		),
+		stdenvcfg.PrintSettings(&buf),
+	)
+
+	assert.Equal(t, `NAME         VALUE         SOURCE
+APP_BUCKET   [REDACTED]    secret:vault
+APP_MODE     fast          defaults
+APP_ORIGINS                unset
+APP_REGION   eu-central-1  envDefault
+APP_TOKEN    [REDACTED]    defaults
+APP_WORKERS                unset
+`, buf.String())
+}
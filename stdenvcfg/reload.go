@@ -0,0 +1,291 @@
+package stdenvcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Loader (re-)reads the environment from its sources. It is provided by [ProvideLayeredEnvironment] and may be
+// decorated, for example to resolve secrets on every reload.
+type Loader func(ctx context.Context) (Environment, Sources, error)
+
+// ReloaderConfig configures when reloadable configuration is re-read from its sources.
+type ReloaderConfig struct {
+	// Interval at which the environment is re-read, zero disables reloading on a schedule.
+	Interval time.Duration `env:"INTERVAL" validate:"min=0s"`
+	// OnSIGHUP re-reads the environment when the process receives a SIGHUP signal. It is opt-in since it replaces
+	// the default handling of the signal, which terminates the process.
+	OnSIGHUP bool `env:"ON_SIGHUP"`
+	// Timeout for re-reading the environment, including the resolving of any secrets.
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s" validate:"min=1ms"`
+}
+
+// Reloader re-reads the environment and publishes new values to every [Reloadable] configuration.
+type Reloader struct {
+	cfg  ReloaderConfig
+	load Loader
+	logs atomic.Pointer[zap.Logger]
+
+	mu      sync.Mutex
+	targets []reloadTarget
+	gen     uint64
+}
+
+// reloadTarget parses and validates configuration from the environment, and returns a function that publishes it. The
+// generation orders the publishes of concurrent reloads.
+type reloadTarget func(vars Environment, gen uint64) (publish func(), err error)
+
+// NewReloader constructs the reloader and starts reloading on the configured schedule or signal.
+func NewReloader(deps struct {
+	fx.In
+	fx.Lifecycle
+
+	Config ReloaderConfig
+	Loader Loader
+},
+) *Reloader {
+	rld := &Reloader{cfg: deps.Config, load: deps.Loader}
+	rld.logs.Store(zap.NewNop())
+
+	var (
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+
+	deps.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			hup := make(chan os.Signal, 1)
+			if rld.cfg.OnSIGHUP {
+				signal.Notify(hup, syscall.SIGHUP)
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer signal.Stop(hup)
+
+				var tick <-chan time.Time
+				if rld.cfg.Interval > 0 {
+					ticker := time.NewTicker(rld.cfg.Interval)
+					defer ticker.Stop()
+
+					tick = ticker.C
+				}
+
+				for {
+					select {
+					case <-done:
+						return
+					case <-tick:
+					case <-hup:
+					}
+
+					if err := rld.Reload(context.Background()); err != nil {
+						rld.logs.Load().Error("failed to reload configuration, keeping current values", zap.Error(err))
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			close(done)
+			wg.Wait()
+
+			return nil
+		},
+	})
+
+	return rld
+}
+
+// Reload re-reads the environment and re-validates all reloadable configuration. Only if all of it is valid the new
+// values are published, so subscribers never observe a partially reloaded configuration. Subscribers are called
+// after the reloader is unlocked, so they may reload or (un)subscribe themselves.
+func (rld *Reloader) Reload(ctx context.Context) error {
+	publishes, err := rld.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, publish := range publishes {
+		publish()
+	}
+
+	rld.logs.Load().Info("reloaded configuration", zap.Int("num_reloadables", len(publishes)))
+
+	return nil
+}
+
+// prepare loads the environment and parses every reloadable configuration from it, and returns the functions that
+// publish the new values.
+func (rld *Reloader) prepare(ctx context.Context) ([]func(), error) {
+	rld.mu.Lock()
+	defer rld.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, rld.cfg.Timeout)
+	defer cancel()
+
+	vars, _, err := rld.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load environment: %w", err)
+	}
+
+	rld.gen++
+
+	var (
+		errs      []error
+		publishes []func()
+	)
+
+	for _, target := range rld.targets {
+		publish, err := target(vars, rld.gen)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		publishes = append(publishes, publish)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+
+	return publishes, nil
+}
+
+// setLogger sets the logger for reporting background reloads. The logger itself may depend on reloadable
+// configuration so it cannot be a dependency of the reloader.
+func (rld *Reloader) setLogger(logs *zap.Logger) {
+	if logs != nil {
+		rld.logs.Store(logs.Named("reloader"))
+	}
+}
+
+func (rld *Reloader) register(target reloadTarget) {
+	rld.mu.Lock()
+	defer rld.mu.Unlock()
+
+	rld.targets = append(rld.targets, target)
+}
+
+// Reloadable holds configuration T that can change during the lifetime of the process.
+type Reloadable[T any] struct {
+	cur atomic.Pointer[T]
+
+	mu   sync.Mutex
+	subs map[int]func(T)
+	next int
+	gen  uint64
+}
+
+// Get returns the current configuration.
+func (r *Reloadable[T]) Get() T { return *r.cur.Load() }
+
+// Subscribe calls fn with every new configuration value, after it has been published. It returns a function that
+// stops the subscription.
+func (r *Reloadable[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.next
+	r.next++
+	r.subs[id] = fn
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		delete(r.subs, id)
+	}
+}
+
+// publish stores the new value and notifies subscribers, if it is different from the current value and not older
+// than it. Subscribers are called without holding the lock.
+func (r *Reloadable[T]) publish(val T, gen uint64) {
+	r.mu.Lock()
+
+	if gen <= r.gen || reflect.DeepEqual(*r.cur.Load(), val) {
+		r.mu.Unlock()
+
+		return
+	}
+
+	r.gen = gen
+	r.cur.Store(&val)
+
+	ids := slices.Sorted(maps.Keys(r.subs))
+	subs := make([]func(T), 0, len(ids))
+
+	for _, id := range ids {
+		subs = append(subs, r.subs[id])
+	}
+
+	r.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(val)
+	}
+}
+
+// ProvideReloader provides the [Reloader] that is required by any [Reloadable] configuration. It is configured from
+// the environment with the "STDENVCFG_RELOAD_" prefix.
+func ProvideReloader() fx.Option {
+	return fx.Options(
+		Provide[ReloaderConfig]("STDENVCFG_RELOAD_"),
+		fx.Provide(NewReloader),
+		fx.Invoke(fx.Annotate((*Reloader).setLogger, fx.ParamTags(``, `optional:"true"`))),
+	)
+}
+
+// ProvideReloadable provides configuration T as a [Reloadable] that parses the environment with an optional prefix.
+// The initial value is validated during boot like any other configuration. Reloaded values that fail validation are
+// never published.
+func ProvideReloadable[T any](prefix ...string) fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(
+			func(envo env.Options, vars Environment, vld Validated, rld *Reloader) (*Reloadable[T], error) {
+				cfg, err := parseValidated[T](envo, vars, vld, prefix...)
+				if err != nil {
+					return nil, err
+				}
+
+				rel := &Reloadable[T]{subs: map[int]func(T){}}
+				rel.cur.Store(&cfg)
+
+				name := checkName[T](prefix...)
+				rld.register(func(vars Environment, gen uint64) (func(), error) {
+					cfg, err := parse[T](envo, vars, prefix...)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %w", name, err)
+					}
+
+					if err := Validate(cfg, effectivePrefix(envo, prefix...)); err != nil {
+						return nil, fmt.Errorf("%s: %w", name, err)
+					}
+
+					return func() { rel.publish(cfg, gen) }, nil
+				})
+
+				return rel, nil
+			},
+			fx.ParamTags(`optional:"true"`, ``, `optional:"true"`))),
+		provideCheck[T](prefix...),
+	)
+}
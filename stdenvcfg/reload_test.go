@@ -0,0 +1,111 @@
+package stdenvcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestReloadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.env")
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=8080"), 0o600))
+
+	var deps struct {
+		fx.In
+		Reloader *stdenvcfg.Reloader
+		Cfg      *stdenvcfg.Reloadable[Conf3]
+	}
+
+	app := fxtest.New(t,
+		fx.Populate(&deps),
+		stdenvcfg.ProvideLayeredEnvironment(
+			stdenvcfg.DefaultsLayer(map[string]string{"STDENVCFG_RELOAD_ON_SIGHUP": "true"}),
+			stdenvcfg.FileLayer(path)),
+		stdenvcfg.ProvideReloader(),
+		stdenvcfg.ProvideReloadable[Conf3]("APP_"),
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	assert.Equal(t, 8080, deps.Cfg.Get().Port)
+
+	published := make(chan Conf3, 10)
+	unsubscribe := deps.Cfg.Subscribe(func(c Conf3) { published <- c })
+
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=9090"), 0o600))
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+	assert.Equal(t, 9090, deps.Cfg.Get().Port)
+	assert.Equal(t, 9090, (<-published).Port)
+
+	// unchanged values are not published again.
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+	assert.Empty(t, published)
+
+	// invalid values are never published.
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=70000"), 0o600))
+	require.ErrorContains(t, deps.Reloader.Reload(t.Context()), "APP_PORT: value 70000 must be at most 65535")
+	assert.Equal(t, 9090, deps.Cfg.Get().Port)
+
+	// reload on SIGHUP.
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=7070"), 0o600))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case cfg := <-published:
+		assert.Equal(t, 7070, cfg.Port)
+	case <-time.After(time.Second * 5):
+		t.Fatal("no reload on SIGHUP")
+	}
+
+	unsubscribe()
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=6060"), 0o600))
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+	assert.Equal(t, 6060, deps.Cfg.Get().Port)
+	assert.Empty(t, published)
+}
+
+func TestReloadableReentrantSubscriber(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.env")
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=8080"), 0o600))
+
+	var deps struct {
+		fx.In
+		Reloader *stdenvcfg.Reloader
+		Cfg      *stdenvcfg.Reloadable[Conf3]
+	}
+
+	app := fxtest.New(t,
+		fx.Populate(&deps),
+		stdenvcfg.ProvideLayeredEnvironment(stdenvcfg.FileLayer(path)),
+		stdenvcfg.ProvideReloader(),
+		stdenvcfg.ProvideReloadable[Conf3]("APP_"),
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	var (
+		ports       []int
+		unsubscribe func()
+	)
+
+	unsubscribe = deps.Cfg.Subscribe(func(c Conf3) {
+		ports = append(ports, c.Port)
+
+		// subscribers may subscribe, unsubscribe and reload without deadlocking.
+		deps.Cfg.Subscribe(func(Conf3) {})()
+		unsubscribe()
+		assert.NoError(t, deps.Reloader.Reload(t.Context()))
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("APP_PORT=9090"), 0o600))
+	require.NoError(t, deps.Reloader.Reload(t.Context()))
+	assert.Equal(t, []int{9090}, ports)
+}
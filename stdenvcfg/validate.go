@@ -0,0 +1,324 @@
+package stdenvcfg
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator can be implemented by configuration structs to perform validation that cannot be expressed with the
+// "validate" struct tag. It is called after all tag rules of the struct have been checked.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes a single configuration value that failed validation.
+type FieldError struct {
+	// Var is the (prefixed) name of the environment variable that holds the invalid value.
+	Var string
+	// Msg describes why the value is invalid.
+	Msg string
+}
+
+func (e *FieldError) Error() string { return e.Var + ": " + e.Msg }
+
+// Validate checks the configuration struct 'cfg' against the rules declared in its "validate" struct tags and calls
+// [Validator.Validate] on every (nested) struct that implements it. The prefix is only used to report the full
+// environment variable name. All violations are returned together as a joined error. The following rules are
+// supported, separated by a comma:
+//
+//   - required: the value must not be the zero value. False is a valid value for a bool, use a *bool to require
+//     that the variable is set.
+//   - required_if=NAME: the value is required when the field with env name NAME is not the zero value.
+//   - excluded_with=NAME: the value must be empty when the field with env name NAME is not the zero value.
+//   - min=N, max=N: bounds for numbers and durations, or the length of strings and slices.
+//   - oneof=a|b|c: the value (or each element) must be one of the listed values.
+//   - scheme=https|kms: the value (or each element) must be a URL with one of the listed schemes.
+//   - regex=EXPR: the value (or each element) must match the expression. Must be the last rule.
+//
+// All rules except "required" and "required_if" are skipped for zero values.
+func Validate(cfg any, prefix string) error {
+	val := reflect.ValueOf(cfg)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("configuration must be a struct, got: %T", cfg)
+	}
+
+	return errors.Join(validateStruct(val, prefix)...)
+}
+
+// validateStruct validates all fields of the struct value and returns every violation.
+func validateStruct(val reflect.Value, prefix string) (errs []error) {
+	typ := val.Type()
+
+	// index the fields by env name so conditional rules can refer to their siblings.
+	siblings := map[string]reflect.Value{}
+	for i := range typ.NumField() {
+		if name := envName(typ.Field(i)); name != "" {
+			siblings[name] = val.Field(i)
+		}
+	}
+
+	for i := range typ.NumField() {
+		field, fval := typ.Field(i), val.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := envName(field)
+		if name == "" {
+			if fval.Kind() == reflect.Struct {
+				errs = append(errs, validateStruct(fval, prefix+field.Tag.Get("envPrefix"))...)
+			}
+
+			continue
+		}
+
+		rules, ok := field.Tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+
+		for _, rule := range splitRules(rules) {
+			if msg := checkRule(rule, indirect(fval), siblings, prefix); msg != "" {
+				errs = append(errs, &FieldError{Var: prefix + name, Msg: msg})
+			}
+		}
+	}
+
+	// allow the struct itself to perform any validation that cannot be declared with tags.
+	ptr := reflect.New(typ)
+	ptr.Elem().Set(val)
+
+	if vld, ok := ptr.Interface().(Validator); ok {
+		if err := vld.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// checkRule checks a single rule and returns a description of the violation, or an empty string.
+func checkRule(rule string, fval reflect.Value, siblings map[string]reflect.Value, prefix string) string {
+	kind, arg, _ := strings.Cut(rule, "=")
+
+	switch kind {
+	case "required":
+		if isZero(fval) && fval.Kind() != reflect.Bool {
+			return "is required"
+		}
+
+		return ""
+	case "required_if":
+		if sib, ok := siblings[arg]; ok && !isZero(indirect(sib)) && isZero(fval) {
+			return "is required when " + prefix + arg + " is set"
+		}
+
+		return ""
+	case "excluded_with":
+		if sib, ok := siblings[arg]; ok && !isZero(indirect(sib)) && !isZero(fval) {
+			return "cannot be set together with " + prefix + arg
+		}
+
+		return ""
+	}
+
+	if isZero(fval) {
+		return ""
+	}
+
+	switch kind {
+	case "min", "max":
+		return checkBound(kind, arg, fval)
+	case "oneof":
+		options := strings.Split(arg, "|")
+		for _, elem := range elements(fval) {
+			if s := fmt.Sprint(elem.Interface()); !slices.Contains(options, s) {
+				return fmt.Sprintf("value %q must be one of: %s", s, strings.Join(options, ", "))
+			}
+		}
+	case "scheme":
+		schemes := strings.Split(arg, "|")
+		for _, elem := range elements(fval) {
+			u, err := toURL(elem)
+			if err != nil {
+				return fmt.Sprintf("is not a valid URL: %v", err)
+			}
+
+			if !slices.Contains(schemes, u.Scheme) {
+				return fmt.Sprintf("URL scheme %q must be one of: %s", u.Scheme, strings.Join(schemes, ", "))
+			}
+		}
+	case "regex":
+		expr, err := regexp.Compile(arg)
+		if err != nil {
+			return fmt.Sprintf("invalid regex rule %q: %v", arg, err)
+		}
+
+		for _, elem := range elements(fval) {
+			if s := fmt.Sprint(elem.Interface()); !expr.MatchString(s) {
+				return fmt.Sprintf("value %q must match: %s", s, arg)
+			}
+		}
+	default:
+		return fmt.Sprintf("unsupported validation rule %q", kind)
+	}
+
+	return ""
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// checkBound checks a "min" or "max" rule for numbers, durations and the length of strings, slices and maps.
+func checkBound(kind, arg string, fval reflect.Value) string {
+	var cmp int
+
+	switch {
+	case fval.Type() == durationType:
+		bound, err := time.ParseDuration(arg)
+		if err != nil {
+			return fmt.Sprintf("invalid duration for %s rule: %v", kind, err)
+		}
+
+		cmp = compare(time.Duration(fval.Int()), bound)
+	case fval.CanInt():
+		bound, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid integer for %s rule: %v", kind, err)
+		}
+
+		cmp = compare(fval.Int(), bound)
+	case fval.CanUint():
+		bound, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid integer for %s rule: %v", kind, err)
+		}
+
+		cmp = compare(fval.Uint(), bound)
+	case fval.CanFloat():
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid number for %s rule: %v", kind, err)
+		}
+
+		cmp = compare(fval.Float(), bound)
+	case fval.Kind() == reflect.String, fval.Kind() == reflect.Slice, fval.Kind() == reflect.Map:
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Sprintf("invalid length for %s rule: %v", kind, err)
+		}
+
+		if kind == "min" && fval.Len() < bound {
+			return fmt.Sprintf("length must be at least %d", bound)
+		} else if kind == "max" && fval.Len() > bound {
+			return fmt.Sprintf("length must be at most %d", bound)
+		}
+
+		return ""
+	default:
+		return fmt.Sprintf("%s rule is not supported for type %s", kind, fval.Type())
+	}
+
+	if kind == "min" && cmp < 0 {
+		return fmt.Sprintf("value %v must be at least %s", fval.Interface(), arg)
+	} else if kind == "max" && cmp > 0 {
+		return fmt.Sprintf("value %v must be at most %s", fval.Interface(), arg)
+	}
+
+	return ""
+}
+
+func compare[T int64 | uint64 | float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toURL turns a string, url.URL or *url.URL value into a URL.
+func toURL(val reflect.Value) (*url.URL, error) {
+	switch v := val.Interface().(type) {
+	case url.URL:
+		return &v, nil
+	case *url.URL:
+		return v, nil
+	default:
+		return url.Parse(fmt.Sprint(v))
+	}
+}
+
+// elements returns the elements of a slice value, or the value itself for any other kind. Byte slices are considered
+// a single value.
+func elements(val reflect.Value) []reflect.Value {
+	if val.Kind() != reflect.Slice || val.Type().Elem().Kind() == reflect.Uint8 {
+		return []reflect.Value{val}
+	}
+
+	elems := make([]reflect.Value, 0, val.Len())
+	for i := range val.Len() {
+		elems = append(elems, indirect(val.Index(i)))
+	}
+
+	return elems
+}
+
+// indirect dereferences non-nil pointers.
+func indirect(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	return val
+}
+
+func isZero(val reflect.Value) bool {
+	return !val.IsValid() || val.IsZero()
+}
+
+// envName returns the name of the environment variable the field is parsed from, or empty if it has none or is
+// ignored with `env:"-"`.
+func envName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// splitRules splits the validation tag into its rules. The regex rule consumes the remainder of the tag so that the
+// expression may contain commas.
+func splitRules(tag string) (rules []string) {
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(rules, tag)
+		}
+
+		var rule string
+		rule, tag, _ = strings.Cut(tag, ",")
+
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
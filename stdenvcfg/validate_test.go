@@ -0,0 +1,148 @@
+package stdenvcfg_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+type Conf2 struct {
+	Enabled    bool          `env:"ENABLED"`
+	KekURI     string        `env:"KEYSET_KEK_URI" validate:"required_if=ENABLED,scheme=aws-kms|gcp-kms"`
+	Timeout    time.Duration `env:"TIMEOUT"        validate:"min=1s,max=1m"`
+	Workers    int           `env:"WORKERS"        validate:"min=1,max=10"`
+	Mode       string        `env:"MODE"           validate:"oneof=fast|safe"`
+	Region     string        `env:"REGION"         validate:"regex=^[a-z]{2}-[a-z]+-[0-9]{1,2}$"`
+	Endpoint   url.URL       `env:"ENDPOINT"       validate:"scheme=https"`
+	Origins    []string      `env:"ORIGINS"        validate:"min=1,scheme=http|https"`
+	PlainToken string        `env:"PLAIN_TOKEN"    validate:"excluded_with=KEYSET_KEK_URI"`
+
+	Nested Conf2Nested `envPrefix:"NESTED_"`
+}
+
+type Conf2Nested struct {
+	Name string `env:"NAME" validate:"required"`
+}
+
+func (c Conf2Nested) Validate() error {
+	if c.Name == "forbidden" {
+		return errors.New("name is forbidden")
+	}
+
+	return nil
+}
+
+func TestValidateOK(t *testing.T) {
+	require.NoError(t, stdenvcfg.Validate(Conf2{
+		Enabled:  true,
+		KekURI:   "aws-kms://arn/key/abc",
+		Timeout:  time.Second * 10,
+		Workers:  5,
+		Mode:     "safe",
+		Region:   "eu-central-1",
+		Endpoint: url.URL{Scheme: "https", Host: "example.com"},
+		Origins:  []string{"https://example.com", "http://localhost:3000"},
+		Nested:   Conf2Nested{Name: "foo"},
+	}, "FOO_"))
+}
+
+func TestValidateViolations(t *testing.T) {
+	err := stdenvcfg.Validate(&Conf2{
+		Enabled:    true,
+		Timeout:    time.Minute * 2,
+		Workers:    -1,
+		Mode:       "slow",
+		Region:     "Europe",
+		Endpoint:   url.URL{Scheme: "http", Host: "example.com"},
+		Origins:    []string{"ftp://example.com"},
+		PlainToken: "secret",
+		Nested:     Conf2Nested{Name: "forbidden"},
+	}, "FOO_")
+	require.Error(t, err)
+
+	assert.Equal(t, `FOO_KEYSET_KEK_URI: is required when FOO_ENABLED is set
+FOO_TIMEOUT: value 2m0s must be at most 1m
+FOO_WORKERS: value -1 must be at least 1
+FOO_MODE: value "slow" must be one of: fast, safe
+FOO_REGION: value "Europe" must match: ^[a-z]{2}-[a-z]+-[0-9]{1,2}$
+FOO_ENDPOINT: URL scheme "http" must be one of: https
+FOO_ORIGINS: URL scheme "ftp" must be one of: http, https
+name is forbidden`, err.Error())
+
+	var ferr *stdenvcfg.FieldError
+	require.ErrorAs(t, err, &ferr)
+	assert.Equal(t, "FOO_KEYSET_KEK_URI", ferr.Var)
+}
+
+func TestValidateExcludedAndNestedRequired(t *testing.T) {
+	err := stdenvcfg.Validate(Conf2{KekURI: "gcp-kms://foo", PlainToken: "secret"}, "")
+	require.EqualError(t, err, `PLAIN_TOKEN: cannot be set together with KEYSET_KEK_URI
+NESTED_NAME: is required`)
+}
+
+type Conf3 struct {
+	Port int `env:"PORT" validate:"max=65535"`
+}
+
+func TestValidateOnBootAggregated(t *testing.T) {
+	var invoked bool
+
+	app := fx.New(
+		stdenvcfg.Provide[Conf2]("A_"),
+		stdenvcfg.ProvideNamed[Conf3]("b", "B_"),
+		stdenvcfg.ProvideExplicitEnvironment(map[string]string{
+			"A_ENABLED":     "true",
+			"A_NESTED_NAME": "foo",
+			"B_B_PORT":      "70000",
+		}),
+		fx.Invoke(func(Conf2) { invoked = true }),
+	)
+
+	require.ErrorContains(t, app.Err(), `invalid configuration:
+stdenvcfg_test.Conf2 (A_): A_KEYSET_KEK_URI: is required when A_ENABLED is set
+stdenvcfg_test.Conf3 (B_B_): B_B_PORT: value 70000 must be at most 65535`)
+	require.False(t, invoked)
+}
+
+func TestValidateOnBootSuppliedEnvironment(t *testing.T) {
+	// an environment that is supplied directly is not parsed into unvalidated configuration.
+	app := fx.New(
+		stdenvcfg.Provide[Conf3](),
+		fx.Supply(stdenvcfg.Environment{"PORT": "70000"}),
+		fx.Invoke(func(Conf3) {}),
+	)
+	require.ErrorContains(t, app.Err(), "PORT: value 70000 must be at most 65535")
+
+	var cfg Conf3
+	app = fx.New(
+		stdenvcfg.Provide[Conf3](),
+		fx.Supply(stdenvcfg.Environment{"PORT": "8080"}),
+		fx.Populate(&cfg),
+	)
+	require.NoError(t, app.Err())
+	require.Equal(t, 8080, cfg.Port)
+
+	app = fx.New(
+		stdenvcfg.Provide[Conf3](),
+		fx.Supply(stdenvcfg.Environment{"PORT": "70000"}),
+		stdenvcfg.ProvideValidation(),
+		fx.Invoke(func(Conf3) {}),
+	)
+	require.ErrorContains(t, app.Err(), "PORT: value 70000 must be at most 65535")
+}
+
+func TestValidateRequiredBool(t *testing.T) {
+	type conf struct {
+		Enabled bool  `env:"ENABLED" validate:"required"`
+		Debug   *bool `env:"DEBUG"   validate:"required"`
+	}
+
+	require.NoError(t, stdenvcfg.Validate(conf{Debug: new(bool)}, ""), "false is a valid value")
+	require.EqualError(t, stdenvcfg.Validate(conf{}, ""), "DEBUG: is required")
+}
@@ -14,7 +14,7 @@ import (
 // https://uber-go.github.io/fx/result-objects.html.
 func ZapEnvCfgModule[CFG any, P, R any](name string, newf func(P) (R, error), opts ...fx.Option) fx.Option {
 	return fx.Module(name, append(opts,
-		stdenvcfg.Provide[CFG](strcase.ToScreamingSnake(name)+"_"),
+		stdenvcfg.Provide[CFG](envPrefix(name)),
 		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(name) }),
 		fx.Provide(fx.Annotate(newf)),
 	)...)
@@ -23,7 +23,7 @@ func ZapEnvCfgModule[CFG any, P, R any](name string, newf func(P) (R, error), op
 // NamedNoProvideZapEnvCfgModule is like [ZapEnvCfgModule] but does not provide anything by default.
 func NamedNoProvideZapEnvCfgModule[CFG any](moduleName, instanceName string, opts ...fx.Option) fx.Option {
 	return fx.Module(moduleName, append(opts,
-		stdenvcfg.ProvideNamed[CFG](instanceName, strcase.ToScreamingSnake(moduleName)+"_"),
+		stdenvcfg.ProvideNamed[CFG](instanceName, envPrefix(moduleName)),
 		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName) }),
 	)...)
 }
@@ -31,7 +31,12 @@ func NamedNoProvideZapEnvCfgModule[CFG any](moduleName, instanceName string, opt
 // NoProvideZapEnvCfgModule is like [ZapEnvCfgModule] but does not provide anything by default.
 func NoProvideZapEnvCfgModule[CFG any](moduleName string, opts ...fx.Option) fx.Option {
 	return fx.Module(moduleName, append(opts,
-		stdenvcfg.Provide[CFG](strcase.ToScreamingSnake(moduleName)+"_"),
+		stdenvcfg.Provide[CFG](envPrefix(moduleName)),
 		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName) }),
 	)...)
 }
+
+// envPrefix returns the prefix of the environment variables that configure the module with the given name.
+func envPrefix(moduleName string) string {
+	return strcase.ToScreamingSnake(moduleName) + "_"
+}
@@ -0,0 +1,408 @@
+package stdfx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+// Graph describes the constructors of an fx application, grouped by the module they were provided in.
+type Graph struct {
+	// Modules in the order they were first seen, the root module has an empty name.
+	Modules []*Module `json:"modules"`
+}
+
+// Module describes one fx module instance.
+type Module struct {
+	// Name of the module, empty for the root of the application.
+	Name string `json:"name"`
+	// Trace identifies where the module instance was created, followed by the stack that created it.
+	Trace []string `json:"trace,omitempty"`
+	// EnvPrefix is the prefix of environment variables for modules created with this package.
+	EnvPrefix string `json:"env_prefix,omitempty"`
+	// LoggerName is the name of the logger for modules created with this package.
+	LoggerName string `json:"logger_name,omitempty"`
+	// Constructors provided, supplied or decorated in this module.
+	Constructors []*Constructor `json:"constructors"`
+}
+
+// Constructor describes a function that was provided, supplied or decorated.
+type Constructor struct {
+	// ID uniquely identifies the constructor in the graph.
+	ID int `json:"id"`
+	// Kind is either "provide", "supply" or "decorate".
+	Kind string `json:"kind"`
+	// Name of the constructor function.
+	Name string `json:"name"`
+	// Provides lists the types that are produced, including any name or group tag.
+	Provides []string `json:"provides"`
+	// Consumes lists the types that are required, including any name or group tag.
+	Consumes []string `json:"consumes,omitempty"`
+	// Private is true if the constructor is only available in its own module.
+	Private bool `json:"private,omitempty"`
+	// Consumed is true if another constructor consumes any of the types it provides.
+	Consumed bool `json:"consumed"`
+
+	module *Module
+}
+
+// errInspected stops the application before it calls the first invoked function.
+var errInspected = errors.New("stdfx: inspected")
+
+// Inspect describes the constructors of the application that is built from the options, without calling any of
+// them. The graph is built from the events fx emits while the constructors are provided, the application is stopped
+// before the first function passed to fx.Invoke is called. What each constructor consumes is read from the DOT graph
+// of dig, which is best-effort. An error is returned when the application would fail to build, this is determined
+// with a dry run (see fx.ValidateApp) so again nothing is called.
+func Inspect(opts ...fx.Option) (*Graph, error) {
+	rec := &graphRecorder{graph: &Graph{}}
+
+	// the module is first so its invoke runs before any of the application's invokes.
+	fx.New(append([]fx.Option{
+		fx.Module("stdfx_inspect", fx.Invoke(func(dot fx.DotGraph) error {
+			rec.dot = string(dot)
+
+			return errInspected
+		})),
+	}, append(opts, fx.WithLogger(func() fxevent.Logger { return rec }))...)...)
+
+	rec.resolve()
+
+	return rec.graph, fx.ValidateApp(append(opts, fx.NopLogger)...) //nolint:wrapcheck
+}
+
+// Unused returns the constructors of which nothing consumes what they provide. Since invoked functions are never
+// called the graph doesn't know what they consume, the types that are only consumed by invoked functions must be
+// passed as 'invoked' so their constructors are not reported.
+func (g *Graph) Unused(invoked ...string) (unused []*Constructor) {
+	for _, mod := range g.Modules {
+		for _, ctor := range mod.Constructors {
+			if ctor.Kind == "decorate" || ctor.Consumed || strings.HasPrefix(ctor.Name, "go.uber.org/fx.") ||
+				slices.ContainsFunc(ctor.Provides, func(typ string) bool { return slices.Contains(invoked, typ) }) {
+				continue
+			}
+
+			unused = append(unused, ctor)
+		}
+	}
+
+	return unused
+}
+
+// DuplicateModules returns the names of modules that were included more than once.
+func (g *Graph) DuplicateModules() (dups []string) {
+	seen := map[string]int{}
+	for _, mod := range g.Modules {
+		if mod.Name == "" {
+			continue
+		}
+
+		if seen[mod.Name]++; seen[mod.Name] == 2 {
+			dups = append(dups, mod.Name)
+		}
+	}
+
+	return dups
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(g); err != nil {
+		return fmt.Errorf("failed to encode graph: %w", err)
+	}
+
+	return nil
+}
+
+// WriteDOT writes the graph in the DOT language. Each module is drawn as a cluster and every constructor has an edge
+// to the constructors that provide what it consumes.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var buf strings.Builder
+
+	providers := map[string][]*Constructor{}
+
+	buf.WriteString("digraph {\n\trankdir=RL;\n")
+
+	for i, mod := range g.Modules {
+		label := mod.Name
+		if label == "" {
+			label = "(root)"
+		}
+
+		if mod.EnvPrefix != "" {
+			label += "\\nenv: " + mod.EnvPrefix + "*\\nlogger: " + mod.LoggerName
+		}
+
+		fmt.Fprintf(&buf, "\tsubgraph cluster_%d {\n\t\tlabel=%s;\n", i, dotQuote(label))
+
+		for _, ctor := range mod.Constructors {
+			style := ""
+			if !ctor.Consumed {
+				style = " style=dashed"
+			}
+
+			label := ctor.Kind + ": " + ctor.Name + "\\n" + strings.Join(ctor.Provides, "\\n")
+			fmt.Fprintf(&buf, "\t\tc%d [shape=box label=%s%s];\n", ctor.ID, dotQuote(label), style)
+
+			if ctor.Kind != "decorate" {
+				for _, typ := range ctor.Provides {
+					providers[typ] = append(providers[typ], ctor)
+				}
+			}
+		}
+
+		buf.WriteString("\t}\n")
+	}
+
+	for _, mod := range g.Modules {
+		for _, ctor := range mod.Constructors {
+			for _, typ := range ctor.Consumes {
+				for _, prov := range providers[typ] {
+					fmt.Fprintf(&buf, "\tc%d -> c%d [label=%s];\n", ctor.ID, prov.ID, dotQuote(typ))
+				}
+			}
+		}
+	}
+
+	buf.WriteString("}\n")
+
+	if _, err := io.WriteString(w, buf.String()); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+
+	return nil
+}
+
+// AssertGraph fails the test if the application fails to build, if any module is included more than once or if any
+// constructor is unused. The types that are only consumed by the application's invoked functions are passed as
+// 'invoked', see [Graph.Unused]. It returns the graph for further assertions.
+func AssertGraph(tb testing.TB, invoked []string, opts ...fx.Option) *Graph {
+	tb.Helper()
+
+	graph, err := Inspect(opts...)
+
+	for _, name := range graph.DuplicateModules() {
+		tb.Errorf("stdfx: module %q is provided more than once", name)
+	}
+
+	if err != nil {
+		tb.Errorf("stdfx: failed to build application: %v", err)
+
+		return graph
+	}
+
+	for _, ctor := range graph.Unused(invoked...) {
+		tb.Errorf("stdfx: %s %q in module %q is never used, it provides: %s",
+			ctor.Kind, ctor.Name, ctor.module.Name, strings.Join(ctor.Provides, ", "))
+	}
+
+	return graph
+}
+
+// graphRecorder builds the graph from the events that fx emits.
+type graphRecorder struct {
+	graph *Graph
+	ctors []*Constructor
+	dot   string
+}
+
+func (r *graphRecorder) LogEvent(event fxevent.Event) {
+	switch ev := event.(type) {
+	case *fxevent.Provided:
+		r.add(ev.ModuleName, moduleInstance(ev.ModuleName, ev.ModuleTrace, ev.StackTrace), &Constructor{
+			Kind: "provide", Name: ev.ConstructorName, Provides: normalizeTypes(ev.OutputTypeNames...), Private: ev.Private,
+		})
+	case *fxevent.Supplied:
+		r.add(ev.ModuleName, moduleInstance(ev.ModuleName, ev.ModuleTrace, ev.StackTrace), &Constructor{
+			Kind: "supply", Name: "stub(" + ev.TypeName + ")", Provides: normalizeTypes(ev.TypeName),
+		})
+	case *fxevent.Decorated:
+		r.add(ev.ModuleName, moduleInstance(ev.ModuleName, ev.ModuleTrace, ev.StackTrace), &Constructor{
+			Kind: "decorate", Name: ev.DecoratorName, Provides: normalizeTypes(ev.OutputTypeNames...),
+		})
+	}
+}
+
+// moduleInstance identifies the instance of a module by where it was created, and the call stack that lead to it.
+func moduleInstance(moduleName string, moduleTrace, stackTrace []string) []string {
+	if moduleName == "" {
+		return nil
+	}
+
+	idx := slices.IndexFunc(moduleTrace, func(s string) bool { return strings.HasSuffix(s, "("+moduleName+")") })
+	if idx < 0 {
+		return moduleTrace
+	}
+
+	// the module trace doesn't include the callers of the function that created the module, but the stack trace of
+	// what was provided in it does.
+	creator, _, _ := strings.Cut(moduleTrace[idx], " (")
+	for i, frame := range stackTrace {
+		if strings.HasPrefix(frame, creator+" (") {
+			return append([]string{moduleTrace[idx]}, stackTrace[i+1:]...)
+		}
+	}
+
+	return moduleTrace[idx:]
+}
+
+// add records a constructor in the module instance identified by the trace.
+func (r *graphRecorder) add(moduleName string, trace []string, ctor *Constructor) {
+	idx := slices.IndexFunc(r.graph.Modules, func(m *Module) bool {
+		return m.Name == moduleName && (m.Name == "" || slices.Equal(m.Trace, trace))
+	})
+	if idx < 0 {
+		mod := &Module{Name: moduleName}
+		if moduleName != "" {
+			mod.Trace = trace
+		}
+
+		// modules created by this package follow a fixed naming convention.
+		if slices.ContainsFunc(trace, func(s string) bool {
+			return strings.HasPrefix(s, "github.com/advdv/stdgo/stdfx.") && strings.HasSuffix(s, "("+moduleName+")")
+		}) {
+			mod.EnvPrefix, mod.LoggerName = envPrefix(moduleName), moduleName
+		}
+
+		r.graph.Modules = append(r.graph.Modules, mod)
+		idx = len(r.graph.Modules) - 1
+	}
+
+	ctor.ID, ctor.module = len(r.ctors), r.graph.Modules[idx]
+	ctor.module.Constructors = append(ctor.module.Constructors, ctor)
+	r.ctors = append(r.ctors, ctor)
+}
+
+// resolve determines what each constructor consumes from the dig graph, and which constructors are consumed.
+func (r *graphRecorder) resolve() {
+	consumes := parseDigConsumes(r.dot)
+	consumed := map[string]bool{}
+
+	for _, ctor := range r.ctors {
+		if ctor.Kind == "decorate" {
+			continue
+		}
+
+		// constructors with the same results can only be told apart by their name, which dig doesn't report for
+		// annotated functions. Those are matched in the order they were provided.
+		key := strings.Join(ctor.Provides, "|")
+		idx := slices.IndexFunc(consumes[key], func(c digConstructor) bool { return c.name+"()" == ctor.Name })
+		if idx < 0 && len(consumes[key]) > 0 {
+			idx = 0
+		}
+
+		if idx >= 0 {
+			ctor.Consumes = consumes[key][idx].params
+			consumes[key] = slices.Delete(consumes[key], idx, idx+1)
+		}
+
+		for _, typ := range ctor.Consumes {
+			consumed[typ] = true
+		}
+	}
+
+	for _, ctor := range r.ctors {
+		ctor.Consumed = slices.ContainsFunc(ctor.Provides, func(typ string) bool { return consumed[typ] })
+	}
+}
+
+// dotQuote quotes a DOT identifier, it leaves escape sequences such as \n in place.
+func dotQuote(s string) string { return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"` }
+
+var (
+	digPackage = regexp.MustCompile(`^\s*label = "([^"]*)";$`)
+	digCluster = regexp.MustCompile(`constructor_(\d+) \[shape=plaintext label="([^"]*)"`)
+	digResult  = regexp.MustCompile(`^\s*"([^"]+)" \[label=<`)
+	digParam   = regexp.MustCompile(`^\s*constructor_(\d+) -> "([^"]+)"`)
+	digGroup   = regexp.MustCompile(`^\[type=(.+) group=(.+)\]$`)
+	digGrouped = regexp.MustCompile(`^(.+\[group=[^\]]+\])\d+$`)
+)
+
+// digConstructor is a constructor as described by the DOT graph of dig.
+type digConstructor struct {
+	name   string
+	params []string
+}
+
+// parseDigConsumes parses the DOT graph produced by dig. It returns every constructor, keyed by the types the
+// constructor provides. Constructors with the same results are listed in the order they were provided. Anything
+// that is not recognized is skipped, so an unexpected graph yields fewer constructors or parameters.
+func parseDigConsumes(dot string) map[string][]digConstructor {
+	var (
+		ctors   = map[string]*digConstructor{}
+		results = map[string][]string{}
+		order   []string
+		pkg     string
+		current string
+	)
+
+	for line := range strings.SplitSeq(dot, "\n") {
+		if m := digPackage.FindStringSubmatch(line); m != nil {
+			pkg = m[1]
+
+			continue
+		}
+
+		if m := digCluster.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			ctors[current] = &digConstructor{name: pkg + "." + m[2]}
+			order = append(order, current)
+
+			continue
+		}
+
+		if m := digParam.FindStringSubmatch(line); m != nil {
+			if ctor, ok := ctors[m[1]]; ok {
+				ctor.params = append(ctor.params, normalizeDigType(m[2]))
+			}
+
+			continue
+		}
+
+		if m := digResult.FindStringSubmatch(line); m != nil && current != "" && strings.HasPrefix(line, "\t\t\t") {
+			results[current] = append(results[current], normalizeDigType(m[1]))
+		}
+	}
+
+	consumes := map[string][]digConstructor{}
+	for _, id := range order {
+		key := strings.Join(results[id], "|")
+		consumes[key] = append(consumes[key], *ctors[id])
+	}
+
+	return consumes
+}
+
+// normalizeDigType turns the node identifiers of dig's DOT graph into the same format as the fx event types.
+func normalizeDigType(typ string) string {
+	if m := digGroup.FindStringSubmatch(typ); m != nil {
+		return m[1] + "[group=" + m[2] + "]"
+	}
+
+	if m := digGrouped.FindStringSubmatch(typ); m != nil {
+		return m[1]
+	}
+
+	return typ
+}
+
+// normalizeTypes turns fx type names such as `Foo[name = "bar"]` into `Foo[name=bar]`.
+func normalizeTypes(types ...string) []string {
+	out := make([]string, 0, len(types))
+	for _, typ := range types {
+		out = append(out, strings.NewReplacer(` = "`, "=", `"`, "").Replace(typ))
+	}
+
+	return out
+}
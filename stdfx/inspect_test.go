@@ -0,0 +1,133 @@
+package stdfx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/advdv/stdgo/stdenvcfg"
+	"github.com/advdv/stdgo/stdfx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type Unused struct{}
+
+type NamedResult struct {
+	fx.Out
+
+	Bar Bar `name:"named_bar"`
+	Num int `group:"nums"`
+}
+
+type NamedConfig struct {
+	Foo string `env:"FOO"`
+}
+
+type NamedParams struct {
+	fx.In
+
+	Cfg  NamedConfig
+	Logs *zap.Logger
+}
+
+func NewNamed(NamedParams) (NamedResult, error) { return NamedResult{}, nil }
+
+func TestInspect(t *testing.T) {
+	graph, err := stdfx.Inspect(
+		Module1,
+		stdfx.ZapEnvCfgModule[NamedConfig]("named_mod", NewNamed),
+		fx.Provide(zap.NewExample),
+		fx.Provide(func() Unused { return Unused{} }),
+		stdenvcfg.ProvideOSEnvironment(),
+		fx.Invoke(func(Bar) {}),
+	)
+	require.NoError(t, err)
+
+	var mod *stdfx.Module
+	for _, m := range graph.Modules {
+		if m.Name == "named_mod" {
+			mod = m
+		}
+	}
+
+	require.NotNil(t, mod)
+	assert.Equal(t, "NAMED_MOD_", mod.EnvPrefix)
+	assert.Equal(t, "named_mod", mod.LoggerName)
+
+	var ctor *stdfx.Constructor
+	for _, c := range mod.Constructors {
+		if c.Kind == "provide" && c.Name == "fx.Annotate(github.com/advdv/stdgo/stdfx_test.NewNamed()" {
+			ctor = c
+		}
+	}
+
+	require.NotNil(t, ctor)
+	assert.Equal(t, []string{"stdfx_test.Bar[name=named_bar]", "int[group=nums]"}, ctor.Provides)
+	assert.ElementsMatch(t, []string{"stdfx_test.NamedConfig", "*zap.Logger"}, ctor.Consumes)
+	assert.False(t, ctor.Consumed)
+
+	var unused []string
+	for _, c := range graph.Unused("stdfx_test.Bar") {
+		unused = append(unused, c.Provides...)
+	}
+
+	assert.Contains(t, unused, "stdfx_test.Unused")
+	assert.Contains(t, unused, "stdfx_test.Bar[name=named_bar]")
+	assert.NotContains(t, unused, "stdfx_test.Bar")
+	assert.NotContains(t, unused, "*zap.Logger")
+	assert.Empty(t, graph.DuplicateModules())
+
+	var buf bytes.Buffer
+	require.NoError(t, graph.WriteJSON(&buf))
+	require.True(t, json.Valid(buf.Bytes()))
+	assert.Contains(t, buf.String(), `"env_prefix": "NAMED_MOD_"`)
+
+	buf.Reset()
+	require.NoError(t, graph.WriteDOT(&buf))
+	assert.Contains(t, buf.String(), `label="named_mod\nenv: NAMED_MOD_*\nlogger: named_mod"`)
+}
+
+func TestInspectDuplicateModule(t *testing.T) {
+	graph, err := stdfx.Inspect(
+		Module1,
+		stdfx.ZapEnvCfgModule[Config]("foo", New),
+		fx.Provide(zap.NewExample),
+		stdenvcfg.ProvideOSEnvironment(),
+	)
+	require.Error(t, err)
+	assert.Equal(t, []string{"foo"}, graph.DuplicateModules())
+}
+
+func TestInspectCallsNothing(t *testing.T) {
+	graph, err := stdfx.Inspect(
+		Module1,
+		fx.Provide(zap.NewExample),
+		stdenvcfg.ProvideOSEnvironment(),
+		fx.Invoke(func(Bar) { t.Fatal("invoked function must not be called") }),
+		fx.Provide(func() Unused { t.Fatal("constructor must not be called"); return Unused{} }),
+		fx.Invoke(func(Unused) { t.Fatal("invoked function must not be called") }),
+	)
+	require.NoError(t, err)
+	assert.NotEmpty(t, graph.Modules)
+}
+
+func TestInspectMissingDependency(t *testing.T) {
+	_, err := stdfx.Inspect(
+		fx.Provide(zap.NewExample),
+		fx.Invoke(func(Unused) {}),
+	)
+	require.ErrorContains(t, err, "missing type: stdfx_test.Unused")
+}
+
+func TestAssertGraph(t *testing.T) {
+	graph := stdfx.AssertGraph(t, []string{"stdfx_test.Bar", "stdenvcfg.Sources"},
+		Module1,
+		fx.Provide(zap.NewExample),
+		stdenvcfg.ProvideOSEnvironment(),
+		fx.Invoke(func(Bar, stdenvcfg.Sources) {}),
+	)
+	require.NotNil(t, graph)
+}
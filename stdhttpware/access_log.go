@@ -0,0 +1,247 @@
+package stdhttpware
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/netip"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/felixge/httpsnoop"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// Access log schemas.
+const (
+	// AccessLogSchemaDefault logs the request with the fields this package always used.
+	AccessLogSchemaDefault = "default"
+	// AccessLogSchemaECS logs the request with fields named after the Elastic Common Schema.
+	AccessLogSchemaECS = "ecs"
+	// AccessLogSchemaCLF logs the request as a line in the Common Log Format.
+	AccessLogSchemaCLF = "clf"
+)
+
+// AccessLogConfig configures the access log. It can be embedded in a configuration struct that is parsed by
+// stdenvcfg, for example with `envPrefix:"ACCESS_LOG_"`.
+type AccessLogConfig struct {
+	// SkipPaths are path patterns (as matched by path.Match) of requests that are not logged.
+	SkipPaths []string `env:"SKIP_PATHS"`
+	// SkipMethods are the methods of requests that are not logged.
+	SkipMethods []string `env:"SKIP_METHODS"`
+	// SkipUserAgents are prefixes of user agents of requests that are not logged, such as health checkers.
+	SkipUserAgents []string `env:"SKIP_USER_AGENTS" envDefault:"ELB-HealthChecker/,Wget"`
+	// SkipUserAgentsFromAnyAddr also skips user agents of requests from public addresses. By default only requests
+	// from private or loopback addresses are skipped, so the user agent can't be used to hide requests.
+	SkipUserAgentsFromAnyAddr bool `env:"SKIP_USER_AGENTS_FROM_ANY_ADDR"`
+	// SkipStatusClasses are status classes of responses that are not logged, such as "2xx" or "3xx".
+	SkipStatusClasses []string `env:"SKIP_STATUS_CLASSES" validate:"oneof=1xx|2xx|3xx|4xx|5xx"`
+	// Schema determines the fields of the access log.
+	Schema string `env:"SCHEMA" envDefault:"default" validate:"oneof=default|ecs|clf"`
+	// RequestHeaders that are logged, "*" logs all headers.
+	RequestHeaders []string `env:"REQUEST_HEADERS" envDefault:"*"`
+	// ResponseHeaders that are logged, "*" logs all headers.
+	ResponseHeaders []string `env:"RESPONSE_HEADERS" envDefault:"*"`
+	// SuccessSampleRate is the fraction of successful requests that is logged, failed requests are always logged.
+	SuccessSampleRate float64 `env:"SUCCESS_SAMPLE_RATE" envDefault:"1" validate:"min=0,max=1"`
+}
+
+// DefaultAccessLogConfig returns the access log configuration with the same values as the environment defaults.
+func DefaultAccessLogConfig() AccessLogConfig {
+	return AccessLogConfig{
+		SkipUserAgents:    []string{"ELB-HealthChecker/", "Wget"},
+		Schema:            AccessLogSchemaDefault,
+		RequestHeaders:    []string{"*"},
+		ResponseHeaders:   []string{"*"},
+		SuccessSampleRate: 1,
+	}
+}
+
+// NewAccessLogMiddleware adds a zap logger to the request context for all request handling code to use, and logs every
+// request that is not skipped by the configuration. Skipping only drops the access log entry, what the handler logs
+// for a skipped request is still logged.
+func NewAccessLogMiddleware(logs *zap.Logger, cfg AccessLogConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqID := chimiddleware.GetReqID(r.Context())
+			reqLogs := logs.With(
+				zap.String("host", r.Host),
+				zap.String("method", r.Method),
+				zap.String("remote_addr", r.RemoteAddr),
+				zap.String("content_type", r.Header.Get("Content-Type")),
+				zap.String("request_id", reqID),
+				zap.String("request_uri", r.RequestURI))
+
+			skipped := skipRequest(cfg, r)
+
+			// we set the id on the response so we can more easily trace it.
+			w.Header().Set("Sd-Request-Id", reqID)
+
+			body := &countingReader{ReadCloser: r.Body}
+			if r.Body != nil {
+				r.Body = body
+			}
+
+			start := time.Now()
+			ctx := stdctx.WithLogger(r.Context(), reqLogs)
+			m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx)) // call other middleware.
+
+			if skipped || skipResponse(cfg, m.Code) {
+				return
+			}
+
+			entry := accessLogEntry{
+				r: r, reqID: reqID, start: start, status: m.Code, duration: m.Duration,
+				bytesRead: body.n.Load(), bytesWritten: m.Written,
+				reqHeader:  filterHeader(r.Header, cfg.RequestHeaders),
+				respHeader: filterHeader(w.Header(), cfg.ResponseHeaders),
+			}
+
+			switch cfg.Schema {
+			case AccessLogSchemaECS:
+				entry.logECS(logs)
+			case AccessLogSchemaCLF:
+				entry.logCLF(logs)
+			default:
+				entry.logDefault(reqLogs)
+			}
+		})
+	}
+}
+
+// skipRequest determines if the request should not be logged, before it is handled.
+func skipRequest(cfg AccessLogConfig, r *http.Request) bool {
+	if slices.ContainsFunc(cfg.SkipMethods, func(m string) bool { return strings.EqualFold(m, r.Method) }) {
+		return true
+	}
+
+	if slices.ContainsFunc(cfg.SkipPaths, func(p string) bool { ok, _ := path.Match(p, r.URL.Path); return ok }) {
+		return true
+	}
+
+	if !slices.ContainsFunc(cfg.SkipUserAgents, func(ua string) bool { return strings.HasPrefix(r.UserAgent(), ua) }) {
+		return false
+	}
+
+	if cfg.SkipUserAgentsFromAnyAddr {
+		return true
+	}
+
+	// health checkers are expected to run on the internal network, or on the host itself.
+	remote, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		addr, err := netip.ParseAddr(r.RemoteAddr)
+		if err != nil {
+			return false
+		}
+
+		remote = netip.AddrPortFrom(addr, 0)
+	}
+
+	return remote.Addr().IsPrivate() || remote.Addr().IsLoopback()
+}
+
+// skipResponse determines if the request should not be logged, after it has been handled.
+func skipResponse(cfg AccessLogConfig, status int) bool {
+	if slices.Contains(cfg.SkipStatusClasses, strconv.Itoa(status/100)+"xx") {
+		return true
+	}
+
+	// failed requests are always logged, successful requests may be sampled.
+	if status >= http.StatusBadRequest || cfg.SuccessSampleRate >= 1 {
+		return false
+	}
+
+	return rand.Float64() >= cfg.SuccessSampleRate //nolint:gosec // no need for a secure random number.
+}
+
+// filterHeader returns the headers in the allow-list, or all headers if the list contains "*".
+func filterHeader(hdr http.Header, allow []string) http.Header {
+	if slices.Contains(allow, "*") {
+		return hdr
+	}
+
+	filtered := http.Header{}
+	for _, name := range allow {
+		if vals := hdr.Values(name); len(vals) > 0 {
+			filtered[http.CanonicalHeaderKey(name)] = vals
+		}
+	}
+
+	return filtered
+}
+
+// accessLogEntry holds what is logged about a request.
+type accessLogEntry struct {
+	r                     *http.Request
+	reqID                 string
+	start                 time.Time
+	status                int
+	duration              time.Duration
+	bytesRead             int64
+	bytesWritten          int64
+	reqHeader, respHeader http.Header
+}
+
+func (e accessLogEntry) logDefault(logs *zap.Logger) {
+	logs.Info("request",
+		zap.Any("request_header", e.reqHeader),
+		zap.Any("response_header", e.respHeader),
+		zap.Int("status", e.status),
+		zap.Int64("bytes_read", e.bytesRead),
+		zap.Int64("bytes_written", e.bytesWritten),
+		zap.Duration("duration", e.duration))
+}
+
+func (e accessLogEntry) logECS(logs *zap.Logger) {
+	logs.Info("request",
+		zap.String("http.request.id", e.reqID),
+		zap.String("http.request.method", e.r.Method),
+		zap.Int64("http.request.body.bytes", e.bytesRead),
+		zap.Any("http.request.headers", e.reqHeader),
+		zap.Int("http.response.status_code", e.status),
+		zap.Int64("http.response.body.bytes", e.bytesWritten),
+		zap.Any("http.response.headers", e.respHeader),
+		zap.String("url.original", e.r.RequestURI),
+		zap.String("url.domain", e.r.Host),
+		zap.String("client.address", e.r.RemoteAddr),
+		zap.String("user_agent.original", e.r.UserAgent()),
+		zap.Int64("event.duration", e.duration.Nanoseconds()))
+}
+
+func (e accessLogEntry) logCLF(logs *zap.Logger) {
+	host := e.r.RemoteAddr
+	if ap, err := netip.ParseAddrPort(host); err == nil {
+		host = ap.Addr().String()
+	}
+
+	user := "-"
+	if u, _, ok := e.r.BasicAuth(); ok && u != "" {
+		user = u
+	}
+
+	logs.Info(fmt.Sprintf("%s - %s [%s] %q %d %d",
+		host, user, e.start.Format("02/Jan/2006:15:04:05 -0700"),
+		e.r.Method+" "+e.r.RequestURI+" "+e.r.Proto, e.status, e.bytesWritten),
+		zap.String("request_id", e.reqID))
+}
+
+// countingReader counts the bytes read from the request body.
+type countingReader struct {
+	io.ReadCloser
+	n atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n.Add(int64(n))
+
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,158 @@
+package stdhttpware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdhttpware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func setupAccessLog(base http.Handler, cfg stdhttpware.AccessLogConfig) (*observer.ObservedLogs, http.Handler) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	chain := stdhttpware.Apply(base, zap.New(core), stdhttpware.WithAccessLog(cfg))
+	return logs, chain
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skip rules", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultAccessLogConfig()
+		cfg.SkipPaths = []string{"/livez", "/static/*"}
+		cfg.SkipMethods = []string{"OPTIONS"}
+		cfg.SkipStatusClasses = []string{"3xx"}
+
+		for _, tc := range []struct {
+			method, path, userAgent, remoteAddr string
+			status                              int
+			logged                              bool
+		}{
+			{method: http.MethodGet, path: "/foo", status: http.StatusOK, logged: true},
+			{method: http.MethodGet, path: "/livez", status: http.StatusOK},
+			{method: http.MethodGet, path: "/static/app.js", status: http.StatusOK},
+			{method: http.MethodGet, path: "/static/js/app.js", status: http.StatusOK, logged: true},
+			{method: http.MethodOptions, path: "/foo", status: http.StatusOK},
+			{method: http.MethodGet, path: "/foo", status: http.StatusFound},
+			{method: http.MethodGet, path: "/foo", userAgent: "Wget", remoteAddr: "127.0.0.1:1234", status: http.StatusOK},
+			{
+				method: http.MethodGet, path: "/foo", userAgent: "Wget", remoteAddr: "8.8.8.8:1234",
+				status: http.StatusOK, logged: true,
+			},
+		} {
+			logs, chain := setupAccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				stdctx.Log(r.Context()).Info("handled")
+				w.WriteHeader(tc.status)
+			}), cfg)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), tc.method, tc.path, nil)
+			req.Header.Set("User-Agent", tc.userAgent)
+			if tc.remoteAddr != "" {
+				req.RemoteAddr = tc.remoteAddr
+			}
+
+			chain.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.logged, logs.FilterMessage("request").Len() == 1, "%s %s %s", tc.method, tc.path, tc.userAgent)
+			require.Equal(t, 1, logs.FilterMessage("handled").Len(), "the handler logs of skipped requests are kept")
+		}
+	})
+
+	t.Run("size fields and header allow-list", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultAccessLogConfig()
+		cfg.RequestHeaders = []string{"x-foo"}
+		cfg.ResponseHeaders = nil
+
+		logs, chain := setupAccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buf := make([]byte, 100)
+			n, _ := r.Body.Read(buf)
+			_, _ = w.Write(buf[:n])
+		}), cfg)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/foo", strings.NewReader("hello"))
+		req.Header.Set("X-Foo", "foo")
+		req.Header.Set("X-Bar", "bar")
+
+		chain.ServeHTTP(rec, req)
+
+		entries := logs.FilterMessage("request").All()
+		require.Len(t, entries, 1)
+
+		fields := entries[0].ContextMap()
+		require.Equal(t, int64(5), fields["bytes_read"])
+		require.Equal(t, int64(5), fields["bytes_written"])
+		require.Equal(t, http.Header{"X-Foo": {"foo"}}, fields["request_header"])
+		require.Equal(t, http.Header{}, fields["response_header"])
+	})
+
+	t.Run("ecs schema", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultAccessLogConfig()
+		cfg.Schema = stdhttpware.AccessLogSchemaECS
+
+		logs, chain := setupAccessLog(&spyHandler{}, cfg)
+		chain.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/foo?bar=1", nil))
+
+		entries := logs.FilterMessage("request").All()
+		require.Len(t, entries, 1)
+
+		fields := entries[0].ContextMap()
+		require.Equal(t, "GET", fields["http.request.method"])
+		require.Equal(t, int64(200), fields["http.response.status_code"])
+		require.Equal(t, "/foo?bar=1", fields["url.original"])
+		require.NotContains(t, fields, "request_uri")
+	})
+
+	t.Run("clf schema", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultAccessLogConfig()
+		cfg.Schema = stdhttpware.AccessLogSchemaCLF
+
+		logs, chain := setupAccessLog(&spyHandler{}, cfg)
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/foo", nil)
+		req.SetBasicAuth("alice", "secret")
+		chain.ServeHTTP(httptest.NewRecorder(), req)
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		require.Regexp(t, `^192\.0\.2\.1 - alice \[.+\] "GET /foo HTTP/1\.1" 200 2$`, entries[0].Message)
+	})
+
+	t.Run("sample successful requests", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultAccessLogConfig()
+		cfg.SuccessSampleRate = 0
+
+		status := http.StatusOK
+		logs, chain := setupAccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}), cfg)
+
+		chain.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil))
+		require.Equal(t, 0, logs.FilterMessage("request").Len())
+
+		status = http.StatusInternalServerError
+		chain.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil))
+		require.Equal(t, 1, logs.FilterMessage("request").Len())
+	})
+}
@@ -0,0 +1,158 @@
+package stdhttpware
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/advdv/stdgo/stdctx"
+)
+
+// ClientIPConfig configures how the IP of the client is resolved. It can be embedded in a configuration struct that is
+// parsed by stdenvcfg, for example with `envPrefix:"CLIENT_IP_"`.
+type ClientIPConfig struct {
+	// TrustedProxies are the networks of proxies that are trusted to report the address of their peer. By default no
+	// proxy is trusted and the peer of the connection is the client.
+	TrustedProxies []netip.Prefix `env:"TRUSTED_PROXIES"`
+	// ForwardedHeader is the one header that the trusted proxies write the address of their peer to. Other forwarding
+	// headers are ignored, since a proxy passes them through from the client untouched. "Forwarded" is parsed as
+	// RFC 7239, any other header as a comma-separated list of addresses such as "X-Forwarded-For" or "X-Real-Ip".
+	ForwardedHeader string `env:"FORWARDED_HEADER" envDefault:"X-Forwarded-For"`
+}
+
+// DefaultClientIPConfig returns the client IP configuration with the same values as the environment defaults.
+func DefaultClientIPConfig() ClientIPConfig {
+	return ClientIPConfig{ForwardedHeader: "X-Forwarded-For"}
+}
+
+// NewClientIPMiddleware resolves the IP of the client with [ResolveClientIP]. The request's RemoteAddr is replaced
+// by the resolved IP, and the IP and the chain of proxies are added to the context. See [stdctx.ClientIP] and
+// [stdctx.ProxyChain].
+func NewClientIPMiddleware(cfg ClientIPConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, proxies := ResolveClientIP(r, cfg)
+			if ip.IsValid() {
+				r.RemoteAddr = ip.String()
+				r = r.WithContext(stdctx.WithClientIP(r.Context(), ip, proxies))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ResolveClientIP determines the IP of the client that sent the request. The forwarded header of the configuration is
+// only considered if the peer is a trusted proxy. Its chain of addresses is then walked from the right, and the first
+// address that is not a trusted proxy is the client. The proxies are returned in the order the request passed through
+// them. An invalid IP is returned if the RemoteAddr of the request can't be parsed.
+func ResolveClientIP(r *http.Request, cfg ClientIPConfig) (ip netip.Addr, proxies []netip.Addr) {
+	peer, ok := parseHop(r.RemoteAddr)
+	if !ok {
+		return netip.Addr{}, nil
+	}
+
+	isTrusted := func(addr netip.Addr) bool {
+		return slices.ContainsFunc(cfg.TrustedProxies, func(p netip.Prefix) bool { return p.Contains(addr) })
+	}
+
+	if !isTrusted(peer) {
+		return peer, nil
+	}
+
+	hops := forwardedHops(r.Header, cfg.ForwardedHeader)
+
+	chain := []netip.Addr{peer}
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, ok := parseHop(hops[i])
+		if !ok {
+			break // a trusted proxy reported something we can't use, the proxy itself is the best we know.
+		}
+
+		if !isTrusted(addr) {
+			slices.Reverse(chain)
+
+			return addr, chain
+		}
+
+		chain = append(chain, addr)
+	}
+
+	// every address is a trusted proxy, the one furthest away is the client.
+	ip, chain = chain[len(chain)-1], chain[:len(chain)-1]
+	slices.Reverse(chain)
+
+	if len(chain) == 0 {
+		return ip, nil
+	}
+
+	return ip, chain
+}
+
+// forwardedHops returns the addresses that proxies reported in the header, ordered from the client to the last proxy.
+func forwardedHops(hdr http.Header, name string) (hops []string) {
+	values := hdr.Values(name)
+	if name == "" || len(values) == 0 {
+		return nil
+	}
+
+	if !strings.EqualFold(name, "Forwarded") {
+		for hop := range strings.SplitSeq(strings.Join(values, ","), ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+
+		return hops
+	}
+
+	for _, elem := range splitQuoted(strings.Join(values, ","), ',') {
+		var forAddr string
+		for _, pair := range splitQuoted(elem, ';') {
+			key, val, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			if strings.EqualFold(key, "for") {
+				forAddr = strings.Trim(val, `"`)
+			}
+		}
+
+		hops = append(hops, forAddr) // an element without "for" is kept so it stops the walk.
+	}
+
+	return hops
+}
+
+// splitQuoted splits s on sep, except when sep is inside a quoted string.
+func splitQuoted(s string, sep rune) (parts []string) {
+	var quoted, escaped bool
+
+	start := 0
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// parseHop parses an address as reported by a proxy or as the RemoteAddr of a request. It may include a port, and
+// IPv6 addresses may be enclosed in brackets.
+func parseHop(s string) (netip.Addr, bool) {
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return ap.Addr().Unmap(), true
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
@@ -0,0 +1,150 @@
+package stdhttpware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdhttpware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	t.Parallel()
+
+	xff := stdhttpware.ClientIPConfig{
+		TrustedProxies: []netip.Prefix{
+			netip.MustParsePrefix("127.0.0.0/8"),
+			netip.MustParsePrefix("::1/128"),
+			netip.MustParsePrefix("10.0.0.0/8"),
+			netip.MustParsePrefix("192.168.0.0/16"),
+		},
+		ForwardedHeader: "X-Forwarded-For",
+	}
+
+	fwd, xrip := xff, xff
+	fwd.ForwardedHeader, xrip.ForwardedHeader = "Forwarded", "X-Real-Ip"
+
+	for _, tc := range []struct {
+		name       string
+		cfg        stdhttpware.ClientIPConfig
+		remoteAddr string
+		header     http.Header
+		expIP      string
+		expProxies []string
+	}{
+		{
+			name: "untrusted peer is the client", cfg: xff, remoteAddr: "203.0.113.9:1234",
+			header: http.Header{"X-Forwarded-For": {"1.1.1.1"}, "X-Real-Ip": {"1.1.1.1"}},
+			expIP:  "203.0.113.9",
+		},
+		{
+			name: "no proxy is trusted by default", cfg: stdhttpware.DefaultClientIPConfig(), remoteAddr: "10.0.0.1:1234",
+			header: http.Header{"X-Forwarded-For": {"203.0.113.9"}},
+			expIP:  "10.0.0.1",
+		},
+		{
+			name: "trusted peer without headers", cfg: xff, remoteAddr: "10.0.0.1:1234",
+			expIP: "10.0.0.1",
+		},
+		{
+			name: "spoofed leftmost entries are ignored", cfg: xff, remoteAddr: "10.0.0.1:1234",
+			header: http.Header{"X-Forwarded-For": {"1.1.1.1, 203.0.113.9", "10.0.0.2"}},
+			expIP:  "203.0.113.9", expProxies: []string{"10.0.0.2", "10.0.0.1"},
+		},
+		{
+			name: "all trusted", cfg: xff, remoteAddr: "127.0.0.1:1234",
+			header: http.Header{"X-Forwarded-For": {"192.168.1.5, 10.0.0.2"}},
+			expIP:  "192.168.1.5", expProxies: []string{"10.0.0.2", "127.0.0.1"},
+		},
+		{
+			name: "invalid hop stops the walk", cfg: xff, remoteAddr: "10.0.0.1:1234",
+			header: http.Header{"X-Forwarded-For": {"203.0.113.9, garbage, 10.0.0.2"}},
+			expIP:  "10.0.0.2", expProxies: []string{"10.0.0.1"},
+		},
+		{
+			name: "forwarded header from the client is ignored", cfg: xff, remoteAddr: "10.0.0.1:1234",
+			header: http.Header{
+				"Forwarded":       {`for=198.51.100.7`},
+				"X-Forwarded-For": {"203.0.113.9"},
+			},
+			expIP: "203.0.113.9", expProxies: []string{"10.0.0.1"},
+		},
+		{
+			name: "forwarded header", cfg: fwd, remoteAddr: "10.0.0.1:1234",
+			header: http.Header{
+				"Forwarded":       {`for=198.51.100.7;proto=https, for="[2001:db8::1]:4711";by=10.0.0.1`},
+				"X-Forwarded-For": {"203.0.113.9"},
+			},
+			expIP: "2001:db8::1", expProxies: []string{"10.0.0.1"},
+		},
+		{
+			name: "obfuscated forwarded identifier", cfg: fwd, remoteAddr: "10.0.0.1:1234",
+			header: http.Header{"Forwarded": {`for=198.51.100.7, for=_hidden, for=10.0.0.3`}},
+			expIP:  "10.0.0.3", expProxies: []string{"10.0.0.1"},
+		},
+		{
+			name: "x-real-ip from trusted peer", cfg: xrip, remoteAddr: "[::1]:1234",
+			header: http.Header{"X-Real-Ip": {"203.0.113.9"}, "X-Forwarded-For": {"1.1.1.1"}},
+			expIP:  "203.0.113.9", expProxies: []string{"::1"},
+		},
+		{
+			name: "ipv4 mapped peer", cfg: xff, remoteAddr: "[::ffff:10.0.0.1]:1234",
+			header: http.Header{"X-Forwarded-For": {"203.0.113.9"}},
+			expIP:  "203.0.113.9", expProxies: []string{"10.0.0.1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+			req.RemoteAddr, req.Header = tc.remoteAddr, tc.header
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+
+			ip, proxies := stdhttpware.ResolveClientIP(req, tc.cfg)
+			require.Equal(t, netip.MustParseAddr(tc.expIP), ip)
+
+			var expProxies []netip.Addr
+			for _, p := range tc.expProxies {
+				expProxies = append(expProxies, netip.MustParseAddr(p))
+			}
+
+			require.Equal(t, expProxies, proxies)
+		})
+	}
+}
+
+func TestClientIPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sawRemoteAddr string
+		sawIP         netip.Addr
+		sawProxies    []netip.Addr
+	)
+
+	cfg := stdhttpware.DefaultClientIPConfig()
+	cfg.TrustedProxies = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	chain := stdhttpware.NewClientIPMiddleware(cfg)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sawRemoteAddr = r.RemoteAddr
+			sawIP, _ = stdctx.ClientIP(r.Context())
+			sawProxies = stdctx.ProxyChain(r.Context())
+		}))
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+	chain.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Equal(t, "203.0.113.9", sawRemoteAddr)
+	require.Equal(t, netip.MustParseAddr("203.0.113.9"), sawIP)
+	require.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1")}, sawProxies)
+}
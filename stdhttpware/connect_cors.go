@@ -1,10 +1,10 @@
 package stdhttpware
 
 import (
+	"encoding/json"
 	"net/http"
-	"net/url"
 	"slices"
-	"strings"
+	"sync"
 
 	connectcors "connectrpc.com/cors"
 	"github.com/advdv/stdgo/stdctx"
@@ -12,39 +12,84 @@ import (
 	"go.uber.org/zap"
 )
 
-// RootDomainOfHost returns the "root" part of the host provided.
-func RootDomainOfHost(h string) string {
-	parts := strings.Split(h, ".")
-	root := parts[max(0, len(parts)-2):]
-	return strings.Join(root, ".")
+// NewConnectCORSMiddleware initializes the CORS middleware. In our case CORS is allowed if the origin of the request
+// matches one of the patterns in the whitelist (see [OriginMatcher]), or if it shares the registrable domain of the
+// requests host, i.e: it is a first party request. Invalid patterns are ignored, use [ParseOriginPatterns] to validate
+// them upfront.
+func NewConnectCORSMiddleware(maxAgeSeconds int, whiteList ...string) func(http.Handler) http.Handler {
+	return NewDynamicConnectCORSMiddleware(maxAgeSeconds, func() []string { return whiteList })
 }
 
-// NewConnectCORSMiddleware initializes the CORS middleware. In our case CORS is allowed if the origin of the request is
-// on the same root domain as the requests host, i.e: it is a first party request.
-func NewConnectCORSMiddleware(maxAgeSeconds int, whiteList ...string) func(http.Handler) http.Handler {
+// NewDynamicConnectCORSMiddleware is like [NewConnectCORSMiddleware] but the whitelist is read for every request. This
+// allows the allowed origins to change while the process is running.
+func NewDynamicConnectCORSMiddleware(maxAgeSeconds int, whiteList func() []string) func(http.Handler) http.Handler {
+	matcher := newCachedOriginMatcher(whiteList)
 	corsh := cors.New(cors.Options{
 		AllowOriginVaryRequestFunc: func(r *http.Request, origin string) (bool, []string) {
-			if slices.Contains(whiteList, origin) {
-				return true, nil
+			dec := matcher.get().Explain(r.Host, origin)
+			if dec.Reason == reasonInvalidOrigin {
+				stdctx.Log(r.Context()).Info("invalid origin header received", zap.String("origin", origin))
 			}
 
-			originURL, err := url.Parse(origin)
-			if err != nil {
-				stdctx.Log(r.Context()).Info("invalid origin header received",
-					zap.Error(err), zap.String("origin", origin))
+			stdctx.Log(r.Context()).Debug("cors origin decision",
+				zap.String("origin", dec.Origin),
+				zap.String("host", dec.Host),
+				zap.Bool("allowed", dec.Allowed),
+				zap.String("reason", dec.Reason))
 
-				return false, nil
-			}
-
-			return RootDomainOfHost(r.Host) == RootDomainOfHost(originURL.Host), nil
+			return dec.Allowed, nil
 		},
 		AllowedMethods: connectcors.AllowedMethods(),
 		AllowedHeaders: append(connectcors.AllowedHeaders(),
-			"Authorization", "Cookie"),
-		ExposedHeaders:   connectcors.ExposedHeaders(),
+			"Authorization", "Cookie", "X-Csrf-Token", "Idempotency-Key"),
+		ExposedHeaders:   append(connectcors.ExposedHeaders(), "Idempotent-Replayed"),
 		AllowCredentials: true,
 		MaxAge:           maxAgeSeconds,
 	})
 
 	return corsh.Handler
 }
+
+// NewCORSDebugHandler returns a handler that explains whether an origin is allowed by the whitelist, as JSON. The
+// origin is read from the "origin" query parameter, and the host from the "host" query parameter (defaulting to the
+// host of the request). It should only be mounted where it is not publicly reachable.
+func NewCORSDebugHandler(whiteList func() []string) http.Handler {
+	matcher := newCachedOriginMatcher(whiteList)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.URL.Query().Get("host")
+		if host == "" {
+			host = r.Host
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(matcher.get().Explain(host, r.URL.Query().Get("origin")))
+	})
+}
+
+// cachedOriginMatcher only re-parses the whitelist when it changed.
+type cachedOriginMatcher struct {
+	whiteList func() []string
+
+	mu      sync.Mutex
+	parsed  []string
+	matcher *OriginMatcher
+}
+
+func newCachedOriginMatcher(whiteList func() []string) *cachedOriginMatcher {
+	return &cachedOriginMatcher{whiteList: whiteList}
+}
+
+func (c *cachedOriginMatcher) get() *OriginMatcher {
+	patterns := c.whiteList()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.matcher == nil || !slices.Equal(c.parsed, patterns) {
+		c.matcher, _ = ParseOriginPatterns(patterns...)
+		c.parsed = slices.Clone(patterns)
+	}
+
+	return c.matcher
+}
@@ -64,6 +64,23 @@ func TestCORS(t *testing.T) {
 		require.Equal(t, "http://foo.bar.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
 	})
 
+	t.Run("not-allow-by-public-suffix", func(t *testing.T) {
+		t.Parallel()
+		mw := stdhttpware.NewConnectCORSMiddleware(10)
+		ctx := stdctx.WithLogger(t.Context(), zap.NewNop())
+
+		rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodOptions, "/", nil)
+		req.Host = "api.example.co.uk"
+		req.Header.Add("Access-Control-Request-Headers", "connect-protocol-version,content-type,cookie")
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		req.Header.Set("Origin", "https://evil.co.uk")
+
+		mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Result().StatusCode)
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
 	t.Run("not-allow-invalid-origin", func(t *testing.T) {
 		t.Parallel()
 
@@ -83,4 +100,28 @@ func TestCORS(t *testing.T) {
 
 		require.Len(t, obs.FilterMessage("invalid origin header received").All(), 1)
 	})
+
+	t.Run("allow-by-dynamic-whitelist", func(t *testing.T) {
+		t.Parallel()
+
+		whiteList := []string{}
+		mw := stdhttpware.NewDynamicConnectCORSMiddleware(10, func() []string { return whiteList })
+		ctx := stdctx.WithLogger(t.Context(), zap.NewNop())
+
+		preflight := func() *httptest.ResponseRecorder {
+			rec, req := httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodOptions, "/", nil)
+			req.Header.Add("Access-Control-Request-Headers", "connect-protocol-version,content-type,cookie")
+			req.Header.Set("Access-Control-Request-Method", "GET")
+			req.Header.Set("Origin", "http://localhost:3030")
+
+			mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+
+			return rec
+		}
+
+		require.Empty(t, preflight().Header().Get("Access-Control-Allow-Origin"))
+
+		whiteList = []string{"http://localhost:3030"}
+		require.Equal(t, "http://localhost:3030", preflight().Header().Get("Access-Control-Allow-Origin"))
+	})
 }
@@ -4,20 +4,58 @@ package stdhttpware
 import (
 	"fmt"
 	"net/http"
-	"net/netip"
-	"strings"
 
-	"github.com/advdv/stdgo/stdctx"
-	"github.com/felixge/httpsnoop"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
 )
 
+type options struct {
+	accessLog       AccessLogConfig
+	clientIP        ClientIPConfig
+	securityHeaders SecurityHeadersConfig
+}
+
+// Option configures the middleware.
+type Option func(o *options)
+
+// WithAccessLog configures the access log, instead of using [DefaultAccessLogConfig].
+func WithAccessLog(v AccessLogConfig) Option {
+	return func(o *options) {
+		o.accessLog = v
+	}
+}
+
+func newOptions(opts ...Option) (o options) {
+	o.accessLog, o.clientIP = DefaultAccessLogConfig(), DefaultClientIPConfig()
+	o.securityHeaders = DefaultSecurityHeadersConfig()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithClientIP configures how the client IP is resolved, instead of using [DefaultClientIPConfig].
+func WithClientIP(v ClientIPConfig) Option {
+	return func(o *options) {
+		o.clientIP = v
+	}
+}
+
+// WithSecurityHeaders configures the security headers, instead of using [DefaultSecurityHeadersConfig].
+func WithSecurityHeaders(v SecurityHeadersConfig) Option {
+	return func(o *options) {
+		o.securityHeaders = v
+	}
+}
+
 // Apply applies the middleware in the correct order.
-func Apply(mux http.Handler, logs *zap.Logger) http.Handler {
-	/* ^ */ mux = loggerMiddleware(logs)(mux)
+func Apply(mux http.Handler, logs *zap.Logger, opts ...Option) http.Handler {
+	o := newOptions(opts...)
+	/* ^ */ mux = NewAccessLogMiddleware(logs, o.accessLog)(mux)
 	/* | */ mux = cacheMiddleware()(mux)
-	/* | */ mux = chimiddleware.RealIP(mux)
+	/* | */ mux = NewSecurityHeadersMiddleware(o.securityHeaders)(mux)
+	/* | */ mux = NewClientIPMiddleware(o.clientIP)(mux)
 	/* | */ mux = chimiddleware.RequestID(mux)
 	/* | */ mux = recoverMiddleware(logs)(mux) // outer middleware, recover anything.
 	return mux
@@ -36,49 +74,6 @@ func cacheMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
-// loggerMiddleware adds a zap logger to the reques context for all request handling code to use.
-func loggerMiddleware(logs *zap.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			reqID := chimiddleware.GetReqID(r.Context())
-			logs := logs.With(
-				zap.String("host", r.Host),
-				zap.String("method", r.Method),
-				zap.String("remote_addr", r.RemoteAddr),
-				zap.String("content_type", r.Header.Get("Content-Type")),
-				zap.String("request_id", reqID),
-				zap.String("request_uri", r.RequestURI))
-
-			// we check for a private address, so in that case we hide ELB checks.
-			isRemotePrivate := false
-
-			remoteIPPort, parseAddrErr := netip.ParseAddrPort("172.20.1.200:7948")
-			if parseAddrErr == nil && remoteIPPort.Addr().IsPrivate() {
-				isRemotePrivate = true
-			}
-
-			// in general we wanna show all requests, but the Docker and ALB health checks are too noisy.
-			if r.UserAgent() == "ELB-HealthChecker/2.0" && isRemotePrivate ||
-				r.UserAgent() == "Wget" && strings.HasPrefix(r.RemoteAddr, "127.0.0.1") {
-				logs = zap.NewNop()
-			}
-
-			// we set the id on the response so we can more easily trace it.
-			w.Header().Set("Sd-Request-Id", reqID)
-
-			ctx := stdctx.WithLogger(r.Context(), logs)
-			m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx)) // call other middleware.
-
-			logs.Info("request",
-				zap.Any("request_header", r.Header),
-				zap.Any("response_header", w.Header()),
-				zap.Int("status", m.Code),
-				zap.Int64("bytes_written", m.Written),
-				zap.Duration("duration", m.Duration))
-		})
-	}
-}
-
 // recoverMiddleware initializes middleware to recover from panics and log them.
 func recoverMiddleware(logs *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,218 @@
+package stdhttpware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// RootDomainOfHost returns the registrable domain of the host provided, i.e. the public suffix plus one label
+// according to the embedded Public Suffix List. For "app.example.co.uk" this is "example.co.uk". A port is ignored. IP
+// addresses, single-label hosts such as "localhost", and hosts that are a public suffix themselves are returned as-is.
+func RootDomainOfHost(h string) string {
+	h = strings.ToLower(hostWithoutPort(h))
+	if net.ParseIP(h) != nil {
+		return h
+	}
+
+	root, err := publicsuffix.EffectiveTLDPlusOne(h)
+	if err != nil {
+		return h
+	}
+
+	return root
+}
+
+// hostWithoutPort strips the port from a host, if any.
+func hostWithoutPort(h string) string {
+	if host, _, err := net.SplitHostPort(h); err == nil {
+		return host
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(h, "["), "]")
+}
+
+// reasonInvalidOrigin is the reason for rejecting an origin that can't be parsed.
+const reasonInvalidOrigin = "origin is not a valid URL"
+
+// OriginDecision explains why an origin is allowed or rejected.
+type OriginDecision struct {
+	Origin  string `json:"origin"`
+	Host    string `json:"host"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// originPattern is a single parsed entry of the allowed origins.
+type originPattern struct {
+	raw    string
+	scheme string
+	// host is the exact host, or the parent domain for wildcard patterns.
+	host     string
+	port     string
+	wildcard bool
+	anyPort  bool
+}
+
+// OriginMatcher decides whether a CORS origin is allowed. Origins are allowed if they match one of the configured
+// patterns, or if they share the registrable domain of the request's host (a first-party request). For local
+// development an origin on a loopback host is also first-party when the request's host is a loopback host, on any
+// port.
+//
+// Patterns are one of:
+//   - an exact origin, e.g. "https://app.example.com" or "http://localhost:3030".
+//   - a wildcard for every subdomain of a domain that is not a public suffix, e.g. "https://*.example.co.uk". The
+//     domain itself is not matched.
+//   - any port on a loopback host, for local development: "http://localhost:*", "http://127.0.0.1:*" or
+//     "http://[::1]:*".
+type OriginMatcher struct {
+	patterns []originPattern
+	invalid  []string
+}
+
+// ParseOriginPatterns parses the allowed origin patterns, see [OriginMatcher]. It returns an error that includes
+// every invalid pattern, the matcher then holds the valid patterns only.
+func ParseOriginPatterns(patterns ...string) (*OriginMatcher, error) {
+	var (
+		m    OriginMatcher
+		errs []error
+	)
+
+	for _, raw := range patterns {
+		pat, err := parseOriginPattern(raw)
+		if err != nil {
+			m.invalid = append(m.invalid, raw)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		m.patterns = append(m.patterns, pat)
+	}
+
+	return &m, errors.Join(errs...)
+}
+
+func parseOriginPattern(raw string) (pat originPattern, err error) {
+	pat.raw = raw
+
+	scheme, rest, ok := strings.Cut(strings.ToLower(strings.TrimSpace(raw)), "://")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return pat, fmt.Errorf("origin pattern %q: scheme must be http or https", raw)
+	}
+
+	if rest == "" || strings.ContainsAny(rest, "/?#") {
+		return pat, fmt.Errorf("origin pattern %q: must only have a scheme, host and optional port", raw)
+	}
+
+	pat.scheme, pat.host = scheme, rest
+	if host, port, err := net.SplitHostPort(rest); err == nil {
+		pat.host, pat.port = strings.Trim(host, "[]"), port
+	}
+
+	switch {
+	case pat.port == "*":
+		if !isLoopbackHost(pat.host) {
+			return pat, fmt.Errorf("origin pattern %q: any port is only allowed for loopback hosts", raw)
+		}
+
+		pat.anyPort = true
+	case strings.HasPrefix(pat.host, "*."):
+		pat.host, pat.wildcard = strings.TrimPrefix(pat.host, "*."), true
+		if strings.Contains(pat.host, "*") {
+			return pat, fmt.Errorf("origin pattern %q: only a single leading wildcard label is allowed", raw)
+		}
+
+		if _, err := publicsuffix.EffectiveTLDPlusOne(pat.host); err != nil {
+			return pat, fmt.Errorf("origin pattern %q: wildcard must be below a registrable domain: %w", raw, err)
+		}
+	case strings.Contains(pat.host, "*"):
+		return pat, fmt.Errorf("origin pattern %q: wildcard must be the leading label", raw)
+	}
+
+	return pat, nil
+}
+
+// isLoopbackHost returns true for hosts that always refer to the local machine.
+func isLoopbackHost(h string) bool {
+	if h == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(h)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// Explain decides whether the origin is allowed for a request to the host, and why.
+func (m *OriginMatcher) Explain(host, origin string) (dec OriginDecision) {
+	dec.Origin, dec.Host = origin, host
+
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Host == "" {
+		dec.Reason = reasonInvalidOrigin
+
+		return dec
+	}
+
+	scheme, ohost, oport := strings.ToLower(originURL.Scheme), strings.ToLower(originURL.Hostname()), originURL.Port()
+
+	for _, pat := range m.patterns {
+		if pat.scheme != scheme {
+			continue
+		}
+
+		switch {
+		case pat.anyPort && pat.host == ohost:
+			dec.Allowed, dec.Reason = true, fmt.Sprintf("matches loopback pattern %q", pat.raw)
+		case pat.wildcard && pat.port == oport && strings.HasSuffix(ohost, "."+pat.host):
+			dec.Allowed, dec.Reason = true, fmt.Sprintf("matches wildcard pattern %q", pat.raw)
+		case !pat.anyPort && !pat.wildcard && pat.host == ohost && pat.port == oport:
+			dec.Allowed, dec.Reason = true, fmt.Sprintf("matches exact pattern %q", pat.raw)
+		}
+
+		if dec.Allowed {
+			return dec
+		}
+	}
+
+	if isLoopbackHost(ohost) && isLoopbackHost(hostWithoutPort(strings.ToLower(host))) {
+		dec.Allowed, dec.Reason = true, "first-party: origin and host are both loopback"
+
+		return dec
+	}
+
+	originRoot, hostRoot := RootDomainOfHost(ohost), RootDomainOfHost(host)
+	if originRoot == hostRoot && isRegistrable(originRoot) {
+		dec.Allowed, dec.Reason = true, fmt.Sprintf("first-party: shares registrable domain %q with host", hostRoot)
+
+		return dec
+	}
+
+	dec.Reason = fmt.Sprintf("no pattern matched and registrable domain %q differs from host's %q",
+		originRoot, hostRoot)
+	if originRoot == hostRoot {
+		dec.Reason = fmt.Sprintf("no pattern matched and %q is not a registrable domain", originRoot)
+	}
+
+	if len(m.invalid) > 0 {
+		dec.Reason += fmt.Sprintf(" (invalid patterns ignored: %q)", m.invalid)
+	}
+
+	return dec
+}
+
+// isRegistrable returns true if the domain can be registered, so it is not an IP, a single label or a public suffix.
+func isRegistrable(domain string) bool {
+	if net.ParseIP(domain) != nil {
+		return false
+	}
+
+	root, err := publicsuffix.EffectiveTLDPlusOne(domain)
+
+	return err == nil && root == domain
+}
@@ -0,0 +1,106 @@
+package stdhttpware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/advdv/stdgo/stdhttpware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootDomainOfHost(t *testing.T) {
+	t.Parallel()
+
+	for host, exp := range map[string]string{
+		"example.com":           "example.com",
+		"foo.bar.example.com":   "example.com",
+		"app.example.co.uk":     "example.co.uk",
+		"app.example.co.uk:443": "example.co.uk",
+		"co.uk":                 "co.uk",
+		"foo.github.io":         "foo.github.io",
+		"localhost:3030":        "localhost",
+		"127.0.0.1:8080":        "127.0.0.1",
+		"[::1]:8080":            "::1",
+	} {
+		require.Equal(t, exp, stdhttpware.RootDomainOfHost(host), host)
+	}
+}
+
+func TestParseOriginPatterns(t *testing.T) {
+	t.Parallel()
+
+	_, err := stdhttpware.ParseOriginPatterns(
+		"https://app.example.com", "https://*.example.co.uk", "http://localhost:*", "http://[::1]:*")
+	require.NoError(t, err)
+
+	for _, pat := range []string{
+		"app.example.com",
+		"ftp://example.com",
+		"https://example.com/path",
+		"https://*.co.uk",
+		"https://*.github.io",
+		"https://foo.*.example.com",
+		"https://example.com:*",
+		"*",
+	} {
+		_, err := stdhttpware.ParseOriginPatterns(pat)
+		require.Error(t, err, pat)
+	}
+
+	m, err := stdhttpware.ParseOriginPatterns("https://*.co.uk", "https://app.example.com")
+	require.ErrorContains(t, err, "wildcard must be below a registrable domain")
+	require.True(t, m.Explain("api.example.com", "https://app.example.com").Allowed, "valid patterns are kept")
+}
+
+func TestOriginMatcherExplain(t *testing.T) {
+	t.Parallel()
+
+	m, err := stdhttpware.ParseOriginPatterns(
+		"https://app.partner.com", "https://*.example.co.uk", "http://localhost:*")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		host, origin string
+		allowed      bool
+		reason       string
+	}{
+		{"api.example.org", "https://app.partner.com", true, `matches exact pattern "https://app.partner.com"`},
+		{"api.example.org", "http://app.partner.com", false, "differs from host's"},
+		{"api.example.org", "https://app.partner.com:8443", false, "differs from host's"},
+		{"api.example.org", "https://a.b.example.co.uk", true, "matches wildcard pattern"},
+		{"api.example.org", "https://example.co.uk", false, "differs from host's"},
+		{"api.example.org", "https://evil.co.uk", false, "differs from host's"},
+		{"api.example.org", "http://localhost:5173", true, "matches loopback pattern"},
+		{"api.example.org", "https://localhost:5173", false, "differs from host's"},
+		{"api.example.org", "https://www.example.org", true, `first-party: shares registrable domain "example.org"`},
+		{"api.example.co.uk", "https://evil.co.uk", false, "differs from host's"},
+		{"localhost:8080", "http://127.0.0.1:3030", true, "first-party: origin and host are both loopback"},
+		{"127.0.0.1:8080", "https://[::1]:3030", true, "first-party: origin and host are both loopback"},
+		{"api.example.org", "http://127.0.0.1:3030", false, "differs from host's"},
+		{"co.uk", "https://co.uk", false, `"co.uk" is not a registrable domain`},
+		{"api.example.org", "http://example.com/%2", false, "origin is not a valid URL"},
+	} {
+		dec := m.Explain(tc.host, tc.origin)
+		require.Equal(t, tc.allowed, dec.Allowed, "%s from %s: %s", tc.host, tc.origin, dec.Reason)
+		require.Contains(t, dec.Reason, tc.reason)
+	}
+}
+
+func TestCORSDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	hdlr := stdhttpware.NewCORSDebugHandler(func() []string { return []string{"https://*.example.co.uk"} })
+
+	rec := httptest.NewRecorder()
+	hdlr.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet,
+		"/?origin=https://app.example.co.uk&host=api.example.org", nil))
+
+	var dec stdhttpware.OriginDecision
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&dec))
+	require.Equal(t, stdhttpware.OriginDecision{
+		Origin: "https://app.example.co.uk", Host: "api.example.org", Allowed: true,
+		Reason: `matches wildcard pattern "https://*.example.co.uk"`,
+	}, dec)
+}
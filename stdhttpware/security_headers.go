@@ -0,0 +1,149 @@
+package stdhttpware
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/advdv/stdgo/stdctx"
+)
+
+// CSPNoncePlaceholder is replaced with a fresh nonce for every response, in a Content-Security-Policy. For example:
+// "script-src 'nonce-{nonce}'". The nonce is available to handlers through [stdctx.CSPNonce].
+const CSPNoncePlaceholder = "{nonce}"
+
+// SecurityHeadersConfig configures the security headers that are set on every response. It can be embedded in a
+// configuration struct that is parsed by stdenvcfg, for example with `envPrefix:"SECURITY_HEADERS_"`. Empty values
+// disable the respective header.
+type SecurityHeadersConfig struct {
+	// HSTSMaxAge is how long browsers must only use HTTPS for this host, zero disables Strict-Transport-Security.
+	// It is off by default since it can't be taken back once browsers have seen it, enable it only on hosts that are
+	// always served over HTTPS.
+	HSTSMaxAge time.Duration `env:"HSTS_MAX_AGE"`
+	// HSTSIncludeSubdomains also applies Strict-Transport-Security to every subdomain, including those that are not
+	// served by this application.
+	HSTSIncludeSubdomains bool `env:"HSTS_INCLUDE_SUBDOMAINS"`
+	// HSTSPreload allows the host to be included in the browsers' HSTS preload lists.
+	HSTSPreload bool `env:"HSTS_PRELOAD"`
+	// ContentTypeNoSniff prevents browsers from guessing the content type of responses.
+	ContentTypeNoSniff bool `env:"CONTENT_TYPE_NO_SNIFF" envDefault:"true"`
+	// ReferrerPolicy determines what is sent in the Referer header of requests that originate from our responses.
+	ReferrerPolicy string `env:"REFERRER_POLICY" envDefault:"strict-origin-when-cross-origin"`
+	// PermissionsPolicy determines which browser features our responses may use.
+	PermissionsPolicy string `env:"PERMISSIONS_POLICY" envDefault:"camera=(), microphone=(), geolocation=()"`
+	// FrameOptions determines if our responses may be framed by other pages.
+	FrameOptions string `env:"FRAME_OPTIONS" envDefault:"DENY"`
+	// ContentSecurityPolicy of our responses, it may include the [CSPNoncePlaceholder].
+	ContentSecurityPolicy string `env:"CONTENT_SECURITY_POLICY" envDefault:"default-src 'none'; frame-ancestors 'none'"`
+
+	// PathOverrides relax or tighten the headers for specific paths, such as documentation pages.
+	PathOverrides []SecurityHeadersOverride `env:"-"`
+}
+
+// SecurityHeadersOverride replaces the headers for requests with a path that starts with PathPrefix. The longest
+// matching prefix wins, empty fields keep the value of the [SecurityHeadersConfig].
+type SecurityHeadersOverride struct {
+	PathPrefix            string
+	FrameOptions          string
+	ContentSecurityPolicy string
+}
+
+// DefaultSecurityHeadersConfig returns the security headers configuration with the same values as the environment
+// defaults.
+func DefaultSecurityHeadersConfig() SecurityHeadersConfig {
+	return SecurityHeadersConfig{
+		ContentTypeNoSniff:    true,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+		PermissionsPolicy:     "camera=(), microphone=(), geolocation=()",
+		FrameOptions:          "DENY",
+		ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+	}
+}
+
+// NewSecurityHeadersMiddleware sets the configured security headers on every response. Handlers may still overwrite
+// them. If the Content-Security-Policy includes the [CSPNoncePlaceholder] a fresh nonce is generated for every
+// request and added to the context, see [stdctx.CSPNonce].
+func NewSecurityHeadersMiddleware(cfg SecurityHeadersConfig) func(http.Handler) http.Handler {
+	static := http.Header{}
+	if cfg.HSTSMaxAge > 0 {
+		hsts := "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds()))
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+
+		static.Set("Strict-Transport-Security", hsts)
+	}
+
+	if cfg.ContentTypeNoSniff {
+		static.Set("X-Content-Type-Options", "nosniff")
+	}
+
+	if cfg.ReferrerPolicy != "" {
+		static.Set("Referrer-Policy", cfg.ReferrerPolicy)
+	}
+
+	if cfg.PermissionsPolicy != "" {
+		static.Set("Permissions-Policy", cfg.PermissionsPolicy)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range static {
+				w.Header()[k] = v
+			}
+
+			frameOptions, csp := cfg.FrameOptions, cfg.ContentSecurityPolicy
+			if ovr, ok := matchSecurityHeadersOverride(cfg.PathOverrides, r.URL.Path); ok {
+				if ovr.FrameOptions != "" {
+					frameOptions = ovr.FrameOptions
+				}
+
+				if ovr.ContentSecurityPolicy != "" {
+					csp = ovr.ContentSecurityPolicy
+				}
+			}
+
+			if frameOptions != "" {
+				w.Header().Set("X-Frame-Options", frameOptions)
+			}
+
+			if strings.Contains(csp, CSPNoncePlaceholder) {
+				nonce := newCSPNonce()
+				csp = strings.ReplaceAll(csp, CSPNoncePlaceholder, nonce)
+				r = r.WithContext(stdctx.WithCSPNonce(r.Context(), nonce))
+			}
+
+			if csp != "" {
+				w.Header().Set("Content-Security-Policy", csp)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchSecurityHeadersOverride returns the override with the longest prefix of the path.
+func matchSecurityHeadersOverride(ovrs []SecurityHeadersOverride, path string) (best SecurityHeadersOverride, ok bool) {
+	for _, ovr := range ovrs {
+		if strings.HasPrefix(path, ovr.PathPrefix) && (!ok || len(ovr.PathPrefix) > len(best.PathPrefix)) {
+			best, ok = ovr, true
+		}
+	}
+
+	return best, ok
+}
+
+// newCSPNonce returns 128 bits of randomness, base64 encoded as required by the CSP specification.
+func newCSPNonce() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return base64.StdEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,90 @@
+package stdhttpware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/advdv/stdgo/stdctx"
+	"github.com/advdv/stdgo/stdhttpware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	serve := func(cfg stdhttpware.SecurityHeadersConfig, path string) (http.Header, string) {
+		var nonce string
+
+		rec := httptest.NewRecorder()
+		stdhttpware.NewSecurityHeadersMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nonce, _ = stdctx.CSPNonce(r.Context())
+		})).ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet, path, nil))
+
+		return rec.Header(), nonce
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		hdr, nonce := serve(stdhttpware.DefaultSecurityHeadersConfig(), "/")
+		require.Empty(t, nonce)
+		require.Equal(t, http.Header{
+			"X-Content-Type-Options":  {"nosniff"},
+			"Referrer-Policy":         {"strict-origin-when-cross-origin"},
+			"Permissions-Policy":      {"camera=(), microphone=(), geolocation=()"},
+			"X-Frame-Options":         {"DENY"},
+			"Content-Security-Policy": {"default-src 'none'; frame-ancestors 'none'"},
+		}, hdr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		hdr, _ := serve(stdhttpware.SecurityHeadersConfig{}, "/")
+		require.Empty(t, hdr)
+	})
+
+	t.Run("hsts preload", func(t *testing.T) {
+		t.Parallel()
+
+		hdr, _ := serve(stdhttpware.SecurityHeadersConfig{HSTSMaxAge: time.Hour, HSTSPreload: true}, "/")
+		require.Equal(t, "max-age=3600; preload", hdr.Get("Strict-Transport-Security"))
+	})
+
+	t.Run("hsts include subdomains", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.SecurityHeadersConfig{HSTSMaxAge: 365 * 24 * time.Hour, HSTSIncludeSubdomains: true}
+		hdr, _ := serve(cfg, "/")
+		require.Equal(t, "max-age=31536000; includeSubDomains", hdr.Get("Strict-Transport-Security"))
+	})
+
+	t.Run("nonce and path overrides", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := stdhttpware.DefaultSecurityHeadersConfig()
+		cfg.ContentSecurityPolicy = "script-src 'nonce-{nonce}'"
+		cfg.PathOverrides = []stdhttpware.SecurityHeadersOverride{
+			{PathPrefix: "/o/", ContentSecurityPolicy: "default-src 'self'"},
+			{PathPrefix: "/o/embed/", FrameOptions: "SAMEORIGIN"},
+		}
+
+		hdr1, nonce1 := serve(cfg, "/rpc")
+		hdr2, nonce2 := serve(cfg, "/rpc")
+		require.NotEmpty(t, nonce1)
+		require.NotEqual(t, nonce1, nonce2)
+		require.Equal(t, "script-src 'nonce-"+nonce1+"'", hdr1.Get("Content-Security-Policy"))
+		require.Equal(t, "script-src 'nonce-"+nonce2+"'", hdr2.Get("Content-Security-Policy"))
+
+		hdr, nonce := serve(cfg, "/o/docs")
+		require.Empty(t, nonce)
+		require.Equal(t, "default-src 'self'", hdr.Get("Content-Security-Policy"))
+		require.Equal(t, "DENY", hdr.Get("X-Frame-Options"))
+
+		hdr, _ = serve(cfg, "/o/embed/docs")
+		require.Equal(t, "SAMEORIGIN", hdr.Get("X-Frame-Options"))
+		require.Contains(t, hdr.Get("Content-Security-Policy"), "'nonce-", "longest prefix only overrides frame options")
+	})
+}